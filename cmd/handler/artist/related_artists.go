@@ -0,0 +1,92 @@
+package artist
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"festwrap/internal/artist"
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+)
+
+type RelatedArtistsHandler struct {
+	artistIdPath     string
+	artistRepository artist.ArtistRepository
+	logger           logging.Logger
+	encoder          serialization.Encoder[[]artist.Artist]
+	maxLimit         int
+}
+
+// Returns the artists similar to the one given, as found in Spotify
+func NewRelatedArtistsHandler(
+	artistIdPath string,
+	artistRepository artist.ArtistRepository,
+	logger logging.Logger,
+) RelatedArtistsHandler {
+	encoder := serialization.NewJsonEncoder[[]artist.Artist]()
+	return RelatedArtistsHandler{
+		artistIdPath:     artistIdPath,
+		artistRepository: artistRepository,
+		logger:           logger,
+		encoder:          encoder,
+		maxLimit:         20,
+	}
+}
+
+func (h *RelatedArtistsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	artistId := r.PathValue(h.artistIdPath)
+	if artistId == "" {
+		detail := "artist id was not provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	limit, err := h.readLimit(r)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("validation error: %v", err))
+		problem.Write(w, problem.InvalidParameter(err.Error()))
+		return
+	}
+
+	related, err := h.artistRepository.GetRelatedArtists(r.Context(), artistId)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not retrieve artists related to %s: %v", artistId, err))
+		problem.Write(w, problem.FromError(err, "could not retrieve related artists"))
+		return
+	}
+	if len(related) > limit {
+		related = related[:limit]
+	}
+
+	var body bytes.Buffer
+	if err = h.encoder.Encode(&body, related); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode artists related to %s: %v", artistId, err))
+		problem.Write(w, problem.Internal("could not encode related artists"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func (h *RelatedArtistsHandler) readLimit(r *http.Request) (int, error) {
+	limitStr := r.URL.Query().Get("limit")
+	if limitStr == "" {
+		return h.maxLimit, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > h.maxLimit {
+		return 0, fmt.Errorf("limit must be an integer in interval [1, %d], found %s", h.maxLimit, limitStr)
+	}
+	return limit, nil
+}
+
+func (h *RelatedArtistsHandler) SetEncoder(encoder serialization.Encoder[[]artist.Artist]) {
+	h.encoder = encoder
+}
@@ -0,0 +1,148 @@
+package artist
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"festwrap/internal/artist"
+	artisterrors "festwrap/internal/artist/errors"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	artistId     = "someId"
+	artistIdPath = "artistId"
+)
+
+func relatedArtists() []artist.Artist {
+	first := artist.NewArtistWithImageUri("La Dispute", "https://some_image")
+	first.Id = "lahd"
+	second := artist.NewArtist("Touché Amoré")
+	second.Id = "touche"
+	return []artist.Artist{first, second}
+}
+
+func buildRelatedArtistsRequest(artistId string, query string) *http.Request {
+	url := fmt.Sprintf("https://example.com/artists/%s/related%s", artistId, query)
+	request := httptest.NewRequest("GET", url, nil)
+	request.SetPathValue(artistIdPath, artistId)
+	return request
+}
+
+func relatedArtistsSetup() (RelatedArtistsHandler, *httptest.ResponseRecorder, *artist.FakeArtistRepository) {
+	repository := &artist.FakeArtistRepository{}
+	repository.SetRelatedArtists(artistId, relatedArtists())
+	handler := NewRelatedArtistsHandler(artistIdPath, repository, logging.NoopLogger{})
+	return handler, httptest.NewRecorder(), repository
+}
+
+func TestRelatedArtistsHandlerReturnsBadRequestIfIdNotProvided(t *testing.T) {
+	handler, writer, _ := relatedArtistsSetup()
+
+	handler.ServeHTTP(writer, buildRelatedArtistsRequest("", ""))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestRelatedArtistsHandlerReturnsUnprocessableEntityOnInvalidLimit(t *testing.T) {
+	tests := map[string]struct {
+		query string
+	}{
+		"non integer limit": {query: "?limit=abc"},
+		"zero limit":        {query: "?limit=0"},
+		"limit above max":   {query: "?limit=21"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, writer, _ := relatedArtistsSetup()
+
+			handler.ServeHTTP(writer, buildRelatedArtistsRequest(artistId, test.query))
+
+			assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+		})
+	}
+}
+
+func TestRelatedArtistsHandlerCallsRepositoryWithArtistId(t *testing.T) {
+	handler, writer, repository := relatedArtistsSetup()
+	request := buildRelatedArtistsRequest(artistId, "")
+
+	handler.ServeHTTP(writer, request)
+
+	expected := []artist.GetRelatedArtistsArgs{{Context: request.Context(), ArtistId: artistId}}
+	assert.Equal(t, expected, repository.GetGetRelatedArtistsArgs())
+}
+
+func TestRelatedArtistsHandlerReturnsInternalErrorOnRepositoryError(t *testing.T) {
+	handler, writer, repository := relatedArtistsSetup()
+	repository.SetRelatedArtistsError(errors.New("test error"))
+
+	handler.ServeHTTP(writer, buildRelatedArtistsRequest(artistId, ""))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestRelatedArtistsHandlerReturnsProblemOnUpstreamError(t *testing.T) {
+	handler, writer, repository := relatedArtistsSetup()
+	repository.SetRelatedArtistsError(artisterrors.NewCannotRetrieveArtistsError("test error"))
+
+	handler.ServeHTTP(writer, buildRelatedArtistsRequest(artistId, ""))
+
+	expected := `{"type":"about:blank","title":"Bad Gateway","status":502,` +
+		`"detail":"could not retrieve related artists","code":"artist_unavailable"}` + "\n"
+	assert.Equal(t, http.StatusBadGateway, writer.Code)
+	assert.Equal(t, "application/problem+json", writer.Header().Get("Content-Type"))
+	assert.Equal(t, expected, writer.Body.String())
+}
+
+func TestRelatedArtistsHandlerReturnsInternalErrorOnEncoderError(t *testing.T) {
+	handler, writer, _ := relatedArtistsSetup()
+	encoder := serialization.FakeEncoder[[]artist.Artist]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler.SetEncoder(encoder)
+
+	handler.ServeHTTP(writer, buildRelatedArtistsRequest(artistId, ""))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
+
+func TestRelatedArtistsHandlerReturnsArtists(t *testing.T) {
+	laDispute := `{"id":"lahd","name":"La Dispute","imageUri":"https://some_image","popularity":0,"followers":0}`
+	touche := `{"id":"touche","name":"Touché Amoré","popularity":0,"followers":0}`
+	tests := map[string]struct {
+		query    string
+		expected string
+	}{
+		"all artists by default": {
+			query:    "",
+			expected: fmt.Sprintf("[%s,%s]\n", laDispute, touche),
+		},
+		"limited artists": {
+			query:    "?limit=1",
+			expected: fmt.Sprintf("[%s]\n", laDispute),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, writer, _ := relatedArtistsSetup()
+
+			handler.ServeHTTP(writer, buildRelatedArtistsRequest(artistId, test.query))
+
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+			assert.Equal(t, test.expected, writer.Body.String())
+		})
+	}
+}
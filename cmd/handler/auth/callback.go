@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"festwrap/internal/auth"
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+)
+
+// Finishes the login, exchanging the authorization code for a token which is kept in the session
+type CallbackHandler struct {
+	oauthClient     auth.OAuthClient
+	loginStates     auth.LoginStateStore
+	sessions        auth.SessionStore
+	redirectUri     string
+	successRedirect string
+	logger          logging.Logger
+}
+
+func NewCallbackHandler(
+	oauthClient auth.OAuthClient,
+	loginStates auth.LoginStateStore,
+	sessions auth.SessionStore,
+	redirectUri string,
+	logger logging.Logger,
+) CallbackHandler {
+	return CallbackHandler{
+		oauthClient:     oauthClient,
+		loginStates:     loginStates,
+		sessions:        sessions,
+		redirectUri:     redirectUri,
+		successRedirect: "/",
+		logger:          logger,
+	}
+}
+
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	state, err := h.loginStates.Get(r)
+	// Login states can only be used once, so the callback cannot be replayed
+	h.loginStates.Clear(w)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("login state not found: %v", err))
+		problem.Write(w, problem.MissingParameter("login was not started or has expired"))
+		return
+	}
+
+	query := r.URL.Query()
+	if authErr := query.Get("error"); authErr != "" {
+		detail := fmt.Sprintf("authorization was not granted: %s", authErr)
+		h.logger.Warn(detail)
+		problem.Write(w, problem.New(http.StatusUnauthorized, problem.InvalidCredentialsCode, detail))
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state.State)) != 1 {
+		detail := "state does not match the one of the login"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.InvalidParameter(detail))
+		return
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		detail := "authorization code was not provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	token, err := h.oauthClient.ExchangeCode(code, state.Verifier, h.redirectUri)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not exchange authorization code: %v", err))
+		problem.Write(w, problem.FromError(err, "could not obtain spotify token"))
+		return
+	}
+
+	if err = h.sessions.Save(w, token); err != nil {
+		h.logger.Error(fmt.Sprintf("could not save session: %v", err))
+		problem.Write(w, problem.Internal("could not save session"))
+		return
+	}
+
+	http.Redirect(w, r, h.successRedirect, http.StatusSeeOther)
+}
+
+// Page users are sent to once logged in
+func (h *CallbackHandler) SetSuccessRedirect(url string) {
+	h.successRedirect = url
+}
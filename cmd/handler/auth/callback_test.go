@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+	"festwrap/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loginState() auth.LoginState {
+	return auth.LoginState{State: "some_state", Verifier: "some_verifier"}
+}
+
+func sessionToken() auth.Token {
+	return auth.Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Scopes:       []string{"playlist-modify-public"},
+	}
+}
+
+type callbackTest struct {
+	handler     CallbackHandler
+	client      *auth.FakeOAuthClient
+	loginStates auth.CookieStore[auth.LoginState]
+	sessions    auth.CookieStore[auth.Token]
+	stateCookie *http.Cookie
+}
+
+func callbackSetup(t *testing.T) callbackTest {
+	client := &auth.FakeOAuthClient{}
+	client.SetExchangeCodeResult(auth.TokenResult{Token: sessionToken()})
+	loginStates := loginStateStore(t)
+	sessions := sessionStore(t)
+	handler := NewCallbackHandler(client, loginStates, sessions, redirectUri, logging.NoopLogger{})
+	handler.SetSuccessRedirect("https://festwrap.com/home")
+
+	writer := httptest.NewRecorder()
+	loginStates.Save(writer, loginState())
+	return callbackTest{
+		handler:     handler,
+		client:      client,
+		loginStates: loginStates,
+		sessions:    sessions,
+		stateCookie: findCookie(writer, "login"),
+	}
+}
+
+func callbackRequest(query string, cookies ...*http.Cookie) *http.Request {
+	return requestWithCookies("GET", fmt.Sprintf("https://festwrap.com/auth/callback%s", query), cookies...)
+}
+
+func TestCallbackHandlerExchangesCodeWithVerifier(t *testing.T) {
+	test := callbackSetup(t)
+
+	test.handler.ServeHTTP(httptest.NewRecorder(), callbackRequest("?code=some_code&state=some_state", test.stateCookie))
+
+	expected := []auth.ExchangeCodeArgs{{Code: "some_code", Verifier: "some_verifier", RedirectUri: redirectUri}}
+	assert.Equal(t, expected, test.client.GetExchangeCodeArgs())
+}
+
+func TestCallbackHandlerSavesSessionAndRedirects(t *testing.T) {
+	test := callbackSetup(t)
+	writer := httptest.NewRecorder()
+
+	test.handler.ServeHTTP(writer, callbackRequest("?code=some_code&state=some_state", test.stateCookie))
+
+	assert.Equal(t, http.StatusSeeOther, writer.Code)
+	assert.Equal(t, "https://festwrap.com/home", writer.Header().Get("Location"))
+	session, err := test.sessions.Get(requestWithCookies("GET", "https://festwrap.com", findCookie(writer, "session")))
+	assert.Nil(t, err)
+	assert.Equal(t, sessionToken(), session)
+}
+
+func TestCallbackHandlerClearsLoginState(t *testing.T) {
+	test := callbackSetup(t)
+	writer := httptest.NewRecorder()
+
+	test.handler.ServeHTTP(writer, callbackRequest("?code=some_code&state=some_state", test.stateCookie))
+
+	assert.Equal(t, -1, findCookie(writer, "login").MaxAge)
+}
+
+func TestCallbackHandlerRejectsInvalidRequests(t *testing.T) {
+	tests := map[string]struct {
+		query       string
+		withState   bool
+		expected    int
+		exchangeErr error
+	}{
+		"missing login state": {
+			query:     "?code=some_code&state=some_state",
+			withState: false,
+			expected:  http.StatusBadRequest,
+		},
+		"authorization denied": {
+			query:     "?error=access_denied&state=some_state",
+			withState: true,
+			expected:  http.StatusUnauthorized,
+		},
+		"state mismatch": {
+			query:     "?code=some_code&state=other_state",
+			withState: true,
+			expected:  http.StatusUnprocessableEntity,
+		},
+		"missing code": {
+			query:     "?state=some_state",
+			withState: true,
+			expected:  http.StatusBadRequest,
+		},
+		"code rejected": {
+			query:       "?code=some_code&state=some_state",
+			withState:   true,
+			expected:    http.StatusUnauthorized,
+			exchangeErr: autherrors.NewInvalidGrantError("test error"),
+		},
+		"token endpoint failure": {
+			query:       "?code=some_code&state=some_state",
+			withState:   true,
+			expected:    http.StatusBadGateway,
+			exchangeErr: autherrors.NewCannotRetrieveTokenError("test error"),
+		},
+		"unexpected error": {
+			query:       "?code=some_code&state=some_state",
+			withState:   true,
+			expected:    http.StatusInternalServerError,
+			exchangeErr: errors.New("test error"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			setup := callbackSetup(t)
+			if test.exchangeErr != nil {
+				setup.client.SetExchangeCodeResult(auth.TokenResult{Err: test.exchangeErr})
+			}
+			cookies := []*http.Cookie{}
+			if test.withState {
+				cookies = append(cookies, setup.stateCookie)
+			}
+			writer := httptest.NewRecorder()
+
+			setup.handler.ServeHTTP(writer, callbackRequest(test.query, cookies...))
+
+			assert.Equal(t, test.expected, writer.Code)
+			assert.Nil(t, findCookie(writer, "session"))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"festwrap/internal/auth"
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+)
+
+// Starts the login of the user, redirecting to the Spotify authorization page
+type LoginHandler struct {
+	oauthClient auth.OAuthClient
+	loginStates auth.LoginStateStore
+	redirectUri string
+	logger      logging.Logger
+}
+
+func NewLoginHandler(
+	oauthClient auth.OAuthClient,
+	loginStates auth.LoginStateStore,
+	redirectUri string,
+	logger logging.Logger,
+) LoginHandler {
+	return LoginHandler{oauthClient: oauthClient, loginStates: loginStates, redirectUri: redirectUri, logger: logger}
+}
+
+func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	state, err := auth.NewLoginState()
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not generate login state: %v", err))
+		problem.Write(w, problem.Internal("could not start login"))
+		return
+	}
+
+	if err = h.loginStates.Save(w, state); err != nil {
+		h.logger.Error(fmt.Sprintf("could not save login state: %v", err))
+		problem.Write(w, problem.Internal("could not start login"))
+		return
+	}
+
+	http.Redirect(w, r, h.oauthClient.AuthorizeUrl(state, h.redirectUri), http.StatusFound)
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"festwrap/internal/auth"
+	"festwrap/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	redirectUri  = "https://festwrap.com/auth/callback"
+	authorizeUrl = "https://accounts.spotify.com/authorize?client_id=some_client"
+)
+
+func cookieKey() []byte {
+	return []byte("0123456789abcdef0123456789abcdef")
+}
+
+func loginStateStore(t *testing.T) auth.CookieStore[auth.LoginState] {
+	store, err := auth.NewCookieStore[auth.LoginState]("login", cookieKey(), 10*time.Minute)
+	if err != nil {
+		t.Fatalf("could not create login state store: %v", err)
+	}
+	return store
+}
+
+func sessionStore(t *testing.T) auth.CookieStore[auth.Token] {
+	store, err := auth.NewCookieStore[auth.Token]("session", cookieKey(), time.Hour)
+	if err != nil {
+		t.Fatalf("could not create session store: %v", err)
+	}
+	return store
+}
+
+func findCookie(writer *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range writer.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func requestWithCookies(method string, url string, cookies ...*http.Cookie) *http.Request {
+	request := httptest.NewRequest(method, url, nil)
+	for _, cookie := range cookies {
+		request.AddCookie(cookie)
+	}
+	return request
+}
+
+func loginSetup(t *testing.T) (LoginHandler, *auth.FakeOAuthClient, auth.CookieStore[auth.LoginState]) {
+	client := &auth.FakeOAuthClient{}
+	client.SetAuthorizeUrl(authorizeUrl)
+	loginStates := loginStateStore(t)
+	handler := NewLoginHandler(client, loginStates, redirectUri, logging.NoopLogger{})
+	return handler, client, loginStates
+}
+
+func TestLoginHandlerRedirectsToAuthorizeUrl(t *testing.T) {
+	handler, _, _ := loginSetup(t)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, httptest.NewRequest("GET", "https://festwrap.com/auth/login", nil))
+
+	assert.Equal(t, http.StatusFound, writer.Code)
+	assert.Equal(t, authorizeUrl, writer.Header().Get("Location"))
+}
+
+func TestLoginHandlerSavesStateUsedInAuthorizeUrl(t *testing.T) {
+	handler, client, loginStates := loginSetup(t)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, httptest.NewRequest("GET", "https://festwrap.com/auth/login", nil))
+
+	saved, err := loginStates.Get(requestWithCookies("GET", "https://festwrap.com", findCookie(writer, "login")))
+	assert.Nil(t, err)
+	expected := []auth.AuthorizeUrlArgs{{State: saved, RedirectUri: redirectUri}}
+	assert.Equal(t, expected, client.GetAuthorizeUrlArgs())
+}
+
+func TestLoginHandlerGeneratesNewStatePerLogin(t *testing.T) {
+	handler, client, _ := loginSetup(t)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "https://festwrap.com/auth/login", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "https://festwrap.com/auth/login", nil))
+
+	args := client.GetAuthorizeUrlArgs()
+	assert.NotEqual(t, args[0].State, args[1].State)
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"net/http"
+
+	"festwrap/internal/auth"
+)
+
+// Removes the session of the user. Spotify tokens are not revoked, since it does not support it.
+// Sessions live in the cookie only, so this just asks the browser to drop it: a copy of the cookie
+// taken before logging out remains valid until the session max age is reached
+type LogoutHandler struct {
+	sessions auth.SessionStore
+}
+
+func NewLogoutHandler(sessions auth.SessionStore) LogoutHandler {
+	return LogoutHandler{sessions: sessions}
+}
+
+func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.sessions.Clear(w)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogoutHandlerClearsSession(t *testing.T) {
+	sessions := sessionStore(t)
+	handler := NewLogoutHandler(sessions)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/logout", sessionCookie(t, sessions)))
+
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	assert.Equal(t, -1, findCookie(writer, "session").MaxAge)
+}
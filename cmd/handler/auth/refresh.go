@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+)
+
+type SessionResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	Scopes    []string  `json:"scopes"`
+}
+
+// Refreshes the token of the session. Tokens are refreshed when used, so this is only needed
+// by clients wanting to extend the session in advance
+type RefreshHandler struct {
+	sessions  auth.SessionStore
+	refresher auth.TokenRefresher
+	encoder   serialization.Encoder[SessionResponse]
+	logger    logging.Logger
+}
+
+func NewRefreshHandler(sessions auth.SessionStore, refresher auth.TokenRefresher, logger logging.Logger) RefreshHandler {
+	return RefreshHandler{
+		sessions:  sessions,
+		refresher: refresher,
+		encoder:   serialization.NewJsonEncoder[SessionResponse](),
+		logger:    logger,
+	}
+}
+
+func (h *RefreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, err := h.sessions.Get(r)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("session not found: %v", err))
+		problem.Write(w, problem.FromError(err, "no session found, login is needed"))
+		return
+	}
+
+	refreshed, err := h.refresher.Refresh(token)
+	var grantErr *autherrors.InvalidGrantError
+	if errors.As(err, &grantErr) {
+		h.logger.Warn(fmt.Sprintf("refresh token rejected: %v", err))
+		h.sessions.Clear(w)
+		problem.Write(w, problem.FromError(err, "session has expired, login is needed"))
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("could not refresh token: %v", err))
+		problem.Write(w, problem.FromError(err, "could not refresh session"))
+		return
+	}
+
+	if err = h.sessions.Save(w, refreshed); err != nil {
+		h.logger.Error(fmt.Sprintf("could not save session: %v", err))
+		problem.Write(w, problem.Internal("could not save session"))
+		return
+	}
+
+	response := SessionResponse{ExpiresAt: refreshed.ExpiresAt, Scopes: refreshed.Scopes}
+	var body bytes.Buffer
+	if err = h.encoder.Encode(&body, response); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode session: %v", err))
+		problem.Write(w, problem.Internal("could not encode session"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func (h *RefreshHandler) SetEncoder(encoder serialization.Encoder[SessionResponse]) {
+	h.encoder = encoder
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func refreshedToken() auth.Token {
+	return auth.Token{
+		AccessToken:  "new_access",
+		RefreshToken: "new_refresh",
+		ExpiresAt:    time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC),
+		Scopes:       []string{"playlist-modify-public"},
+	}
+}
+
+func sessionCookie(t *testing.T, sessions auth.CookieStore[auth.Token]) *http.Cookie {
+	writer := httptest.NewRecorder()
+	if err := sessions.Save(writer, sessionToken()); err != nil {
+		t.Fatalf("could not save session: %v", err)
+	}
+	return findCookie(writer, "session")
+}
+
+func refreshSetup(t *testing.T) (RefreshHandler, *auth.FakeOAuthClient, auth.CookieStore[auth.Token]) {
+	client := &auth.FakeOAuthClient{}
+	client.SetRefreshTokenResult(auth.TokenResult{Token: refreshedToken()})
+	sessions := sessionStore(t)
+	handler := NewRefreshHandler(sessions, auth.NewTokenRefresher(client), logging.NoopLogger{})
+	return handler, client, sessions
+}
+
+func TestRefreshHandlerRefreshesSessionToken(t *testing.T) {
+	handler, client, sessions := refreshSetup(t)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/refresh", sessionCookie(t, sessions)))
+
+	assert.Equal(t, []string{"refresh"}, client.GetRefreshTokenArgs())
+	session, err := sessions.Get(requestWithCookies("GET", "https://festwrap.com", findCookie(writer, "session")))
+	assert.Nil(t, err)
+	assert.Equal(t, refreshedToken(), session)
+}
+
+func TestRefreshHandlerReturnsSessionDetails(t *testing.T) {
+	handler, _, sessions := refreshSetup(t)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/refresh", sessionCookie(t, sessions)))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+	assert.Equal(t, `{"expiresAt":"2025-06-01T13:00:00Z","scopes":["playlist-modify-public"]}`+"\n", writer.Body.String())
+}
+
+func TestRefreshHandlerReturnsUnauthorizedWithoutSession(t *testing.T) {
+	handler, _, _ := refreshSetup(t)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/refresh"))
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+}
+
+func TestRefreshHandlerClearsSessionIfRefreshTokenRejected(t *testing.T) {
+	handler, client, sessions := refreshSetup(t)
+	client.SetRefreshTokenResult(auth.TokenResult{Err: autherrors.NewInvalidGrantError("test error")})
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/refresh", sessionCookie(t, sessions)))
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	assert.Equal(t, -1, findCookie(writer, "session").MaxAge)
+}
+
+func TestRefreshHandlerKeepsSessionOnOtherErrors(t *testing.T) {
+	handler, client, sessions := refreshSetup(t)
+	client.SetRefreshTokenResult(auth.TokenResult{Err: autherrors.NewCannotRetrieveTokenError("test error")})
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/refresh", sessionCookie(t, sessions)))
+
+	assert.Equal(t, http.StatusBadGateway, writer.Code)
+	assert.Nil(t, findCookie(writer, "session"))
+}
+
+func TestRefreshHandlerReturnsInternalErrorOnEncoderError(t *testing.T) {
+	handler, _, sessions := refreshSetup(t)
+	encoder := serialization.FakeEncoder[SessionResponse]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler.SetEncoder(encoder)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, requestWithCookies("POST", "https://festwrap.com/auth/refresh", sessionCookie(t, sessions)))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
@@ -0,0 +1,67 @@
+package job
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"festwrap/internal/http/problem"
+	"festwrap/internal/job"
+	joberrors "festwrap/internal/job/errors"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+)
+
+type GetJobHandler struct {
+	pathId   string
+	jobStore job.JobStore
+	encoder  serialization.Encoder[job.Job]
+	logger   logging.Logger
+}
+
+func NewGetJobHandler(pathId string, jobStore job.JobStore, logger logging.Logger) GetJobHandler {
+	return GetJobHandler{
+		pathId:   pathId,
+		jobStore: jobStore,
+		encoder:  serialization.NewJsonEncoder[job.Job](),
+		logger:   logger,
+	}
+}
+
+func (h *GetJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	jobId := r.PathValue(h.pathId)
+	if jobId == "" {
+		detail := "job id was not provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	result, err := h.jobStore.Get(jobId)
+	var notFoundErr *joberrors.JobNotFoundError
+	if errors.As(err, &notFoundErr) {
+		h.logger.Warn(fmt.Sprintf("job %s not found", jobId))
+		problem.Write(w, problem.FromError(err, fmt.Sprintf("job %s not found", jobId)))
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("could not retrieve job %s: %v", jobId, err))
+		problem.Write(w, problem.FromError(err, "could not retrieve job"))
+		return
+	}
+
+	var body bytes.Buffer
+	if err = h.encoder.Encode(&body, result); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode job %s: %v", jobId, err))
+		problem.Write(w, problem.Internal("could not encode job"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func (h *GetJobHandler) SetEncoder(encoder serialization.Encoder[job.Job]) {
+	h.encoder = encoder
+}
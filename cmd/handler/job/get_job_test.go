@@ -0,0 +1,83 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"festwrap/internal/job"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+	setlisterrors "festwrap/internal/setlist/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	jobId     = "someJob"
+	jobIdPath = "jobId"
+)
+
+func storedJob() job.Job {
+	storedJob := job.NewJob(jobId, "somePlaylist", []string{"Alexisonfire", "Pianos Become the Teeth"})
+	storedJob.Start()
+	storedJob.SetArtistResult(0, nil)
+	storedJob.SetArtistResult(1, setlisterrors.NewSetlistNotFoundError("setlist not found"))
+	return storedJob
+}
+
+func buildRequest(jobId string) *http.Request {
+	request := httptest.NewRequest("GET", "https://example.com/jobs/someJob", nil)
+	request.SetPathValue(jobIdPath, jobId)
+	return request
+}
+
+func setup() (GetJobHandler, *httptest.ResponseRecorder) {
+	store := job.NewMemoryJobStore(time.Minute)
+	store.Save(storedJob())
+	handler := NewGetJobHandler(jobIdPath, store, logging.NoopLogger{})
+	return handler, httptest.NewRecorder()
+}
+
+func TestGetJobReturnsBadRequestIfIdNotProvided(t *testing.T) {
+	handler, writer := setup()
+
+	handler.ServeHTTP(writer, buildRequest(""))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestGetJobReturnsNotFoundIfJobDoesNotExist(t *testing.T) {
+	handler, writer := setup()
+
+	handler.ServeHTTP(writer, buildRequest("anotherJob"))
+
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestGetJobReturnsInternalErrorOnEncoderError(t *testing.T) {
+	handler, writer := setup()
+	encoder := serialization.FakeEncoder[job.Job]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler.SetEncoder(encoder)
+
+	handler.ServeHTTP(writer, buildRequest(jobId))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
+
+func TestGetJobReturnsJobProgress(t *testing.T) {
+	handler, writer := setup()
+
+	handler.ServeHTTP(writer, buildRequest(jobId))
+
+	expected := `{"id":"someJob","playlistId":"somePlaylist","status":"running","artists":[` +
+		`{"name":"Alexisonfire","status":"completed"},` +
+		`{"name":"Pianos Become the Teeth","status":"failed","error":"could not find a setlist for the artist"}]}` + "\n"
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, expected, writer.Body.String())
+}
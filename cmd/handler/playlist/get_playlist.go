@@ -0,0 +1,112 @@
+package playlist
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	"festwrap/internal/serialization"
+)
+
+type GetPlaylistHandler struct {
+	playlistIdPath  string
+	playlistService playlist.PlaylistService
+	logger          logging.Logger
+	responseEncoder serialization.Encoder[playlist.PlaylistWithTracks]
+	defaultLimit    int
+	maxLimit        int
+}
+
+// Returns the playlist metadata along with a page of its tracks
+func NewGetPlaylistHandler(
+	playlistIdPath string,
+	playlistService playlist.PlaylistService,
+	logger logging.Logger,
+) GetPlaylistHandler {
+	responseEncoder := serialization.NewJsonEncoder[playlist.PlaylistWithTracks]()
+	return GetPlaylistHandler{
+		playlistIdPath:  playlistIdPath,
+		playlistService: playlistService,
+		logger:          logger,
+		responseEncoder: &responseEncoder,
+		defaultLimit:    50,
+		maxLimit:        100,
+	}
+}
+
+func (h *GetPlaylistHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	playlistId := r.PathValue(h.playlistIdPath)
+	if playlistId == "" {
+		detail := "playlist id was not provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	offset, limit, err := h.readPage(r)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("validation error: %v", err))
+		problem.Write(w, problem.InvalidParameter(err.Error()))
+		return
+	}
+
+	result, err := h.playlistService.GetPlaylist(r.Context(), playlistId, offset, limit)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not retrieve playlist %s: %v", playlistId, err))
+		problem.Write(w, problem.FromError(err, "could not retrieve playlist"))
+		return
+	}
+
+	var body bytes.Buffer
+	if err = h.responseEncoder.Encode(&body, result); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode playlist %s: %v", playlistId, err))
+		problem.Write(w, problem.Internal("could not encode playlist"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func (h *GetPlaylistHandler) readPage(r *http.Request) (int, int, error) {
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		value, err := strconv.Atoi(offsetStr)
+		if err != nil || value < 0 {
+			return 0, 0, fmt.Errorf("offset must be a non negative integer, found %s", offsetStr)
+		}
+		offset = value
+	}
+
+	limit := h.defaultLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil || value < 1 || value > h.maxLimit {
+			return 0, 0, fmt.Errorf("limit must be an integer in interval [1, %d], found %s", h.maxLimit, limitStr)
+		}
+		limit = value
+	}
+
+	return offset, limit, nil
+}
+
+func (h *GetPlaylistHandler) SetPlaylistService(service playlist.PlaylistService) {
+	h.playlistService = service
+}
+
+func (h *GetPlaylistHandler) SetResponseEncoder(encoder serialization.Encoder[playlist.PlaylistWithTracks]) {
+	h.responseEncoder = encoder
+}
+
+func (h *GetPlaylistHandler) SetMaxLimit(limit int) {
+	h.maxLimit = limit
+}
+
+func (h *GetPlaylistHandler) SetDefaultLimit(limit int) {
+	h.defaultLimit = limit
+}
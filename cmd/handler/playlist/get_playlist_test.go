@@ -0,0 +1,141 @@
+package playlist
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	playlistmocks "festwrap/internal/playlist/mocks"
+	"festwrap/internal/serialization"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func retrievedPlaylist() playlist.PlaylistWithTracks {
+	return playlist.PlaylistWithTracks{
+		Id:          playlistId,
+		Name:        "Hardcore",
+		Description: "Some description",
+		IsPublic:    true,
+		Owner:       playlist.PlaylistOwner{Id: "userId", Name: "Some user"},
+		SnapshotId:  "snapshot",
+		Tracks: playlist.PlaylistTracksPage{
+			Items: []playlist.PlaylistTrack{
+				{Uri: "uri1", Name: "Wake the Dead", Artists: []string{"Comeback Kid"}, DurationMs: 219000},
+			},
+			Offset: 0,
+			Limit:  50,
+			Total:  1,
+		},
+	}
+}
+
+func buildGetPlaylistRequest(playlistId string, query string) *http.Request {
+	request := httptest.NewRequest("GET", fmt.Sprintf("https://example.com/playlists/someId%s", query), nil)
+	request.SetPathValue(playlistIdPath, playlistId)
+	return request
+}
+
+func getPlaylistSetup() (GetPlaylistHandler, *http.Request, *httptest.ResponseRecorder, *playlistmocks.PlaylistServiceMock) {
+	request := buildGetPlaylistRequest(playlistId, "")
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("GetPlaylist", request.Context(), playlistId, 0, 50).Return(retrievedPlaylist(), nil)
+	handler := NewGetPlaylistHandler(playlistIdPath, playlistService, logging.NoopLogger{})
+	return handler, request, httptest.NewRecorder(), playlistService
+}
+
+func TestGetPlaylistHandlerReturnsBadRequestIfIdNotProvided(t *testing.T) {
+	handler, _, writer, _ := getPlaylistSetup()
+
+	handler.ServeHTTP(writer, buildGetPlaylistRequest("", ""))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestGetPlaylistHandlerReturnsUnprocessableEntityOnInvalidPage(t *testing.T) {
+	tests := map[string]struct {
+		query string
+	}{
+		"non integer offset": {query: "?offset=abc"},
+		"negative offset":    {query: "?offset=-1"},
+		"non integer limit":  {query: "?limit=abc"},
+		"zero limit":         {query: "?limit=0"},
+		"limit above max":    {query: "?limit=101"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, _, writer, _ := getPlaylistSetup()
+
+			handler.ServeHTTP(writer, buildGetPlaylistRequest(playlistId, test.query))
+
+			assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+		})
+	}
+}
+
+func TestGetPlaylistHandlerCallsServiceWithDefaultPage(t *testing.T) {
+	handler, request, writer, playlistService := getPlaylistSetup()
+
+	handler.ServeHTTP(writer, request)
+
+	playlistService.AssertExpectations(t)
+}
+
+func TestGetPlaylistHandlerCallsServiceWithRequestedPage(t *testing.T) {
+	handler, _, writer, _ := getPlaylistSetup()
+	request := buildGetPlaylistRequest(playlistId, "?offset=100&limit=20")
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("GetPlaylist", request.Context(), playlistId, 100, 20).Return(retrievedPlaylist(), nil)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	playlistService.AssertExpectations(t)
+}
+
+func TestGetPlaylistHandlerReturnsInternalErrorOnServiceError(t *testing.T) {
+	handler, request, writer, _ := getPlaylistSetup()
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("GetPlaylist", mock.Anything, playlistId, mock.Anything, mock.Anything).Return(
+		playlist.PlaylistWithTracks{}, errors.New("test error"),
+	)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestGetPlaylistHandlerReturnsInternalErrorOnEncoderError(t *testing.T) {
+	handler, request, writer, _ := getPlaylistSetup()
+	encoder := serialization.FakeEncoder[playlist.PlaylistWithTracks]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler.SetResponseEncoder(encoder)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
+
+func TestGetPlaylistHandlerReturnsPlaylist(t *testing.T) {
+	handler, request, writer, _ := getPlaylistSetup()
+
+	handler.ServeHTTP(writer, request)
+
+	expected := `{"id":"someId","name":"Hardcore","description":"Some description","isPublic":true,` +
+		`"owner":{"id":"userId","name":"Some user"},"snapshotId":"snapshot","tracks":{"items":[` +
+		`{"uri":"uri1","name":"Wake the Dead","artists":["Comeback Kid"],"durationMs":219000}` +
+		`],"offset":0,"limit":50,"total":1}}` + "\n"
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+	assert.Equal(t, expected, writer.Body.String())
+}
@@ -0,0 +1,85 @@
+package playlist
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	"festwrap/internal/serialization"
+)
+
+type RemoveArtistResponse struct {
+	Playlist      Playlist                 `json:"playlist"`
+	RemovedTracks []playlist.PlaylistTrack `json:"removedTracks"`
+}
+
+type RemoveArtistHandler struct {
+	playlistIdPath  string
+	artistPath      string
+	playlistService playlist.PlaylistService
+	logger          logging.Logger
+	responseEncoder serialization.Encoder[RemoveArtistResponse]
+}
+
+// Removes from a playlist all the tracks where the given artist takes part
+func NewRemoveArtistHandler(
+	playlistIdPath string,
+	artistPath string,
+	playlistService playlist.PlaylistService,
+	logger logging.Logger,
+) RemoveArtistHandler {
+	responseEncoder := serialization.NewJsonEncoder[RemoveArtistResponse]()
+	return RemoveArtistHandler{
+		playlistIdPath:  playlistIdPath,
+		artistPath:      artistPath,
+		playlistService: playlistService,
+		logger:          logger,
+		responseEncoder: &responseEncoder,
+	}
+}
+
+func (h *RemoveArtistHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	playlistId := r.PathValue(h.playlistIdPath)
+	artist := r.PathValue(h.artistPath)
+	if playlistId == "" || artist == "" {
+		detail := "playlist id and artist name must be provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	removedTracks, err := h.playlistService.RemoveArtist(r.Context(), playlistId, artist)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not remove songs for %s from playlist %s: %v", artist, playlistId, err))
+		problem.Write(w, problem.FromError(err, "could not remove artist songs"))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Removed %d tracks for %s from playlist %s", len(removedTracks), artist, playlistId))
+
+	response := RemoveArtistResponse{Playlist: Playlist{Id: playlistId}, RemovedTracks: removedTracks}
+	var body bytes.Buffer
+	if err = h.responseEncoder.Encode(&body, response); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode response: %v", err))
+		problem.Write(w, problem.Internal("could not encode response"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func (h *RemoveArtistHandler) GetPlaylistService() playlist.PlaylistService {
+	return h.playlistService
+}
+
+func (h *RemoveArtistHandler) SetPlaylistService(service playlist.PlaylistService) {
+	h.playlistService = service
+}
+
+func (h *RemoveArtistHandler) SetResponseEncoder(encoder serialization.Encoder[RemoveArtistResponse]) {
+	h.responseEncoder = encoder
+}
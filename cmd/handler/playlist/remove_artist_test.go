@@ -0,0 +1,114 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	playlistmocks "festwrap/internal/playlist/mocks"
+	"festwrap/internal/serialization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	removedArtist     = "Comeback Kid"
+	removedArtistPath = "artistName"
+)
+
+func removedTracks() []playlist.PlaylistTrack {
+	return []playlist.PlaylistTrack{
+		{Uri: "uri1", Name: "Wake the Dead", Artists: []string{removedArtist}},
+	}
+}
+
+func buildRemoveArtistRequest(playlistId string, artist string) *http.Request {
+	request := httptest.NewRequest("DELETE", "https://example.com/playlists/someId/artists/someArtist", nil)
+	request.SetPathValue(playlistIdPath, playlistId)
+	request.SetPathValue(removedArtistPath, artist)
+	return request
+}
+
+func removeArtistSetup() (RemoveArtistHandler, *http.Request, *httptest.ResponseRecorder) {
+	request := buildRemoveArtistRequest(playlistId, removedArtist)
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("RemoveArtist", request.Context(), playlistId, removedArtist).Return(removedTracks(), nil)
+	handler := NewRemoveArtistHandler(playlistIdPath, removedArtistPath, playlistService, logging.NoopLogger{})
+	return handler, request, httptest.NewRecorder()
+}
+
+func TestRemoveArtistHandlerReturnsBadRequestIfPathValuesMissing(t *testing.T) {
+	tests := map[string]struct {
+		playlistId string
+		artist     string
+	}{
+		"missing playlist id": {
+			playlistId: "",
+			artist:     removedArtist,
+		},
+		"missing artist": {
+			playlistId: playlistId,
+			artist:     "",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, _, writer := removeArtistSetup()
+			request := buildRemoveArtistRequest(test.playlistId, test.artist)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+		})
+	}
+}
+
+func TestRemoveArtistHandlerCallsServiceWithPathValues(t *testing.T) {
+	handler, request, writer := removeArtistSetup()
+
+	handler.ServeHTTP(writer, request)
+
+	playlistService := handler.GetPlaylistService().(*playlistmocks.PlaylistServiceMock)
+	playlistService.AssertExpectations(t)
+}
+
+func TestRemoveArtistHandlerReturnsInternalErrorOnServiceError(t *testing.T) {
+	handler, request, writer := removeArtistSetup()
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("RemoveArtist", request.Context(), playlistId, removedArtist).Return(
+		[]playlist.PlaylistTrack{}, errors.New("test error"),
+	)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestRemoveArtistHandlerReturnsInternalErrorOnEncoderError(t *testing.T) {
+	handler, request, writer := removeArtistSetup()
+	encoder := serialization.FakeEncoder[RemoveArtistResponse]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler.SetResponseEncoder(encoder)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
+
+func TestRemoveArtistHandlerReturnsRemovedTracks(t *testing.T) {
+	handler, request, writer := removeArtistSetup()
+
+	handler.ServeHTTP(writer, request)
+
+	expected := `{"playlist":{"id":"someId"},"removedTracks":[{"uri":"uri1","name":"Wake the Dead","artists":["Comeback Kid"]}]}` + "\n"
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, expected, writer.Body.String())
+}
@@ -1,20 +1,86 @@
 package playlist
 
 import (
+	"bytes"
+	"context"
+	"festwrap/internal/cover"
+	"festwrap/internal/http/problem"
+	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/http/sse"
+	"festwrap/internal/job"
 	"festwrap/internal/logging"
 	"festwrap/internal/playlist"
 	builders "festwrap/internal/playlist/update_builders"
 	"festwrap/internal/serialization"
 	"fmt"
 	"net/http"
+	"runtime/debug"
+	"strings"
 )
 
 type Playlist struct {
 	Id string `json:"id"`
 }
 
+// Artist added to the update because of its similarity to one of the requested artists
+type RelatedArtist struct {
+	Name      string `json:"name"`
+	SpotifyId string `json:"spotifyId"`
+	RelatedTo string `json:"relatedTo"`
+}
+
 type UpdatePlaylistResponse struct {
+	Playlist       Playlist                     `json:"playlist"`
+	RolledBack     bool                         `json:"rolledBack,omitempty"`
+	Description    string                       `json:"description,omitempty"`
+	Setlists       []playlist.SetlistProvenance `json:"setlists,omitempty"`
+	RelatedArtists []RelatedArtist              `json:"relatedArtists,omitempty"`
+}
+
+// Track of a setlist matched in Spotify and found in the playlist after the update
+type UpdatedTrack struct {
+	Artist string `json:"artist"`
+	Date   string `json:"date,omitempty"`
+	Venue  string `json:"venue,omitempty"`
+	Tour   string `json:"tour,omitempty"`
+	Source string `json:"source,omitempty"`
+	Title  string `json:"title"`
+	Uri    string `json:"uri"`
+	// Whether the track was added or skipped because the playlist already had it
+	Status string `json:"status"`
+}
+
+type Job struct {
+	Id string `json:"id"`
+}
+
+type AsyncUpdatePlaylistResponse struct {
 	Playlist Playlist `json:"playlist"`
+	Job      Job      `json:"job"`
+}
+
+type ArtistUpdatedEvent struct {
+	Artist    string                      `json:"artist"`
+	RelatedTo string                      `json:"relatedTo,omitempty"`
+	Error     string                      `json:"error,omitempty"`
+	Setlist   *playlist.SetlistProvenance `json:"setlist,omitempty"`
+}
+
+type UpdateFinishedEvent struct {
+	Playlist    Playlist `json:"playlist"`
+	Status      int      `json:"status"`
+	RolledBack  bool     `json:"rolledBack,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Outcome of processing all the artists of an update
+type updateResult struct {
+	setlists []playlist.SetlistProvenance
+	errors   int
+	// Last error caused by Spotify rejecting the token, if any
+	authErr     error
+	rolledBack  bool
+	description string
 }
 
 type UpdatePlaylistHandler struct {
@@ -22,9 +88,17 @@ type UpdatePlaylistHandler struct {
 	logger                logging.Logger
 	playlistUpdateBuilder playlist.PlaylistUpdateBuilder
 	maxArtists            int
+	maxRelatedArtists     int
 	returnResponse        bool
-	responseEncoder       serialization.Encoder[UpdatePlaylistResponse]
+	responseEncoders      serialization.EncoderRegistry[UpdatePlaylistResponse]
+	asyncResponseEncoders serialization.EncoderRegistry[AsyncUpdatePlaylistResponse]
 	successStatusCode     int
+	jobStore              job.JobStore
+	jobExecutor           job.Executor
+	jobsPath              string
+	coverService          cover.CoverService
+	descriptionGenerator  playlist.DescriptionGenerator
+	lineupExpander        playlist.LineupExpander
 }
 
 func NewUpdatePlaylistHandler(
@@ -32,15 +106,18 @@ func NewUpdatePlaylistHandler(
 	playlistUpdateBuilder playlist.PlaylistUpdateBuilder,
 	logger logging.Logger,
 ) UpdatePlaylistHandler {
-	responseEncoder := serialization.NewJsonEncoder[UpdatePlaylistResponse]()
 	return UpdatePlaylistHandler{
 		playlistService:       playlistService,
 		logger:                logger,
 		playlistUpdateBuilder: playlistUpdateBuilder,
 		maxArtists:            5,
+		maxRelatedArtists:     5,
 		returnResponse:        false,
-		responseEncoder:       &responseEncoder,
+		responseEncoders:      newUpdatePlaylistResponseEncoders(),
+		asyncResponseEncoders: newAsyncUpdatePlaylistResponseEncoders(),
 		successStatusCode:     http.StatusCreated,
+		jobsPath:              "/jobs",
+		descriptionGenerator:  playlist.NewDescriptionGenerator(),
 	}
 }
 
@@ -58,7 +135,7 @@ func NewUpdateNewPlaylistHandler(
 	playlistService playlist.PlaylistService,
 	logger logging.Logger,
 ) UpdatePlaylistHandler {
-	builder := builders.NewNewPlaylistUpdateBuilder(playlistService)
+	builder := builders.NewNewPlaylistUpdateBuilder()
 	handler := NewUpdatePlaylistHandler(playlistService, &builder, logger)
 	handler.ReturnResponse(true)
 	handler.SetSuccessStatusCode(http.StatusOK)
@@ -66,47 +143,423 @@ func NewUpdateNewPlaylistHandler(
 }
 
 func (h *UpdatePlaylistHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Checked before anything is done, since new playlists are created before updating them
+	if mediaTypes, ok := h.acceptsResponse(r); !ok {
+		detail := fmt.Sprintf("response can only be returned as %s", strings.Join(mediaTypes, ", "))
+		h.logger.Warn(fmt.Sprintf("not acceptable: %s", detail))
+		problem.Write(w, problem.NotAcceptable(detail))
+		return
+	}
+
 	update, err := h.playlistUpdateBuilder.Build(r)
 	if err != nil {
 		h.logger.Warn(fmt.Sprintf("could not get playlist update details: %v", err))
-		http.Error(w, "could not obtain playlist details from request", http.StatusBadRequest)
+		problem.Write(w, problem.InvalidBody("could not obtain playlist details from request"))
 		return
 	}
 
 	if len(update.Artists) == 0 || len(update.Artists) > h.maxArtists {
-		message := fmt.Sprintf("validation error: number of artists must be between 1 and %d", h.maxArtists)
-		h.logger.Warn(message)
-		http.Error(w, message, http.StatusBadRequest)
+		detail := fmt.Sprintf("number of artists must be between 1 and %d", h.maxArtists)
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.InvalidBody(detail))
+		return
+	}
+
+	if update.RelatedArtists < 0 || update.RelatedArtists > h.maxRelatedArtists {
+		detail := fmt.Sprintf("number of related artists must be between 0 and %d", h.maxRelatedArtists)
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.InvalidBody(detail))
+		return
+	}
+
+	// Only created once the update is known to be valid, so invalid requests leave no playlists behind
+	if update.NewPlaylist != nil {
+		playlistId, err := h.playlistService.CreatePlaylist(r.Context(), *update.NewPlaylist)
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("could not create playlist: %v", err))
+			problem.Write(w, problem.FromError(err, "could not create playlist"))
+			return
+		}
+		update.PlaylistId = playlistId
+		update.Created = true
+	}
+	update.Artists = h.addRelatedArtists(r.Context(), update)
+
+	if h.isAsyncRequest(r) {
+		h.serveAsync(w, r, update)
+		return
+	} else if isEventStreamRequest(r) {
+		h.serveEventStream(w, r, update)
+		return
+	}
+
+	result := h.updateSetlists(r.Context(), update, nil)
+	h.finishUpdate(r.Context(), update, &result)
+	if authProblem, ok := h.authProblem(result, len(update.Artists)); ok {
+		h.logger.Warn(fmt.Sprintf("could not update playlist %s: token was rejected", update.PlaylistId))
+		problem.Write(w, authProblem)
+		return
+	}
+	if !h.returnResponse {
+		w.WriteHeader(h.updateStatusCode(result, len(update.Artists)))
+		return
+	}
+
+	mediaType, encoder, _ := h.responseEncoders.Negotiate(r.Header.Get("Accept"))
+	response := UpdatePlaylistResponse{
+		Playlist:       Playlist{Id: update.PlaylistId},
+		RolledBack:     result.rolledBack,
+		Description:    result.description,
+		Setlists:       result.setlists,
+		RelatedArtists: relatedArtists(update.Artists),
+	}
+	// Responses are encoded before writing the status, so encoding errors can still be reported
+	var body bytes.Buffer
+	if err = encoder.Encode(&body, response); err != nil {
+		message := fmt.Sprintf("encoding error: could not encode response: %v", err)
+		h.logger.Error(message)
+		problem.Write(w, problem.Internal("could not encode response"))
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(h.updateStatusCode(result, len(update.Artists)))
+	h.writeBody(w, &body)
+}
+
+// Runs the update in background, so the client can poll the job status instead of waiting for it
+func (h *UpdatePlaylistHandler) serveAsync(w http.ResponseWriter, r *http.Request, update playlist.PlaylistUpdate) {
+	jobId, err := job.NewJobId()
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not generate job id: %v", err))
+		problem.Write(w, problem.Internal("could not create job"))
+		return
+	}
+
+	artists := make([]string, len(update.Artists))
+	for i, artist := range update.Artists {
+		artists[i] = artist.Name
+	}
+	updateJob := job.NewJob(jobId, update.PlaylistId, artists)
+	for i, artist := range update.Artists {
+		updateJob.Artists[i].RelatedTo = artist.RelatedTo
+	}
+	if err = h.jobStore.Save(updateJob); err != nil {
+		h.logger.Error(fmt.Sprintf("could not save job %s: %v", jobId, err))
+		problem.Write(w, problem.Internal("could not create job"))
+		return
+	}
+
+	// Request context is cancelled once the response is sent, but its values are still needed
+	ctx := context.WithoutCancel(r.Context())
+	err = h.jobExecutor.Submit(func() { h.runJob(ctx, update, updateJob) })
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("could not submit job %s: %v", jobId, err))
+		updateJob.Finish()
+		h.saveJob(updateJob)
+		detail := "too many playlist updates in progress"
+		problem.Write(w, problem.New(http.StatusServiceUnavailable, problem.JobQueueFullCode, detail))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Submitted job %s for playlist %s", jobId, update.PlaylistId))
+
+	mediaType, encoder, _ := h.asyncResponseEncoders.Negotiate(r.Header.Get("Accept"))
+	response := AsyncUpdatePlaylistResponse{Playlist: Playlist{Id: update.PlaylistId}, Job: Job{Id: jobId}}
+	var body bytes.Buffer
+	if err = encoder.Encode(&body, response); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode response: %v", err))
+		problem.Write(w, problem.Internal("could not encode response"))
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", h.jobsPath, jobId))
+	w.WriteHeader(http.StatusAccepted)
+	h.writeBody(w, &body)
+}
+
+func (h *UpdatePlaylistHandler) writeBody(w http.ResponseWriter, body *bytes.Buffer) {
+	if _, err := body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+// Sends the progress of the update as Server-Sent Events while it is being processed
+func (h *UpdatePlaylistHandler) serveEventStream(w http.ResponseWriter, r *http.Request, update playlist.PlaylistUpdate) {
+	stream, err := sse.NewEventWriter(w)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not stream playlist update: %v", err))
+		problem.Write(w, problem.Internal("could not stream playlist update"))
 		return
 	}
+	stream.Start()
+
+	sendEvent := func(event string, data any) {
+		if err := stream.Send(event, data); err != nil {
+			h.logger.Warn(fmt.Sprintf("could not send %s event for playlist %s: %v", event, update.PlaylistId, err))
+		}
+	}
+
+	ctx := playlist.WithProgressListener(r.Context(), func(event playlist.ProgressEvent) {
+		if event.Err != nil {
+			event.Error = problem.Message(event.Err)
+		}
+		sendEvent(string(event.Type), event)
+	})
+	result := h.updateSetlists(ctx, update, func(index int, setlist playlist.SetlistProvenance, err error) {
+		event := ArtistUpdatedEvent{Artist: update.Artists[index].Name, RelatedTo: update.Artists[index].RelatedTo}
+		if err != nil {
+			event.Error = problem.Message(err)
+		} else {
+			event.Setlist = &setlist
+		}
+		sendEvent("artistUpdated", event)
+	})
+
+	h.finishUpdate(r.Context(), update, &result)
+	finished := UpdateFinishedEvent{
+		Playlist:    Playlist{Id: update.PlaylistId},
+		Status:      h.updateStatusCode(result, len(update.Artists)),
+		RolledBack:  result.rolledBack,
+		Description: result.description,
+	}
+	sendEvent("finished", finished)
+}
+
+func (h *UpdatePlaylistHandler) runJob(ctx context.Context, update playlist.PlaylistUpdate, updateJob job.Job) {
+	// Otherwise the job would be reported as running forever
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Error(fmt.Sprintf("job %s panicked: %v\n%s", updateJob.Id, r, debug.Stack()))
+			updateJob.Fail("unexpected error")
+			h.saveJob(updateJob)
+		}
+	}()
+
+	updateJob.Start()
+	h.saveJob(updateJob)
+
+	result := h.updateSetlists(ctx, update, func(index int, setlist playlist.SetlistProvenance, err error) {
+		updateJob.SetArtistResult(index, err)
+		if err == nil {
+			updateJob.SetArtistSetlist(index, setlist)
+		}
+		h.saveJob(updateJob)
+	})
+
+	h.finishUpdate(ctx, update, &result)
+	updateJob.RolledBack = result.rolledBack
+	updateJob.Finish()
+	h.saveJob(updateJob)
+	h.logger.Info(fmt.Sprintf("Finished job %s with status %s", updateJob.Id, updateJob.Status))
+}
+
+func (h *UpdatePlaylistHandler) saveJob(updateJob job.Job) {
+	if err := h.jobStore.Save(updateJob); err != nil {
+		h.logger.Error(fmt.Sprintf("could not save job %s: %v", updateJob.Id, err))
+	}
+}
 
-	errors := 0
-	for _, artist := range update.Artists {
-		err := h.playlistService.AddSetlist(r.Context(), update.PlaylistId, artist.Name)
+// Adds the setlist of each artist in the update, returning the setlists added and the number of
+// artists that failed. The optional callback is notified after each artist is processed
+func (h *UpdatePlaylistHandler) updateSetlists(
+	ctx context.Context,
+	update playlist.PlaylistUpdate,
+	onArtistUpdated func(index int, setlist playlist.SetlistProvenance, err error),
+) updateResult {
+	result := updateResult{setlists: []playlist.SetlistProvenance{}}
+	mode := update.Mode
+	for i, artist := range update.Artists {
+		setlist, err := h.updateSetlist(ctx, update.PlaylistId, artist, mode)
 		if err != nil {
 			message := fmt.Sprintf("could not add songs for %s to playlist %s: %v", artist.Name, update.PlaylistId, err)
 			h.logger.Warn(message)
-			errors += 1
+			result.errors += 1
+			if _, ok := httpsender.AsAuthError(err); ok {
+				result.authErr = err
+			}
+		} else {
+			result.setlists = append(result.setlists, setlist)
+			if mode == playlist.ReplaceMode {
+				// Playlist content has already been replaced, so the rest of artists are appended
+				mode = playlist.AppendMode
+			}
+		}
+
+		if onArtistUpdated != nil {
+			onArtistUpdated(i, setlist, err)
 		}
 	}
+	return result
+}
 
-	statusCode := h.successStatusCode
-	if errors > 0 && errors < len(update.Artists) {
-		statusCode = http.StatusMultiStatus
-	} else if errors > 0 {
-		statusCode = http.StatusInternalServerError
+// Related artists are optional, so the requested artists are still added if they cannot be retrieved
+func (h *UpdatePlaylistHandler) addRelatedArtists(
+	ctx context.Context,
+	update playlist.PlaylistUpdate,
+) []playlist.PlaylistArtist {
+	if update.RelatedArtists == 0 {
+		return update.Artists
 	}
-	w.WriteHeader(statusCode)
 
-	if h.returnResponse {
-		response := UpdatePlaylistResponse{Playlist: Playlist{Id: update.PlaylistId}}
-		if err = h.responseEncoder.Encode(w, response); err != nil {
-			message := fmt.Sprintf("encoding error: could not encode response: %v", err)
-			h.logger.Error(message)
-			http.Error(w, "unexpected error: could not encode response", http.StatusInternalServerError)
-			return
+	if h.lineupExpander == nil {
+		message := "related artists are not enabled"
+		h.logger.Warn(fmt.Sprintf("could not add related artists to playlist %s: %s", update.PlaylistId, message))
+		return update.Artists
+	}
+
+	artists, err := h.lineupExpander.Expand(ctx, update.Artists, update.RelatedArtists)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("could not add related artists to playlist %s: %v", update.PlaylistId, err))
+		return update.Artists
+	}
+	return artists
+}
+
+func relatedArtists(artists []playlist.PlaylistArtist) []RelatedArtist {
+	result := []RelatedArtist{}
+	for _, artist := range artists {
+		if artist.RelatedTo != "" {
+			result = append(result, RelatedArtist{Name: artist.Name, SpotifyId: artist.SpotifyId, RelatedTo: artist.RelatedTo})
+		}
+	}
+	return result
+}
+
+// Runs the actions pending once all artists are processed
+func (h *UpdatePlaylistHandler) finishUpdate(ctx context.Context, update playlist.PlaylistUpdate, result *updateResult) {
+	result.rolledBack = h.rollback(ctx, update, result.errors)
+	if result.rolledBack || len(result.setlists) == 0 {
+		return
+	}
+
+	if update.GenerateCover {
+		h.setCover(ctx, update)
+	}
+	if update.GenerateDescription {
+		result.description = h.setDescription(ctx, update, result.setlists)
+	}
+}
+
+// Descriptions are optional, so the update does not fail if they cannot be set.
+// Returns the description set, if any
+func (h *UpdatePlaylistHandler) setDescription(
+	ctx context.Context,
+	update playlist.PlaylistUpdate,
+	setlists []playlist.SetlistProvenance,
+) string {
+	description, err := h.descriptionGenerator.Generate(playlist.DescriptionData{Name: update.Name, Setlists: setlists})
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("could not generate description for playlist %s: %v", update.PlaylistId, err))
+		return ""
+	}
+
+	details := playlist.PlaylistDetails{Description: &description}
+	if err = h.playlistService.UpdatePlaylistDetails(ctx, update.PlaylistId, details); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not set description for playlist %s: %v", update.PlaylistId, err))
+		return ""
+	}
+	return description
+}
+
+// Covers are optional, so the update does not fail if they cannot be generated
+func (h *UpdatePlaylistHandler) setCover(ctx context.Context, update playlist.PlaylistUpdate) {
+	if h.coverService == nil {
+		h.logger.Warn(fmt.Sprintf("could not generate cover for playlist %s: covers are not enabled", update.PlaylistId))
+		return
+	}
+
+	artists := make([]string, len(update.Artists))
+	for i, artist := range update.Artists {
+		artists[i] = artist.Name
+	}
+	if err := h.coverService.SetCover(ctx, update.PlaylistId, update.Name, artists); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not generate cover for playlist %s: %v", update.PlaylistId, err))
+	}
+}
+
+// Removes playlists created for the update when none of the artists could be added to them,
+// so users do not end up with empty playlists in their library
+func (h *UpdatePlaylistHandler) rollback(ctx context.Context, update playlist.PlaylistUpdate, errors int) bool {
+	if !update.Created || errors < len(update.Artists) {
+		return false
+	}
+
+	if err := h.playlistService.DeletePlaylist(ctx, update.PlaylistId); err != nil {
+		h.logger.Error(fmt.Sprintf("could not roll back playlist %s: %v", update.PlaylistId, err))
+		return false
+	}
+
+	h.logger.Info(fmt.Sprintf("Rolled back playlist %s since no artist could be added", update.PlaylistId))
+	return true
+}
+
+func (h *UpdatePlaylistHandler) updateSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist playlist.PlaylistArtist,
+	mode playlist.UpdateMode,
+) (playlist.SetlistProvenance, error) {
+	switch mode {
+	case playlist.ReplaceMode:
+		return h.playlistService.ReplaceSetlist(ctx, playlistId, artist)
+	case playlist.SyncMode:
+		return h.playlistService.SyncSetlist(ctx, playlistId, artist)
+	default:
+		return h.playlistService.AddSetlist(ctx, playlistId, artist)
+	}
+}
+
+func (h *UpdatePlaylistHandler) updateStatusCode(result updateResult, numArtists int) int {
+	if result.errors > 0 && result.errors < numArtists {
+		return http.StatusMultiStatus
+	} else if authProblem, ok := h.authProblem(result, numArtists); ok {
+		return authProblem.Status
+	} else if result.errors > 0 {
+		return http.StatusInternalServerError
+	}
+	return h.successStatusCode
+}
+
+// Spotify rejecting the token when every artist failed means clients have to refresh it or grant
+// more permissions, so the auth error is reported instead of a server error
+func (h *UpdatePlaylistHandler) authProblem(result updateResult, numArtists int) (problem.Problem, bool) {
+	if result.errors == 0 || result.errors < numArtists {
+		return problem.Problem{}, false
+	}
+	return problem.FromAuthError(result.authErr, "could not update playlist")
+}
+
+// Reports whether the response of the request can be encoded in a media type accepted by the client,
+// returning the available ones otherwise
+func (h *UpdatePlaylistHandler) acceptsResponse(r *http.Request) ([]string, bool) {
+	accept := r.Header.Get("Accept")
+	if isEventStreamRequest(r) {
+		return nil, true
+	} else if h.isAsyncRequest(r) {
+		_, _, ok := h.asyncResponseEncoders.Negotiate(accept)
+		return h.asyncResponseEncoders.MediaTypes(), ok
+	} else if h.returnResponse {
+		_, _, ok := h.responseEncoders.Negotiate(accept)
+		return h.responseEncoders.MediaTypes(), ok
+	}
+	return nil, true
+}
+
+func isEventStreamRequest(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// Requests are processed asynchronously only if jobs are enabled and the client asks for it
+func (h *UpdatePlaylistHandler) isAsyncRequest(r *http.Request) bool {
+	if h.jobStore == nil || h.jobExecutor == nil {
+		return false
+	}
+
+	for _, preference := range strings.Split(r.Header.Get("Prefer"), ",") {
+		if strings.TrimSpace(preference) == "respond-async" {
+			return true
 		}
 	}
+	return false
 }
 
 func (h *UpdatePlaylistHandler) SetPlaylistUpdateBuilder(builder playlist.PlaylistUpdateBuilder) {
@@ -132,3 +585,28 @@ func (h *UpdatePlaylistHandler) ReturnResponse(flag bool) {
 func (h *UpdatePlaylistHandler) SetSuccessStatusCode(status int) {
 	h.successStatusCode = status
 }
+
+func (h *UpdatePlaylistHandler) EnableJobs(store job.JobStore, executor job.Executor) {
+	h.jobStore = store
+	h.jobExecutor = executor
+}
+
+func (h *UpdatePlaylistHandler) EnableCovers(service cover.CoverService) {
+	h.coverService = service
+}
+
+func (h *UpdatePlaylistHandler) EnableRelatedArtists(expander playlist.LineupExpander) {
+	h.lineupExpander = expander
+}
+
+func (h *UpdatePlaylistHandler) SetMaxRelatedArtists(limit int) {
+	h.maxRelatedArtists = limit
+}
+
+func (h *UpdatePlaylistHandler) SetDescriptionGenerator(generator playlist.DescriptionGenerator) {
+	h.descriptionGenerator = generator
+}
+
+func (h *UpdatePlaylistHandler) SetJobsPath(path string) {
+	h.jobsPath = path
+}
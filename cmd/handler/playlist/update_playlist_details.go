@@ -0,0 +1,81 @@
+package playlist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	"festwrap/internal/serialization"
+)
+
+type UpdatePlaylistDetailsHandler struct {
+	playlistIdPath  string
+	playlistService playlist.PlaylistService
+	deserializer    serialization.Deserializer[playlist.PlaylistDetails]
+	logger          logging.Logger
+}
+
+// Applies partial updates to the playlist name, description, visibility and collaborative flag
+func NewUpdatePlaylistDetailsHandler(
+	playlistIdPath string,
+	playlistService playlist.PlaylistService,
+	logger logging.Logger,
+) UpdatePlaylistDetailsHandler {
+	return UpdatePlaylistDetailsHandler{
+		playlistIdPath:  playlistIdPath,
+		playlistService: playlistService,
+		deserializer:    serialization.NewJsonDeserializer[playlist.PlaylistDetails](),
+		logger:          logger,
+	}
+}
+
+func (h *UpdatePlaylistDetailsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	playlistId := r.PathValue(h.playlistIdPath)
+	if playlistId == "" {
+		detail := "playlist id was not provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("could not read request body: %v", err))
+		problem.Write(w, problem.InvalidBody("could not read request body"))
+		return
+	}
+
+	var details playlist.PlaylistDetails
+	if err = h.deserializer.Deserialize(body, &details); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not parse playlist details: %v", err))
+		problem.Write(w, problem.InvalidBody("invalid playlist details"))
+		return
+	}
+	details = details.Trimmed()
+
+	if err = details.Validate(); err != nil {
+		h.logger.Warn(fmt.Sprintf("validation error: %v", err))
+		problem.Write(w, problem.FromError(err, err.Error()))
+		return
+	}
+
+	if err = h.playlistService.UpdatePlaylistDetails(r.Context(), playlistId, details); err != nil {
+		h.logger.Error(fmt.Sprintf("could not update details of playlist %s: %v", playlistId, err))
+		problem.Write(w, problem.FromError(err, "could not update playlist"))
+		return
+	}
+
+	h.logger.Info(fmt.Sprintf("Updated details of playlist %s", playlistId))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UpdatePlaylistDetailsHandler) SetPlaylistService(service playlist.PlaylistService) {
+	h.playlistService = service
+}
+
+func (h *UpdatePlaylistDetailsHandler) SetDeserializer(deserializer serialization.Deserializer[playlist.PlaylistDetails]) {
+	h.deserializer = deserializer
+}
@@ -0,0 +1,112 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	playlistmocks "festwrap/internal/playlist/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func buildUpdateDetailsRequest(playlistId string, body string) *http.Request {
+	request := httptest.NewRequest("PATCH", "https://example.com/playlists/someId", strings.NewReader(body))
+	request.SetPathValue(playlistIdPath, playlistId)
+	return request
+}
+
+func updateDetailsSetup(
+	body string,
+) (UpdatePlaylistDetailsHandler, *http.Request, *httptest.ResponseRecorder, *playlistmocks.PlaylistServiceMock) {
+	request := buildUpdateDetailsRequest(playlistId, body)
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("UpdatePlaylistDetails", request.Context(), playlistId, mock.Anything).Return(nil)
+	handler := NewUpdatePlaylistDetailsHandler(playlistIdPath, playlistService, logging.NoopLogger{})
+	return handler, request, httptest.NewRecorder(), playlistService
+}
+
+func TestUpdatePlaylistDetailsHandlerReturnsBadRequestIfIdNotProvided(t *testing.T) {
+	handler, _, writer, _ := updateDetailsSetup(`{"name":"Hellfest"}`)
+
+	handler.ServeHTTP(writer, buildUpdateDetailsRequest("", `{"name":"Hellfest"}`))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestUpdatePlaylistDetailsHandlerReturnsBadRequestOnInvalidJson(t *testing.T) {
+	handler, request, writer, _ := updateDetailsSetup(`{"name":`)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestUpdatePlaylistDetailsHandlerReturnsUnprocessableEntityOnInvalidDetails(t *testing.T) {
+	tests := map[string]struct {
+		body string
+	}{
+		"no fields":                 {body: `{}`},
+		"empty name":                {body: `{"name":""}`},
+		"description with brackets": {body: `{"description":"<b>bold</b>"}`},
+		"public collaborative":      {body: `{"isPublic":true,"collaborative":true}`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer, playlistService := updateDetailsSetup(test.body)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+			playlistService.AssertNotCalled(t, "UpdatePlaylistDetails", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdatePlaylistDetailsHandlerCallsServiceWithProvidedFields(t *testing.T) {
+	handler, request, writer, _ := updateDetailsSetup(`{"name":"Hellfest","isPublic":false,"collaborative":true}`)
+	name := "Hellfest"
+	isPublic := false
+	collaborative := true
+	expected := playlist.PlaylistDetails{Name: &name, IsPublic: &isPublic, Collaborative: &collaborative}
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("UpdatePlaylistDetails", request.Context(), playlistId, expected).Return(nil)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	playlistService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistDetailsHandlerTrimsName(t *testing.T) {
+	handler, request, writer, _ := updateDetailsSetup(`{"name":"  Hellfest "}`)
+	name := "Hellfest"
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	expected := playlist.PlaylistDetails{Name: &name}
+	playlistService.On("UpdatePlaylistDetails", request.Context(), playlistId, expected).Return(nil)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	playlistService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistDetailsHandlerReturnsInternalErrorOnServiceError(t *testing.T) {
+	handler, request, writer, _ := updateDetailsSetup(`{"name":"Hellfest"}`)
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("UpdatePlaylistDetails", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test error"))
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
@@ -0,0 +1,90 @@
+package playlist
+
+import (
+	"festwrap/internal/playlist"
+	"festwrap/internal/serialization"
+)
+
+const (
+	addedTrackStatus   = "added"
+	skippedTrackStatus = "skipped"
+)
+
+// Responses to playlist updates can be exported as JSON, or as the list of setlist tracks found in
+// the playlist after the update either in NDJSON or CSV
+func newUpdatePlaylistResponseEncoders() serialization.EncoderRegistry[UpdatePlaylistResponse] {
+	encoders := serialization.NewEncoderRegistry[UpdatePlaylistResponse]()
+	encoders.Register(serialization.JsonMediaType, serialization.NewJsonEncoder[UpdatePlaylistResponse]())
+	encoders.Register(serialization.NdjsonMediaType, serialization.NewNdjsonEncoder(updatedTracks))
+	header := []string{"playlist", "artist", "date", "venue", "tour", "source", "title", "uri", "status"}
+	encoders.Register(
+		serialization.CsvMediaType,
+		serialization.NewCsvEncoder(header, func(response UpdatePlaylistResponse) [][]string {
+			tracks := updatedTracks(response)
+			records := make([][]string, len(tracks))
+			for i, track := range tracks {
+				records[i] = []string{
+					response.Playlist.Id,
+					track.Artist,
+					track.Date,
+					track.Venue,
+					track.Tour,
+					track.Source,
+					track.Title,
+					track.Uri,
+					track.Status,
+				}
+			}
+			return records
+		}),
+	)
+	return encoders
+}
+
+// Songs not found in Spotify or that could not be added are left out, since they are not in the playlist
+func updatedTracks(response UpdatePlaylistResponse) []UpdatedTrack {
+	tracks := []UpdatedTrack{}
+	for _, setlist := range response.Setlists {
+		if setlist.Songs == nil {
+			continue
+		}
+		tracks = append(tracks, toUpdatedTracks(setlist, setlist.Songs.Added, addedTrackStatus)...)
+		tracks = append(tracks, toUpdatedTracks(setlist, setlist.Songs.Skipped, skippedTrackStatus)...)
+	}
+	return tracks
+}
+
+func toUpdatedTracks(setlist playlist.SetlistProvenance, songs []playlist.SetlistSong, status string) []UpdatedTrack {
+	tracks := make([]UpdatedTrack, len(songs))
+	for i, song := range songs {
+		tracks[i] = UpdatedTrack{
+			Artist: setlist.Artist,
+			Date:   setlist.Date,
+			Venue:  setlist.Venue,
+			Tour:   setlist.Tour,
+			Source: string(setlist.Source),
+			Title:  song.Title,
+			Uri:    song.Uri,
+			Status: status,
+		}
+	}
+	return tracks
+}
+
+func newAsyncUpdatePlaylistResponseEncoders() serialization.EncoderRegistry[AsyncUpdatePlaylistResponse] {
+	encoders := serialization.NewEncoderRegistry[AsyncUpdatePlaylistResponse]()
+	encoders.Register(serialization.JsonMediaType, serialization.NewJsonEncoder[AsyncUpdatePlaylistResponse]())
+	encoders.Register(
+		serialization.NdjsonMediaType,
+		serialization.NewNdjsonEncoder(func(response AsyncUpdatePlaylistResponse) []AsyncUpdatePlaylistResponse {
+			return []AsyncUpdatePlaylistResponse{response}
+		}),
+	)
+	encoders.Register(
+		serialization.CsvMediaType,
+		serialization.NewCsvEncoder([]string{"playlist", "job"}, func(response AsyncUpdatePlaylistResponse) [][]string {
+			return [][]string{{response.Playlist.Id, response.Job.Id}}
+		}),
+	)
+	return encoders
+}
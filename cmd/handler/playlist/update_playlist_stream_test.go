@@ -0,0 +1,115 @@
+package playlist
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"festwrap/internal/logging"
+	"festwrap/internal/playlist"
+	playlistmocks "festwrap/internal/playlist/mocks"
+	buildermocks "festwrap/internal/playlist/update_builders/mocks"
+	setlisterrors "festwrap/internal/setlist/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Error messages may include the credentials of the requests sent, so they should not be streamed
+var setlistNotFoundErr = setlisterrors.NewSetlistNotFoundError("request with header x-api-key:secret failed")
+
+type streamEvent struct {
+	name string
+	data string
+}
+
+func notifySetlistFound(args mock.Arguments) {
+	ctx := args.Get(0).(context.Context)
+	artist := args.Get(2).(playlist.PlaylistArtist)
+	playlist.NotifyProgress(ctx, playlist.ProgressEvent{Type: playlist.SetlistFoundEvent, Artist: artist.Name, NumSongs: 3})
+}
+
+func notifySetlistNotFound(args mock.Arguments) {
+	ctx := args.Get(0).(context.Context)
+	artist := args.Get(2).(playlist.PlaylistArtist)
+	playlist.NotifyProgress(ctx, playlist.ProgressEvent{Type: playlist.SetlistNotFoundEvent, Artist: artist.Name, Err: setlistNotFoundErr})
+}
+
+func streamPlaylistService() *playlistmocks.PlaylistServiceMock {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Run(notifySetlistFound).Return(setlistProvenance("Comeback Kid"), nil)
+	playlistService.On("AddSetlist", mock.Anything, playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Run(notifySetlistNotFound).Return(playlist.SetlistProvenance{}, setlistNotFoundErr)
+	return playlistService
+}
+
+func streamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	builder.On("Build", mock.Anything).Return(playlist.PlaylistUpdate{PlaylistId: playlistId, Artists: updateArtists()}, nil)
+	handler := NewUpdatePlaylistHandler(streamPlaylistService(), &builder, logging.NoopLogger{})
+	server := httptest.NewServer(&handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readEvents(t *testing.T, response *http.Response) []streamEvent {
+	t.Helper()
+	events := []streamEvent{}
+	current := streamEvent{}
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		case line == "":
+			events = append(events, current)
+			current = streamEvent{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Could not read event stream: %v", err)
+	}
+	return events
+}
+
+func requestEventStream(t *testing.T, server *httptest.Server) *http.Response {
+	t.Helper()
+	request, err := http.NewRequest("POST", server.URL, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Could not create request: %v", err)
+	}
+	request.Header.Set("Accept", "text/event-stream")
+
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatalf("Could not send request: %v", err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func TestUpdatePlaylistHandlerStreamsEvents(t *testing.T) {
+	server := streamServer(t)
+
+	response := requestEventStream(t, server)
+
+	expected := []streamEvent{
+		{name: "setlistFound", data: `{"artist":"Comeback Kid","numSongs":3}`},
+		{
+			name: "artistUpdated",
+			data: `{"artist":"Comeback Kid","setlist":{"artist":"Comeback Kid","date":"2024-01-25","venue":"Gruenspan, Hamburg"}}`,
+		},
+		{name: "setlistNotFound", data: `{"artist":"Municipal Waste","error":"could not find a setlist for the artist"}`},
+		{name: "artistUpdated", data: `{"artist":"Municipal Waste","error":"could not find a setlist for the artist"}`},
+		{name: "finished", data: `{"playlist":{"id":"someId"},"status":207}`},
+	}
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	assert.Equal(t, expected, readEvents(t, response))
+}
@@ -2,19 +2,29 @@ package playlist
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"festwrap/internal/artist"
+	covermocks "festwrap/internal/cover/mocks"
+	senderrors "festwrap/internal/http/sender/errors"
+	"festwrap/internal/job"
 	"festwrap/internal/logging"
 	"festwrap/internal/playlist"
+	playlisterrors "festwrap/internal/playlist/errors"
 	playlistmocks "festwrap/internal/playlist/mocks"
 	buildermocks "festwrap/internal/playlist/update_builders/mocks"
+	"festwrap/internal/serialization"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const (
@@ -26,24 +36,49 @@ func updateArtists() []playlist.PlaylistArtist {
 	return []playlist.PlaylistArtist{{Name: "Comeback Kid"}, {Name: "Municipal Waste"}}
 }
 
+func setlistProvenance(artist string) playlist.SetlistProvenance {
+	return playlist.SetlistProvenance{Artist: artist, Date: "2024-01-25", Venue: "Gruenspan, Hamburg"}
+}
+
 func alwaysSuccessPlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
 	playlistService := &playlistmocks.PlaylistServiceMock{}
-	playlistService.On("AddSetlist", request.Context(), playlistId, "Municipal Waste").Return(nil)
-	playlistService.On("AddSetlist", request.Context(), playlistId, "Comeback Kid").Return(nil)
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(setlistProvenance("Municipal Waste"), nil)
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(setlistProvenance("Comeback Kid"), nil)
 	return playlistService
 }
 
 func alwaysErrorPlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
 	playlistService := &playlistmocks.PlaylistServiceMock{}
-	playlistService.On("AddSetlist", request.Context(), playlistId, "Municipal Waste").Return(errors.New("error 1"))
-	playlistService.On("AddSetlist", request.Context(), playlistId, "Comeback Kid").Return(errors.New("error 2"))
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(playlist.SetlistProvenance{}, errors.New("error 1"))
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(playlist.SetlistProvenance{}, errors.New("error 2"))
 	return playlistService
 }
 
 func partialErrorPlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
 	playlistService := &playlistmocks.PlaylistServiceMock{}
-	playlistService.On("AddSetlist", request.Context(), playlistId, "Municipal Waste").Return(nil)
-	playlistService.On("AddSetlist", request.Context(), playlistId, "Comeback Kid").Return(errors.New("error 1"))
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(playlist.SetlistProvenance{}, nil)
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(playlist.SetlistProvenance{}, errors.New("error 1"))
+	return playlistService
+}
+
+func replacePlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("ReplaceSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(playlist.SetlistProvenance{}, nil)
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(playlist.SetlistProvenance{}, nil)
+	return playlistService
+}
+
+func replaceFirstErrorPlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("ReplaceSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(playlist.SetlistProvenance{}, errors.New("error 1"))
+	playlistService.On("ReplaceSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(playlist.SetlistProvenance{}, nil)
+	return playlistService
+}
+
+func syncPlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("SyncSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(playlist.SetlistProvenance{}, nil)
+	playlistService.On("SyncSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(playlist.SetlistProvenance{}, nil)
 	return playlistService
 }
 
@@ -83,6 +118,36 @@ func TestUpdatePlaylistHandlerReturnsErrorOnUpdateBuilderError(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, writer.Code)
 }
 
+func TestUpdatePlaylistHandlerReturnsUpstreamAuthErrorsOnPlaylistCreation(t *testing.T) {
+	tests := map[string]struct {
+		upstreamStatus int
+		expected       int
+	}{
+		"expired token":            {upstreamStatus: http.StatusUnauthorized, expected: http.StatusUnauthorized},
+		"insufficient permissions": {upstreamStatus: http.StatusForbidden, expected: http.StatusForbidden},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer := setup(t)
+			authErr := senderrors.NewUpstreamError("test error", test.upstreamStatus, http.Header{}, "")
+			playlistService := &playlistmocks.PlaylistServiceMock{}
+			playlistService.On("CreatePlaylist", mock.Anything, *newPlaylist()).Return("", authErr)
+			handler.SetPlaylistService(playlistService)
+			builder := buildermocks.PlaylistUpdateBuilderMock{}
+			update := playlist.PlaylistUpdate{Artists: updateArtists(), NewPlaylist: newPlaylist()}
+			builder.On("Build", request).Return(update, nil)
+			handler.SetPlaylistUpdateBuilder(&builder)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expected, writer.Code)
+			assert.NotEmpty(t, writer.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
 func TestUpdatePlaylistHandlerReturnsErrorIfArtistsOutOfBounds(t *testing.T) {
 	tests := map[string]struct {
 		artists    []playlist.PlaylistArtist
@@ -144,6 +209,418 @@ func TestUpdatePlaylistHandlerStatusOnAllFailures(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, writer.Code)
 }
 
+func createdPlaylistSetup(
+	t *testing.T,
+	service *playlistmocks.PlaylistServiceMock,
+) (UpdatePlaylistHandler, *http.Request, *httptest.ResponseRecorder) {
+	t.Helper()
+	handler, request, writer := setup(t)
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	update := playlist.PlaylistUpdate{Artists: updateArtists(), NewPlaylist: newPlaylist()}
+	builder.On("Build", request).Return(update, nil)
+	handler.SetPlaylistUpdateBuilder(&builder)
+	handler.SetPlaylistService(withCreatePlaylist(service))
+	handler.ReturnResponse(true)
+	return handler, request, writer
+}
+
+func newPlaylist() *playlist.Playlist {
+	return &playlist.Playlist{Name: "My playlist", Description: "Some description"}
+}
+
+func withCreatePlaylist(service *playlistmocks.PlaylistServiceMock) *playlistmocks.PlaylistServiceMock {
+	service.On("CreatePlaylist", mock.Anything, *newPlaylist()).Return(playlistId, nil)
+	return service
+}
+
+func TestUpdatePlaylistHandlerCreatesNewPlaylist(t *testing.T) {
+	playlistService := anyContextPlaylistService(nil)
+	handler, request, writer := createdPlaylistSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusCreated, writer.Code)
+	playlistService.AssertCalled(t, "CreatePlaylist", request.Context(), *newPlaylist())
+}
+
+func TestUpdatePlaylistHandlerDoesNotCreatePlaylistOnInvalidUpdate(t *testing.T) {
+	tests := map[string]struct {
+		update playlist.PlaylistUpdate
+	}{
+		"no artists": {
+			update: playlist.PlaylistUpdate{NewPlaylist: newPlaylist()},
+		},
+		"more artists than limit": {
+			update: playlist.PlaylistUpdate{Artists: updateArtists(), NewPlaylist: newPlaylist()},
+		},
+		"negative related artists": {
+			update: playlist.PlaylistUpdate{Artists: updateArtists()[:1], NewPlaylist: newPlaylist(), RelatedArtists: -1},
+		},
+		"more related artists than limit": {
+			update: playlist.PlaylistUpdate{Artists: updateArtists()[:1], NewPlaylist: newPlaylist(), RelatedArtists: 6},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer := setup(t)
+			playlistService := &playlistmocks.PlaylistServiceMock{}
+			handler.SetPlaylistService(playlistService)
+			builder := buildermocks.PlaylistUpdateBuilderMock{}
+			builder.On("Build", request).Return(test.update, nil)
+			handler.SetPlaylistUpdateBuilder(&builder)
+			handler.SetMaxArtists(1)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			playlistService.AssertNotCalled(t, "CreatePlaylist", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdatePlaylistHandlerReturnsErrorOnPlaylistCreation(t *testing.T) {
+	handler, request, writer := setup(t)
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	createErr := playlisterrors.NewCannotCreatePlaylistError("test error")
+	playlistService.On("CreatePlaylist", mock.Anything, *newPlaylist()).Return("", createErr)
+	handler.SetPlaylistService(playlistService)
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	update := playlist.PlaylistUpdate{Artists: updateArtists(), NewPlaylist: newPlaylist()}
+	builder.On("Build", request).Return(update, nil)
+	handler.SetPlaylistUpdateBuilder(&builder)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusBadGateway, writer.Code)
+	playlistService.AssertNotCalled(t, "AddSetlist", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdatePlaylistHandlerReturnsUpstreamAuthErrorsOnAllFailures(t *testing.T) {
+	tests := map[string]struct {
+		upstreamStatus int
+		otherErr       error
+		expected       int
+	}{
+		"expired token": {
+			upstreamStatus: http.StatusUnauthorized,
+			expected:       http.StatusUnauthorized,
+		},
+		"insufficient permissions": {
+			upstreamStatus: http.StatusForbidden,
+			expected:       http.StatusForbidden,
+		},
+		"other artist failing for other reasons": {
+			upstreamStatus: http.StatusUnauthorized,
+			otherErr:       errors.New("test error"),
+			expected:       http.StatusUnauthorized,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer := setup(t)
+			handler.ReturnResponse(true)
+			authErr := senderrors.NewUpstreamError("test error", test.upstreamStatus, http.Header{}, "")
+			otherErr := test.otherErr
+			if otherErr == nil {
+				otherErr = authErr
+			}
+			playlistService := &playlistmocks.PlaylistServiceMock{}
+			comebackKid := playlist.PlaylistArtist{Name: "Comeback Kid"}
+			municipalWaste := playlist.PlaylistArtist{Name: "Municipal Waste"}
+			playlistService.On("AddSetlist", mock.Anything, playlistId, comebackKid).Return(playlist.SetlistProvenance{}, authErr)
+			playlistService.On("AddSetlist", mock.Anything, playlistId, municipalWaste).Return(playlist.SetlistProvenance{}, otherErr)
+			handler.SetPlaylistService(playlistService)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expected, writer.Code)
+			assert.NotEmpty(t, writer.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestUpdatePlaylistHandlerReturnsMultiStatusOnPartialAuthFailures(t *testing.T) {
+	handler, request, writer := setup(t)
+	authErr := senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, "")
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	comebackKid := playlist.PlaylistArtist{Name: "Comeback Kid"}
+	municipalWaste := playlist.PlaylistArtist{Name: "Municipal Waste"}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, comebackKid).Return(playlist.SetlistProvenance{}, authErr)
+	playlistService.On("AddSetlist", mock.Anything, playlistId, municipalWaste).Return(setlistProvenance("Municipal Waste"), nil)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusMultiStatus, writer.Code)
+}
+
+func TestUpdatePlaylistHandlerRollsBackCreatedPlaylistOnAllFailures(t *testing.T) {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, mock.Anything).Return(playlist.SetlistProvenance{}, errors.New("some error"))
+	playlistService.On("DeletePlaylist", mock.Anything, playlistId).Return(nil)
+	handler, request, writer := createdPlaylistSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	expected := fmt.Sprintf("{\"playlist\":{\"id\":\"%s\"},\"rolledBack\":true}\n", playlistId)
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.Equal(t, expected, writer.Body.String())
+	playlistService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistHandlerDoesNotRollBackCreatedPlaylistOnPartialErrors(t *testing.T) {
+	playlistService := anyContextPlaylistService(errors.New("error 1"))
+	handler, request, writer := createdPlaylistSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusMultiStatus, writer.Code)
+	playlistService.AssertNotCalled(t, "DeletePlaylist", mock.Anything, playlistId)
+}
+
+func TestUpdatePlaylistHandlerDoesNotRollBackExistingPlaylistOnAllFailures(t *testing.T) {
+	handler, request, writer := setup(t)
+	playlistService := alwaysErrorPlaylistService(request)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	playlistService.AssertNotCalled(t, "DeletePlaylist", mock.Anything, playlistId)
+}
+
+func TestUpdatePlaylistHandlerReportsNoRollbackIfPlaylistCannotBeDeleted(t *testing.T) {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, mock.Anything).Return(playlist.SetlistProvenance{}, errors.New("some error"))
+	playlistService.On("DeletePlaylist", mock.Anything, playlistId).Return(errors.New("delete error"))
+	handler, request, writer := createdPlaylistSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	expected := fmt.Sprintf("{\"playlist\":{\"id\":\"%s\"}}\n", playlistId)
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.Equal(t, expected, writer.Body.String())
+}
+
+func coverSetup(
+	t *testing.T,
+	service *playlistmocks.PlaylistServiceMock,
+) (UpdatePlaylistHandler, *http.Request, *httptest.ResponseRecorder, *covermocks.CoverServiceMock) {
+	t.Helper()
+	handler, request, writer := setup(t)
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	update := playlist.PlaylistUpdate{
+		Artists:       updateArtists(),
+		NewPlaylist:   newPlaylist(),
+		Name:          "My playlist",
+		GenerateCover: true,
+	}
+	builder.On("Build", request).Return(update, nil)
+	handler.SetPlaylistUpdateBuilder(&builder)
+	handler.SetPlaylistService(withCreatePlaylist(service))
+	coverService := &covermocks.CoverServiceMock{}
+	handler.EnableCovers(coverService)
+	return handler, request, writer, coverService
+}
+
+func TestUpdatePlaylistHandlerGeneratesCoverIfRequested(t *testing.T) {
+	handler, request, writer, coverService := coverSetup(t, anyContextPlaylistService(nil))
+	artists := []string{"Comeback Kid", "Municipal Waste"}
+	coverService.On("SetCover", request.Context(), playlistId, "My playlist", artists).Return(nil)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusCreated, writer.Code)
+	coverService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistHandlerSucceedsOnCoverError(t *testing.T) {
+	handler, request, writer, coverService := coverSetup(t, anyContextPlaylistService(nil))
+	coverService.On("SetCover", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test error"))
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusCreated, writer.Code)
+}
+
+func TestUpdatePlaylistHandlerDoesNotGenerateCoverIfRolledBack(t *testing.T) {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, mock.Anything).Return(playlist.SetlistProvenance{}, errors.New("some error"))
+	playlistService.On("DeletePlaylist", mock.Anything, playlistId).Return(nil)
+	handler, request, writer, coverService := coverSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	coverService.AssertNotCalled(t, "SetCover", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func descriptionSetup(
+	t *testing.T,
+	service *playlistmocks.PlaylistServiceMock,
+) (UpdatePlaylistHandler, *http.Request, *httptest.ResponseRecorder) {
+	t.Helper()
+	handler, request, writer := setup(t)
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	update := playlist.PlaylistUpdate{
+		Artists:             updateArtists(),
+		NewPlaylist:         newPlaylist(),
+		Name:                "My playlist",
+		GenerateDescription: true,
+	}
+	builder.On("Build", request).Return(update, nil)
+	handler.SetPlaylistUpdateBuilder(&builder)
+	handler.SetPlaylistService(withCreatePlaylist(service))
+	handler.ReturnResponse(true)
+	return handler, request, writer
+}
+
+func TestUpdatePlaylistHandlerSetsDescriptionFromAddedSetlists(t *testing.T) {
+	playlistService := anyContextPlaylistService(errors.New("error 1"))
+	description := "Setlists of Municipal Waste at Gruenspan, Hamburg on 2024-01-25"
+	details := playlist.PlaylistDetails{Description: &description}
+	playlistService.On("UpdatePlaylistDetails", mock.Anything, playlistId, details).Return(nil)
+	handler, request, writer := descriptionSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	var response UpdatePlaylistResponse
+	err := json.Unmarshal(writer.Body.Bytes(), &response)
+	assert.Nil(t, err)
+	assert.Equal(t, description, response.Description)
+	playlistService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistHandlerSetsDescriptionFromTemplate(t *testing.T) {
+	playlistService := anyContextPlaylistService(nil)
+	description := "My playlist: 2 setlists"
+	details := playlist.PlaylistDetails{Description: &description}
+	playlistService.On("UpdatePlaylistDetails", mock.Anything, playlistId, details).Return(nil)
+	handler, request, writer := descriptionSetup(t, playlistService)
+	generator := playlist.NewDescriptionGenerator()
+	err := generator.SetTemplate("{{.Name}}: {{len .Setlists}} setlists")
+	assert.Nil(t, err)
+	handler.SetDescriptionGenerator(generator)
+
+	handler.ServeHTTP(writer, request)
+
+	playlistService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistHandlerSucceedsOnDescriptionError(t *testing.T) {
+	playlistService := anyContextPlaylistService(nil)
+	playlistService.On("UpdatePlaylistDetails", mock.Anything, playlistId, mock.Anything).Return(errors.New("test error"))
+	handler, request, writer := descriptionSetup(t, playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	var response UpdatePlaylistResponse
+	err := json.Unmarshal(writer.Body.Bytes(), &response)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, writer.Code)
+	assert.Empty(t, response.Description)
+}
+
+func TestUpdatePlaylistHandlerDoesNotSetDescriptionIfNotRequested(t *testing.T) {
+	handler, request, writer := setup(t)
+	playlistService := alwaysSuccessPlaylistService(request)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	playlistService.AssertNotCalled(t, "UpdatePlaylistDetails", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func relatedArtistsSetup(
+	t *testing.T,
+	relatedArtists int,
+) (UpdatePlaylistHandler, *http.Request, *httptest.ResponseRecorder, *artist.FakeArtistRepository) {
+	t.Helper()
+	handler, request, writer := setup(t)
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	update := playlist.PlaylistUpdate{
+		PlaylistId:     playlistId,
+		Artists:        []playlist.PlaylistArtist{{Name: "Comeback Kid", SpotifyId: "comebackKid"}},
+		RelatedArtists: relatedArtists,
+	}
+	builder.On("Build", request).Return(update, nil)
+	handler.SetPlaylistUpdateBuilder(&builder)
+	handler.ReturnResponse(true)
+
+	artistRepository := &artist.FakeArtistRepository{}
+	relatedArtist := artist.NewArtist("Municipal Waste")
+	relatedArtist.Id = "municipalWaste"
+	artistRepository.SetRelatedArtists("comebackKid", []artist.Artist{relatedArtist})
+	expander := playlist.NewRelatedArtistsExpander(artistRepository)
+	handler.EnableRelatedArtists(&expander)
+	return handler, request, writer, artistRepository
+}
+
+func TestUpdatePlaylistHandlerAddsRelatedArtists(t *testing.T) {
+	handler, request, writer, _ := relatedArtistsSetup(t, 1)
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	comebackKid := playlist.PlaylistArtist{Name: "Comeback Kid", SpotifyId: "comebackKid"}
+	municipalWaste := playlist.PlaylistArtist{Name: "Municipal Waste", SpotifyId: "municipalWaste", RelatedTo: "Comeback Kid"}
+	playlistService.On("AddSetlist", request.Context(), playlistId, comebackKid).Return(setlistProvenance("Comeback Kid"), nil)
+	playlistService.On("AddSetlist", request.Context(), playlistId, municipalWaste).Return(setlistProvenance("Municipal Waste"), nil)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	var response UpdatePlaylistResponse
+	err := json.Unmarshal(writer.Body.Bytes(), &response)
+	expected := []RelatedArtist{{Name: "Municipal Waste", SpotifyId: "municipalWaste", RelatedTo: "Comeback Kid"}}
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, writer.Code)
+	assert.Equal(t, expected, response.RelatedArtists)
+	playlistService.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistHandlerAddsRequestedArtistsOnRelatedArtistsError(t *testing.T) {
+	handler, request, writer, artistRepository := relatedArtistsSetup(t, 1)
+	artistRepository.SetRelatedArtistsError(errors.New("test error"))
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	comebackKid := playlist.PlaylistArtist{Name: "Comeback Kid", SpotifyId: "comebackKid"}
+	playlistService.On("AddSetlist", request.Context(), playlistId, comebackKid).Return(setlistProvenance("Comeback Kid"), nil)
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	var response UpdatePlaylistResponse
+	err := json.Unmarshal(writer.Body.Bytes(), &response)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, writer.Code)
+	assert.Empty(t, response.RelatedArtists)
+	playlistService.AssertNumberOfCalls(t, "AddSetlist", 1)
+}
+
+func TestUpdatePlaylistHandlerReturnsErrorIfRelatedArtistsOutOfBounds(t *testing.T) {
+	tests := map[string]struct {
+		relatedArtists int
+	}{
+		"negative": {
+			relatedArtists: -1,
+		},
+		"more than limit": {
+			relatedArtists: 3,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer, _ := relatedArtistsSetup(t, test.relatedArtists)
+			handler.SetMaxRelatedArtists(2)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+		})
+	}
+}
+
 func TestUpdatePlaylistHandlerStatusOnPartialErrors(t *testing.T) {
 	handler, request, writer := setup(t)
 	playlistService := partialErrorPlaylistService(request)
@@ -161,7 +638,12 @@ func TestUpdatePlaylistHandlerShouldReturnResponseIfEnabled(t *testing.T) {
 	}{
 		"enabled": {
 			returnResponse: true,
-			expected:       fmt.Sprintf("{\"playlist\":{\"id\":\"%s\"}}\n", playlistId),
+			expected: fmt.Sprintf(
+				`{"playlist":{"id":"%s"},"setlists":[%s,%s]}`+"\n",
+				playlistId,
+				`{"artist":"Comeback Kid","date":"2024-01-25","venue":"Gruenspan, Hamburg"}`,
+				`{"artist":"Municipal Waste","date":"2024-01-25","venue":"Gruenspan, Hamburg"}`,
+			),
 		},
 		"disabled": {
 			returnResponse: false,
@@ -183,6 +665,157 @@ func TestUpdatePlaylistHandlerShouldReturnResponseIfEnabled(t *testing.T) {
 	}
 }
 
+func songsReportPlaylistService() *playlistmocks.PlaylistServiceMock {
+	setlist := setlistProvenance("Comeback Kid")
+	setlist.Songs = &playlist.SetlistSongs{
+		Added:    []playlist.SetlistSong{{Title: "Wake the Dead", Uri: "spotify:track:wake"}},
+		NotAdded: []playlist.SetlistSong{{Title: "G.M. Vincent & I", Uri: "spotify:track:vincent"}},
+		NotFound: []playlist.SetlistSong{{Title: "Die Tonight"}},
+	}
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, mock.Anything).Return(setlist, nil)
+	return playlistService
+}
+
+func TestUpdatePlaylistHandlerReturnsSongsOfSetlists(t *testing.T) {
+	handler, request, writer := setup(t)
+	handler.SetPlaylistService(songsReportPlaylistService())
+	handler.ReturnResponse(true)
+
+	handler.ServeHTTP(writer, request)
+
+	expected := `"songs":{` +
+		`"added":[{"title":"Wake the Dead","uri":"spotify:track:wake"}],` +
+		`"notAdded":[{"title":"G.M. Vincent \u0026 I","uri":"spotify:track:vincent"}],` +
+		`"notFound":[{"title":"Die Tonight"}]}`
+	assert.Equal(t, http.StatusCreated, writer.Code)
+	assert.Contains(t, writer.Body.String(), expected)
+}
+
+func TestUpdatePlaylistHandlerStoresSongsOfSetlistsInAsyncJob(t *testing.T) {
+	handler, request, writer, store := asyncSetup(t)
+	handler.SetPlaylistService(songsReportPlaylistService())
+
+	handler.ServeHTTP(writer, request)
+
+	actual, err := store.Get(readJobId(t, writer))
+	expected := []playlist.SetlistSong{{Title: "Die Tonight"}}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual.Artists[0].Setlist.Songs.NotFound)
+}
+
+func tracksPlaylistService(request *http.Request) *playlistmocks.PlaylistServiceMock {
+	comebackKid := setlistProvenance("Comeback Kid")
+	comebackKid.Songs = &playlist.SetlistSongs{
+		Added:    []playlist.SetlistSong{{Title: "Wake the Dead", Uri: "spotify:track:wake"}},
+		Skipped:  []playlist.SetlistSong{{Title: "False Idols Fall", Uri: "spotify:track:idols"}},
+		NotFound: []playlist.SetlistSong{{Title: "Die Tonight"}},
+	}
+	municipalWaste := setlistProvenance("Municipal Waste")
+	municipalWaste.Songs = &playlist.SetlistSongs{
+		NotAdded: []playlist.SetlistSong{{Title: "Born to Party", Uri: "spotify:track:party"}},
+	}
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(municipalWaste, nil)
+	playlistService.On("AddSetlist", request.Context(), playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(comebackKid, nil)
+	return playlistService
+}
+
+func TestUpdatePlaylistHandlerReturnsResponseInAcceptedMediaType(t *testing.T) {
+	tests := map[string]struct {
+		accept      string
+		contentType string
+		expected    string
+	}{
+		"json by default": {
+			accept:      "",
+			contentType: "application/json",
+			expected: fmt.Sprintf(
+				`{"playlist":{"id":"%s"},"setlists":[%s,%s]}`+"\n",
+				playlistId,
+				`{"artist":"Comeback Kid","date":"2024-01-25","venue":"Gruenspan, Hamburg","songs":{`+
+					`"added":[{"title":"Wake the Dead","uri":"spotify:track:wake"}],`+
+					`"skipped":[{"title":"False Idols Fall","uri":"spotify:track:idols"}],`+
+					`"notFound":[{"title":"Die Tonight"}]}}`,
+				`{"artist":"Municipal Waste","date":"2024-01-25","venue":"Gruenspan, Hamburg","songs":{`+
+					`"notAdded":[{"title":"Born to Party","uri":"spotify:track:party"}]}}`,
+			),
+		},
+		"ndjson": {
+			accept:      "application/x-ndjson",
+			contentType: "application/x-ndjson",
+			expected: `{"artist":"Comeback Kid","date":"2024-01-25","venue":"Gruenspan, Hamburg",` +
+				`"title":"Wake the Dead","uri":"spotify:track:wake","status":"added"}` + "\n" +
+				`{"artist":"Comeback Kid","date":"2024-01-25","venue":"Gruenspan, Hamburg",` +
+				`"title":"False Idols Fall","uri":"spotify:track:idols","status":"skipped"}` + "\n",
+		},
+		"csv": {
+			accept:      "text/csv",
+			contentType: "text/csv",
+			expected: "playlist,artist,date,venue,tour,source,title,uri,status\n" +
+				fmt.Sprintf(
+					"%s,Comeback Kid,2024-01-25,\"Gruenspan, Hamburg\",,,Wake the Dead,spotify:track:wake,added\n",
+					playlistId,
+				) +
+				fmt.Sprintf(
+					"%s,Comeback Kid,2024-01-25,\"Gruenspan, Hamburg\",,,False Idols Fall,spotify:track:idols,skipped\n",
+					playlistId,
+				),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer := setup(t)
+			handler.SetPlaylistService(tracksPlaylistService(request))
+			handler.ReturnResponse(true)
+			request.Header.Set("Accept", test.accept)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusCreated, writer.Code)
+			assert.Equal(t, test.contentType, writer.Header().Get("Content-Type"))
+			assert.Equal(t, test.expected, writer.Body.String())
+		})
+	}
+}
+
+func TestUpdatePlaylistHandlerReturnsInternalErrorIfResponseCannotBeEncoded(t *testing.T) {
+	handler, request, writer := setup(t)
+	handler.ReturnResponse(true)
+	encoder := serialization.FakeEncoder[UpdatePlaylistResponse]{}
+	encoder.SetError(errors.New("test error"))
+	handler.responseEncoders.Register(serialization.JsonMediaType, encoder)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.Equal(t, "application/problem+json", writer.Header().Get("Content-Type"))
+}
+
+func TestUpdatePlaylistHandlerReturnsNotAcceptableBeforeUpdating(t *testing.T) {
+	handler, request, writer := setup(t)
+	handler.ReturnResponse(true)
+	builder := buildermocks.PlaylistUpdateBuilderMock{}
+	handler.SetPlaylistUpdateBuilder(&builder)
+	request.Header.Set("Accept", "application/xml")
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusNotAcceptable, writer.Code)
+	builder.AssertNotCalled(t, "Build", mock.Anything)
+}
+
+func TestUpdatePlaylistHandlerIgnoresAcceptIfNoResponseReturned(t *testing.T) {
+	handler, request, writer := setup(t)
+	request.Header.Set("Accept", "application/xml")
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusCreated, writer.Code)
+}
+
 func TestUpdatePlaylistHandlerReturnsGivenStatus(t *testing.T) {
 	status := http.StatusContinue
 	handler, request, writer := setup(t)
@@ -192,3 +825,189 @@ func TestUpdatePlaylistHandlerReturnsGivenStatus(t *testing.T) {
 
 	assert.Equal(t, status, writer.Code)
 }
+
+func TestUpdatePlaylistHandlerUpdatesSetlistsDependingOnMode(t *testing.T) {
+	tests := map[string]struct {
+		mode    playlist.UpdateMode
+		service func(*http.Request) *playlistmocks.PlaylistServiceMock
+	}{
+		"append": {
+			mode:    playlist.AppendMode,
+			service: alwaysSuccessPlaylistService,
+		},
+		"replace only first artist": {
+			mode:    playlist.ReplaceMode,
+			service: replacePlaylistService,
+		},
+		"replace until first success": {
+			mode:    playlist.ReplaceMode,
+			service: replaceFirstErrorPlaylistService,
+		},
+		"sync": {
+			mode:    playlist.SyncMode,
+			service: syncPlaylistService,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, request, writer := setup(t)
+			builder := buildermocks.PlaylistUpdateBuilderMock{}
+			builder.On("Build", request).Return(
+				playlist.PlaylistUpdate{PlaylistId: playlistId, Artists: updateArtists(), Mode: test.mode},
+				nil,
+			)
+			handler.SetPlaylistUpdateBuilder(&builder)
+			playlistService := test.service(request)
+			handler.SetPlaylistService(playlistService)
+
+			handler.ServeHTTP(writer, request)
+
+			playlistService.AssertExpectations(t)
+		})
+	}
+}
+
+// Async updates run with a context detached from the request one
+func anyContextPlaylistService(comebackKidErr error) *playlistmocks.PlaylistServiceMock {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, playlist.PlaylistArtist{Name: "Municipal Waste"}).Return(setlistProvenance("Municipal Waste"), nil)
+	playlistService.On("AddSetlist", mock.Anything, playlistId, playlist.PlaylistArtist{Name: "Comeback Kid"}).Return(playlist.SetlistProvenance{}, comebackKidErr)
+	return playlistService
+}
+
+func asyncSetup(t *testing.T) (UpdatePlaylistHandler, *http.Request, *httptest.ResponseRecorder, job.MemoryJobStore) {
+	t.Helper()
+	handler, request, writer := setup(t)
+	request.Header.Set("Prefer", "respond-async")
+	store := job.NewMemoryJobStore(time.Minute)
+	handler.EnableJobs(store, &job.FakeExecutor{})
+	handler.SetPlaylistService(anyContextPlaylistService(nil))
+	return handler, request, writer, store
+}
+
+func readJobId(t *testing.T, writer *httptest.ResponseRecorder) string {
+	t.Helper()
+	var response AsyncUpdatePlaylistResponse
+	if err := json.Unmarshal(writer.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Could not read async response: %v", err)
+	}
+	return response.Job.Id
+}
+
+func TestUpdatePlaylistHandlerRunsSynchronouslyIfJobsNotEnabled(t *testing.T) {
+	handler, request, writer := setup(t)
+	request.Header.Set("Prefer", "respond-async")
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusCreated, writer.Code)
+}
+
+func TestUpdatePlaylistHandlerReturnsAcceptedForAsyncRequests(t *testing.T) {
+	handler, request, writer, _ := asyncSetup(t)
+
+	handler.ServeHTTP(writer, request)
+
+	jobId := readJobId(t, writer)
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+	assert.Equal(t, fmt.Sprintf("/jobs/%s", jobId), writer.Header().Get("Location"))
+}
+
+func TestUpdatePlaylistHandlerStoresAsyncJobResult(t *testing.T) {
+	handler, request, writer, store := asyncSetup(t)
+	handler.SetPlaylistService(anyContextPlaylistService(errors.New("error 1")))
+
+	handler.ServeHTTP(writer, request)
+
+	actual, err := store.Get(readJobId(t, writer))
+	municipalWasteSetlist := setlistProvenance("Municipal Waste")
+	expected := []job.ArtistProgress{
+		{Name: "Comeback Kid", Status: job.FailedStatus, Error: "unexpected error"},
+		{Name: "Municipal Waste", Status: job.CompletedStatus, Setlist: &municipalWasteSetlist},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, playlistId, actual.PlaylistId)
+	assert.Equal(t, job.PartiallyCompletedStatus, actual.Status)
+	assert.Equal(t, expected, actual.Artists)
+}
+
+func TestUpdatePlaylistHandlerStoresAsyncJobRollback(t *testing.T) {
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, mock.Anything).Return(playlist.SetlistProvenance{}, errors.New("some error"))
+	playlistService.On("DeletePlaylist", mock.Anything, playlistId).Return(nil)
+	handler, request, writer := createdPlaylistSetup(t, playlistService)
+	request.Header.Set("Prefer", "respond-async")
+	store := job.NewMemoryJobStore(time.Minute)
+	handler.EnableJobs(store, &job.FakeExecutor{})
+
+	handler.ServeHTTP(writer, request)
+
+	actual, err := store.Get(readJobId(t, writer))
+	assert.Nil(t, err)
+	assert.Equal(t, job.FailedStatus, actual.Status)
+	assert.True(t, actual.RolledBack)
+}
+
+func TestUpdatePlaylistHandlerFailsAsyncJobOnPanic(t *testing.T) {
+	handler, request, writer, store := asyncSetup(t)
+	playlistService := &playlistmocks.PlaylistServiceMock{}
+	playlistService.On("AddSetlist", mock.Anything, playlistId, mock.Anything).Run(func(args mock.Arguments) {
+		panic("test panic")
+	})
+	handler.SetPlaylistService(playlistService)
+
+	handler.ServeHTTP(writer, request)
+
+	actual, err := store.Get(readJobId(t, writer))
+	assert.Nil(t, err)
+	assert.Equal(t, job.FailedStatus, actual.Status)
+	for _, artist := range actual.Artists {
+		assert.Equal(t, job.FailedStatus, artist.Status)
+	}
+}
+
+func TestUpdatePlaylistHandlerReturnsUnavailableIfJobCannotBeSubmitted(t *testing.T) {
+	handler, request, writer, store := asyncSetup(t)
+	executor := job.FakeExecutor{}
+	executor.SetError(errors.New("test error"))
+	handler.EnableJobs(store, &executor)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+}
+
+func TestUpdatePlaylistHandlerReturnsAsyncResponseInAcceptedMediaType(t *testing.T) {
+	handler, request, writer, _ := asyncSetup(t)
+	request.Header.Set("Accept", "text/csv")
+
+	handler.ServeHTTP(writer, request)
+
+	jobId := strings.TrimPrefix(writer.Header().Get("Location"), "/jobs/")
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+	assert.Equal(t, "text/csv", writer.Header().Get("Content-Type"))
+	assert.Equal(t, fmt.Sprintf("playlist,job\n%s,%s\n", playlistId, jobId), writer.Body.String())
+}
+
+func TestUpdatePlaylistHandlerReturnsInternalErrorIfAsyncResponseCannotBeEncoded(t *testing.T) {
+	handler, request, writer, _ := asyncSetup(t)
+	encoder := serialization.FakeEncoder[AsyncUpdatePlaylistResponse]{}
+	encoder.SetError(errors.New("test error"))
+	handler.asyncResponseEncoders.Register(serialization.JsonMediaType, encoder)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.Empty(t, writer.Header().Get("Location"))
+}
+
+func TestUpdatePlaylistHandlerReturnsNotAcceptableForAsyncRequests(t *testing.T) {
+	handler, request, writer, _ := asyncSetup(t)
+	request.Header.Set("Accept", "application/xml")
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusNotAcceptable, writer.Code)
+}
@@ -0,0 +1,33 @@
+package search
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"festwrap/internal/artist"
+)
+
+// Uses as artist image the one closest to the size in pixels given by the imageSize query parameter
+func ArtistImageSizeTransformer(r *http.Request) (func(artists []artist.Artist) []artist.Artist, error) {
+	sizeStr := r.URL.Query().Get("imageSize")
+	if sizeStr == "" {
+		return func(artists []artist.Artist) []artist.Artist { return artists }, nil
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size < 1 {
+		return nil, fmt.Errorf("imageSize must be a positive integer, found %s", sizeStr)
+	}
+
+	return func(artists []artist.Artist) []artist.Artist {
+		result := make([]artist.Artist, len(artists))
+		for i, currentArtist := range artists {
+			if image, found := currentArtist.ClosestImage(size); found {
+				currentArtist.SetImageUri(image.Url)
+			}
+			result[i] = currentArtist
+		}
+		return result
+	}, nil
+}
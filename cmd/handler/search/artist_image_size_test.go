@@ -0,0 +1,65 @@
+package search
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"festwrap/internal/artist"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func imageArtists() []artist.Artist {
+	return []artist.Artist{
+		{
+			Name:     "Movements",
+			ImageUri: "small",
+			Images: []artist.Image{
+				{Url: "large", Width: 640, Height: 640},
+				{Url: "medium", Width: 320, Height: 320},
+				{Url: "small", Width: 160, Height: 160},
+			},
+		},
+		{Name: "Citizen"},
+	}
+}
+
+func TestArtistImageSizeTransformerSelectsClosestImage(t *testing.T) {
+	request := httptest.NewRequest("GET", "https://example.com/artists/search?name=Movements&imageSize=300", nil)
+
+	transform, err := ArtistImageSizeTransformer(request)
+
+	actual := transform(imageArtists())
+	assert.Nil(t, err)
+	assert.Equal(t, "medium", actual[0].ImageUri)
+	assert.Equal(t, "", actual[1].ImageUri)
+}
+
+func TestArtistImageSizeTransformerKeepsImagesIfSizeNotProvided(t *testing.T) {
+	request := httptest.NewRequest("GET", "https://example.com/artists/search?name=Movements", nil)
+
+	transform, err := ArtistImageSizeTransformer(request)
+
+	assert.Nil(t, err)
+	assert.Equal(t, imageArtists(), transform(imageArtists()))
+}
+
+func TestArtistImageSizeTransformerReturnsErrorOnInvalidSize(t *testing.T) {
+	tests := map[string]struct {
+		size string
+	}{
+		"not an integer": {size: "big"},
+		"zero":           {size: "0"},
+		"negative":       {size: "-100"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			request := httptest.NewRequest("GET", "https://example.com/artists/search?imageSize="+test.size, nil)
+
+			_, err := ArtistImageSizeTransformer(request)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
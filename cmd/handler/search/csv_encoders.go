@@ -0,0 +1,41 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"festwrap/internal/artist"
+	"festwrap/internal/playlist"
+	"festwrap/internal/serialization"
+)
+
+// Encodes artists as CSV. Genres are joined with semicolons, since they are a list
+func NewArtistsCsvEncoder() serialization.CsvEncoder[[]artist.Artist] {
+	header := []string{"id", "uri", "name", "imageUri", "genres", "popularity", "followers"}
+	return serialization.NewCsvEncoder(header, func(artists []artist.Artist) [][]string {
+		records := make([][]string, len(artists))
+		for i, artist := range artists {
+			records[i] = []string{
+				artist.Id,
+				artist.Uri,
+				artist.Name,
+				artist.ImageUri,
+				strings.Join(artist.Genres, ";"),
+				fmt.Sprint(artist.Popularity),
+				fmt.Sprint(artist.Followers),
+			}
+		}
+		return records
+	})
+}
+
+func NewPlaylistsCsvEncoder() serialization.CsvEncoder[[]playlist.Playlist] {
+	header := []string{"id", "name", "description", "isPublic"}
+	return serialization.NewCsvEncoder(header, func(playlists []playlist.Playlist) [][]string {
+		records := make([][]string, len(playlists))
+		for i, playlist := range playlists {
+			records[i] = []string{playlist.Id, playlist.Name, playlist.Description, fmt.Sprint(playlist.IsPublic)}
+		}
+		return records
+	})
+}
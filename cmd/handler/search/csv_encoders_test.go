@@ -0,0 +1,52 @@
+package search
+
+import (
+	"bytes"
+	"testing"
+
+	"festwrap/internal/artist"
+	"festwrap/internal/playlist"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtistsCsvEncoderWritesArtists(t *testing.T) {
+	artists := []artist.Artist{
+		{
+			Id:         "3WrFJ7ztbogyGnTHbHJFl2",
+			Uri:        "spotify:artist:3WrFJ7ztbogyGnTHbHJFl2",
+			Name:       "The Beatles",
+			ImageUri:   "https://i.scdn.co/image/small",
+			Genres:     []string{"british invasion", "rock"},
+			Popularity: 85,
+			Followers:  28265225,
+		},
+		{Name: "Unknown"},
+	}
+	buffer := bytes.Buffer{}
+
+	err := NewArtistsCsvEncoder().Encode(&buffer, artists)
+
+	expected := "id,uri,name,imageUri,genres,popularity,followers\n" +
+		"3WrFJ7ztbogyGnTHbHJFl2,spotify:artist:3WrFJ7ztbogyGnTHbHJFl2,The Beatles,https://i.scdn.co/image/small," +
+		"british invasion;rock,85,28265225\n" +
+		",,Unknown,,,0,0\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestPlaylistsCsvEncoderWritesPlaylists(t *testing.T) {
+	playlists := []playlist.Playlist{
+		{Id: "id1", Name: "Festival, 2025", Description: "Some description", IsPublic: true},
+		{Id: "id2", Name: "Other"},
+	}
+	buffer := bytes.Buffer{}
+
+	err := NewPlaylistsCsvEncoder().Encode(&buffer, playlists)
+
+	expected := "id,name,description,isPublic\n" +
+		"id1,\"Festival, 2025\",Some description,true\n" +
+		"id2,Other,,false\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buffer.String())
+}
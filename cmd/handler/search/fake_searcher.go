@@ -1,15 +1,21 @@
 package search
 
-import "context"
+import (
+	"context"
+
+	"festwrap/internal/pagination"
+)
 
 type SearchArgs struct {
 	Context context.Context
 	Name    string
+	Offset  int
 	Limit   int
 }
 
 type SearchResult[T any] struct {
 	Result []T
+	Total  *int
 	Error  error
 }
 
@@ -22,15 +28,23 @@ func NewFakeSearcher[T any]() *FakeSearcher[T] {
 	return &FakeSearcher[T]{searchArgs: SearchArgs{}, searchResults: SearchResult[T]{}}
 }
 
-func (s *FakeSearcher[T]) Search(ctx context.Context, name string, limit int) ([]T, error) {
-	s.searchArgs = SearchArgs{Context: ctx, Name: name, Limit: limit}
-	return s.searchResults.Result, s.searchResults.Error
+func (s *FakeSearcher[T]) Search(ctx context.Context, name string, offset int, limit int) (pagination.Page[T], error) {
+	s.searchArgs = SearchArgs{Context: ctx, Name: name, Offset: offset, Limit: limit}
+	total := offset + len(s.searchResults.Result)
+	if s.searchResults.Total != nil {
+		total = *s.searchResults.Total
+	}
+	return pagination.NewPage(s.searchResults.Result, offset, total), s.searchResults.Error
 }
 
 func (s *FakeSearcher[T]) SetSearchResult(value []T) {
 	s.searchResults.Result = value
 }
 
+func (s *FakeSearcher[T]) SetSearchTotal(total int) {
+	s.searchResults.Total = &total
+}
+
 func (s *FakeSearcher[T]) SetSearchError(err error) {
 	s.searchResults.Error = err
 }
@@ -1,15 +1,21 @@
 package search
 
-import "context"
+import (
+	"context"
+
+	"festwrap/internal/pagination"
+)
+
+type SearchFunction[T any] func(ctx context.Context, name string, offset int, limit int) (pagination.Page[T], error)
 
 type FunctionSearcher[T any] struct {
-	fn func(ctx context.Context, name string, limit int) ([]T, error)
+	fn SearchFunction[T]
 }
 
-func NewFunctionSearcher[T any](fn func(ctx context.Context, name string, limit int) ([]T, error)) FunctionSearcher[T] {
+func NewFunctionSearcher[T any](fn SearchFunction[T]) FunctionSearcher[T] {
 	return FunctionSearcher[T]{fn: fn}
 }
 
-func (s *FunctionSearcher[T]) Search(ctx context.Context, name string, limit int) ([]T, error) {
-	return s.fn(ctx, name, limit)
+func (s *FunctionSearcher[T]) Search(ctx context.Context, name string, offset int, limit int) (pagination.Page[T], error) {
+	return s.fn(ctx, name, offset, limit)
 }
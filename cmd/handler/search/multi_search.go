@@ -0,0 +1,190 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"festwrap/internal/http/problem"
+	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+)
+
+// Results of a single type in a multi-type search. Either the items found or the error are set
+type TypeSearchResult struct {
+	Items any    `json:"items,omitempty"`
+	Total int    `json:"total"`
+	Error string `json:"error,omitempty"`
+}
+
+// Search results keyed by type
+type MultiSearchResponse map[string]TypeSearchResult
+
+// Searcher whose results can be combined with the ones of other types
+type TypeSearcher interface {
+	SearchType(ctx context.Context, query string, limit int) (TypeSearchResult, error)
+}
+
+type typedSearcher[T any] struct {
+	searcher Searcher[T]
+}
+
+func NewTypeSearcher[T any](searcher Searcher[T]) TypeSearcher {
+	return &typedSearcher[T]{searcher: searcher}
+}
+
+func (s *typedSearcher[T]) SearchType(ctx context.Context, query string, limit int) (TypeSearchResult, error) {
+	page, err := s.searcher.Search(ctx, query, 0, limit)
+	if err != nil {
+		return TypeSearchResult{}, err
+	}
+	return TypeSearchResult{Items: page.Items, Total: page.Total}, nil
+}
+
+type MultiSearchHandler struct {
+	encoder      serialization.Encoder[MultiSearchResponse]
+	searchers    map[string]TypeSearcher
+	defaultLimit int
+	maxLimit     int
+	logger       logging.Logger
+}
+
+func NewMultiSearchHandler(searchers map[string]TypeSearcher, logger logging.Logger) MultiSearchHandler {
+	return MultiSearchHandler{
+		encoder:      serialization.NewJsonEncoder[MultiSearchResponse](),
+		searchers:    searchers,
+		defaultLimit: 5,
+		maxLimit:     10,
+		logger:       logger,
+	}
+}
+
+func (h *MultiSearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		detail := "search query was not provided"
+		h.logger.Warn(fmt.Sprintf("Validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	types, err := h.readTypes(r)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("Validation error: %v", err.Error()))
+		problem.Write(w, problem.InvalidParameter(err.Error()))
+		return
+	}
+
+	limit, err := readLimit(r, h.defaultLimit, h.maxLimit)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("Invalid limit for request: %v", err.Error()))
+		problem.Write(w, problem.InvalidParameter(err.Error()))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Received new search for %s in %v, using limit %d", query, types, limit))
+
+	response, errs := h.search(r.Context(), query, types, limit)
+	if len(errs) == len(types) {
+		problem.Write(w, problem.FromError(searchError(types, errs), "could not perform search"))
+		return
+	}
+
+	var body bytes.Buffer
+	err = h.encoder.Encode(&body, response)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Error encoding search results %v: %v", response, err.Error()))
+		problem.Write(w, problem.Internal("could not encode search results"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+// Searches all types concurrently. Failing types report their error, without affecting the rest.
+// The errors of the failing types are also returned by type
+func (h *MultiSearchHandler) search(
+	ctx context.Context,
+	query string,
+	types []string,
+	limit int,
+) (MultiSearchResponse, map[string]error) {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	response := MultiSearchResponse{}
+	errs := map[string]error{}
+	for _, searchType := range types {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := h.searchers[searchType].SearchType(ctx, query, limit)
+			if err != nil {
+				h.logger.Error(fmt.Sprintf("Error searching for %s: %v", searchType, err.Error()))
+				result = TypeSearchResult{Error: fmt.Sprintf("could not perform %s search", searchType)}
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			response[searchType] = result
+			if err != nil {
+				errs[searchType] = err
+			}
+		}()
+	}
+	wg.Wait()
+	return response, errs
+}
+
+// Errors caused by the token are preferred, since clients can solve them by refreshing it
+func searchError(types []string, errs map[string]error) error {
+	for _, searchType := range types {
+		if _, ok := httpsender.AsAuthError(errs[searchType]); ok {
+			return errs[searchType]
+		}
+	}
+	return errs[types[0]]
+}
+
+// Reads the comma-separated types to search, defaulting to all of them
+func (h *MultiSearchHandler) readTypes(r *http.Request) ([]string, error) {
+	available := make([]string, 0, len(h.searchers))
+	for searchType := range h.searchers {
+		available = append(available, searchType)
+	}
+	slices.Sort(available)
+
+	typesStr := r.URL.Query().Get("types")
+	if typesStr == "" {
+		return available, nil
+	}
+
+	types := []string{}
+	for _, searchType := range strings.Split(typesStr, ",") {
+		searchType = strings.TrimSpace(searchType)
+		if _, ok := h.searchers[searchType]; !ok {
+			return nil, fmt.Errorf("unknown type %s, available types are %s", searchType, strings.Join(available, ", "))
+		}
+		if !slices.Contains(types, searchType) {
+			types = append(types, searchType)
+		}
+	}
+	return types, nil
+}
+
+func (h *MultiSearchHandler) SetEncoder(encoder serialization.Encoder[MultiSearchResponse]) {
+	h.encoder = encoder
+}
+
+func (h *MultiSearchHandler) SetDefaultLimit(limit int) {
+	h.defaultLimit = limit
+}
+
+func (h *MultiSearchHandler) SetMaxLimit(limit int) {
+	h.maxLimit = limit
+}
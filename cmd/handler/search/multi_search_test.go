@@ -0,0 +1,210 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	senderrors "festwrap/internal/http/sender/errors"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type OtherResult struct {
+	Name string `json:"name"`
+}
+
+func multiSearchers() (*FakeSearcher[Result], *FakeSearcher[OtherResult]) {
+	resultSearcher := NewFakeSearcher[Result]()
+	resultSearcher.SetSearchResult(defaultResults()[:2])
+	resultSearcher.SetSearchTotal(10)
+	otherSearcher := NewFakeSearcher[OtherResult]()
+	otherSearcher.SetSearchResult([]OtherResult{{Name: "other"}})
+	return resultSearcher, otherSearcher
+}
+
+func multiSearchHandler(resultSearcher Searcher[Result], otherSearcher Searcher[OtherResult]) MultiSearchHandler {
+	searchers := map[string]TypeSearcher{
+		"result": NewTypeSearcher(resultSearcher),
+		"other":  NewTypeSearcher(otherSearcher),
+	}
+	return NewMultiSearchHandler(searchers, logging.NoopLogger{})
+}
+
+func defaultMultiSearchParams() map[string]string {
+	return map[string]string{"q": "someQuery", "limit": "3"}
+}
+
+func TestMultiSearchBadRequestIfQueryNotProvided(t *testing.T) {
+	handler := multiSearchHandler(multiSearchers())
+	params := defaultMultiSearchParams()
+	delete(params, "q")
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, params))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestMultiSearchStatusCodeDependingOnParams(t *testing.T) {
+	tests := map[string]struct {
+		params map[string]string
+		status int
+	}{
+		"unknown type": {
+			params: map[string]string{"q": "someQuery", "types": "result,unknown"},
+			status: http.StatusUnprocessableEntity,
+		},
+		"limit above max": {
+			params: map[string]string{"q": "someQuery", "limit": "11"},
+			status: http.StatusUnprocessableEntity,
+		},
+		"limit not an integer": {
+			params: map[string]string{"q": "someQuery", "limit": "something"},
+			status: http.StatusUnprocessableEntity,
+		},
+		"valid params": {
+			params: map[string]string{"q": "someQuery", "types": "result", "limit": "10"},
+			status: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			handler := multiSearchHandler(multiSearchers())
+			writer := httptest.NewRecorder()
+
+			handler.ServeHTTP(writer, buildRequestWithParams(t, test.params))
+
+			assert.Equal(t, test.status, writer.Code)
+		})
+	}
+}
+
+func TestMultiSearchCallsSearchersWithParams(t *testing.T) {
+	resultSearcher, otherSearcher := multiSearchers()
+	handler := multiSearchHandler(resultSearcher, otherSearcher)
+	request := buildRequestWithParams(t, defaultMultiSearchParams())
+
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	expected := SearchArgs{Context: request.Context(), Name: "someQuery", Offset: 0, Limit: 3}
+	assert.Equal(t, expected, resultSearcher.GetSearchArgs())
+	assert.Equal(t, expected, otherSearcher.GetSearchArgs())
+}
+
+func TestMultiSearchOnlySearchesRequestedTypes(t *testing.T) {
+	resultSearcher, otherSearcher := multiSearchers()
+	handler := multiSearchHandler(resultSearcher, otherSearcher)
+	params := defaultMultiSearchParams()
+	params["types"] = "other"
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, params))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.JSONEq(t, `{"other":{"items":[{"name":"other"}],"total":1}}`, writer.Body.String())
+	assert.Equal(t, SearchArgs{}, resultSearcher.GetSearchArgs())
+}
+
+func TestMultiSearchReturnsResultsOfAllTypes(t *testing.T) {
+	handler := multiSearchHandler(multiSearchers())
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, defaultMultiSearchParams()))
+
+	expected := `{
+		"result":{"items":[{"id":"1","value":1},{"id":"2","value":2}],"total":10},
+		"other":{"items":[{"name":"other"}],"total":1}
+	}`
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.JSONEq(t, expected, writer.Body.String())
+}
+
+func TestMultiSearchReturnsErrorOfFailingType(t *testing.T) {
+	resultSearcher, otherSearcher := multiSearchers()
+	resultSearcher.SetSearchError(errors.New("test error"))
+	handler := multiSearchHandler(resultSearcher, otherSearcher)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, defaultMultiSearchParams()))
+
+	expected := `{
+		"result":{"total":0,"error":"could not perform result search"},
+		"other":{"items":[{"name":"other"}],"total":1}
+	}`
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.JSONEq(t, expected, writer.Body.String())
+}
+
+func TestMultiSearchReturnsInternalErrorIfAllTypesFail(t *testing.T) {
+	resultSearcher, otherSearcher := multiSearchers()
+	resultSearcher.SetSearchError(errors.New("test error"))
+	otherSearcher.SetSearchError(errors.New("test error"))
+	handler := multiSearchHandler(resultSearcher, otherSearcher)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, defaultMultiSearchParams()))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestMultiSearchReturnsUpstreamAuthErrorIfAllTypesFail(t *testing.T) {
+	unauthorizedErr := senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, "")
+	forbiddenErr := senderrors.NewUpstreamError("test error", http.StatusForbidden, http.Header{}, "")
+	tests := map[string]struct {
+		resultErr error
+		otherErr  error
+		status    int
+	}{
+		"all types with expired token": {
+			resultErr: unauthorizedErr,
+			otherErr:  unauthorizedErr,
+			status:    http.StatusUnauthorized,
+		},
+		"all types with insufficient permissions": {
+			resultErr: forbiddenErr,
+			otherErr:  forbiddenErr,
+			status:    http.StatusForbidden,
+		},
+		"some types with expired token": {
+			resultErr: unauthorizedErr,
+			otherErr:  errors.New("test error"),
+			status:    http.StatusUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			resultSearcher, otherSearcher := multiSearchers()
+			resultSearcher.SetSearchError(test.resultErr)
+			otherSearcher.SetSearchError(test.otherErr)
+			handler := multiSearchHandler(resultSearcher, otherSearcher)
+			writer := httptest.NewRecorder()
+
+			handler.ServeHTTP(writer, buildRequestWithParams(t, defaultMultiSearchParams()))
+
+			assert.Equal(t, test.status, writer.Code)
+			assert.Equal(t, "application/problem+json", writer.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestMultiSearchReturnsInternalErrorOnEncoderError(t *testing.T) {
+	encoder := serialization.FakeEncoder[MultiSearchResponse]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler := multiSearchHandler(multiSearchers())
+	handler.SetEncoder(encoder)
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, defaultMultiSearchParams()))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
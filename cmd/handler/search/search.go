@@ -1,26 +1,52 @@
 package search
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"festwrap/internal/http/problem"
 	"festwrap/internal/logging"
+	"festwrap/internal/pagination"
 	"festwrap/internal/serialization"
 )
 
+// Spotify does not return catalog search results beyond the first 1000
+const SpotifyMaxResults = 1000
+
+// Reads from the request how search results should be transformed, failing if its parameters are invalid
+type ResultTransformer[T any] func(r *http.Request) (func(results []T) []T, error)
+
+// Results returned when the envelope is requested. Next and Previous are relative links to the
+// adjacent pages, absent when there are no more results in that direction
+type SearchPage[T any] struct {
+	Items    []T     `json:"items"`
+	Total    int     `json:"total"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+}
+
 type SearchHandler[T any] struct {
-	encoder      serialization.Encoder[[]T]
+	encoders     serialization.EncoderRegistry[[]T]
+	pageEncoder  serialization.Encoder[SearchPage[T]]
 	defaultLimit int
 	maxLimit     int
+	maxResults   int
 	searcher     Searcher[T]
+	transformer  ResultTransformer[T]
 	entityType   string
 	logger       logging.Logger
 }
 
 func NewSearchHandler[T any](searcher Searcher[T], entityType string, logger logging.Logger) SearchHandler[T] {
+	encoders := serialization.NewEncoderRegistry[[]T]()
+	encoders.Register(serialization.JsonMediaType, serialization.NewJsonEncoder[[]T]())
+	encoders.Register(serialization.NdjsonMediaType, serialization.NewNdjsonSliceEncoder[T]())
 	return SearchHandler[T]{
-		encoder:      serialization.NewJsonEncoder[[]T](),
+		encoders:     encoders,
+		pageEncoder:  serialization.NewJsonEncoder[SearchPage[T]](),
 		searcher:     searcher,
 		entityType:   entityType,
 		maxLimit:     10,
@@ -32,45 +58,145 @@ func NewSearchHandler[T any](searcher Searcher[T], entityType string, logger log
 func (h *SearchHandler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Query().Get("name")
 	if name == "" {
-		message := fmt.Sprintf("Validation error: %s name was not provided", h.entityType)
-		h.logger.Warn(message)
-		http.Error(w, message, http.StatusBadRequest)
+		detail := fmt.Sprintf("%s name was not provided", h.entityType)
+		h.logger.Warn(fmt.Sprintf("Validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
 		return
 	}
 
 	limit, err := h.readLimit(r)
 	if err != nil {
 		h.logger.Warn(fmt.Sprintf("Invalid limit for request: %v", err.Error()))
-		http.Error(
-			w,
-			fmt.Sprintf("Validation error: invalid limit. It should be an integer in interval [1, %d]", h.maxLimit),
-			http.StatusUnprocessableEntity,
-		)
+		problem.Write(w, problem.InvalidParameter(err.Error()))
 		return
 	}
-	h.logger.Info(fmt.Sprintf("Received new request for %s, using limit %d", name, limit))
 
-	results, err := h.searcher.Search(r.Context(), name, limit)
+	offset, err := h.readOffset(r)
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Error searching for %s: %v", h.entityType, err.Error()))
-		h.writeUnexpectedError(w)
+		h.logger.Warn(fmt.Sprintf("Validation error: %v", err.Error()))
+		problem.Write(w, problem.InvalidParameter(err.Error()))
+		return
+	}
+
+	if h.maxResults > 0 && offset+limit > h.maxResults {
+		detail := fmt.Sprintf("offset and limit cannot go beyond the first %d %s", h.maxResults, h.entityType)
+		h.logger.Warn(fmt.Sprintf("Validation error: %s", detail))
+		problem.Write(w, problem.New(http.StatusBadRequest, problem.InvalidParameterCode, detail))
+		return
+	}
+
+	envelope, err := h.readEnvelope(r)
+	if err != nil {
+		h.logger.Warn(fmt.Sprintf("Validation error: %v", err.Error()))
+		problem.Write(w, problem.InvalidParameter(err.Error()))
+		return
+	}
+
+	transform := func(results []T) []T { return results }
+	if h.transformer != nil {
+		transform, err = h.transformer(r)
+		if err != nil {
+			h.logger.Warn(fmt.Sprintf("Validation error: %v", err.Error()))
+			problem.Write(w, problem.InvalidParameter(err.Error()))
+			return
+		}
+	}
+	mediaType, encoder, ok := h.encoders.Negotiate(r.Header.Get("Accept"))
+	if !ok {
+		mediaTypes := strings.Join(h.encoders.MediaTypes(), ", ")
+		detail := fmt.Sprintf("%s can only be returned as %s", h.entityType, mediaTypes)
+		h.logger.Warn(fmt.Sprintf("Not acceptable: %s", detail))
+		problem.Write(w, problem.NotAcceptable(detail))
 		return
 	}
-	h.logger.Info(fmt.Sprintf("Found %s %v for %s, using limit %d", h.entityType, results, name, limit))
+	h.logger.Info(fmt.Sprintf("Received new request for %s, using offset %d and limit %d", name, offset, limit))
 
-	w.Header().Set("Content-Type", "application/json")
-	err = h.encoder.Encode(w, results)
+	page, err := h.searcher.Search(r.Context(), name, offset, limit)
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Error encoding searched %s %v: %v", h.entityType, results, err.Error()))
-		h.writeUnexpectedError(w)
+		h.logger.Error(fmt.Sprintf("Error searching for %s: %v", h.entityType, err.Error()))
+		h.writeSearchError(w, err)
 		return
 	}
+	h.logger.Info(fmt.Sprintf("Found %s %v for %s, using offset %d and limit %d", h.entityType, page.Items, name, offset, limit))
+	page.Items = transform(page.Items)
+
+	// Envelopes are only available in JSON, the rest of formats list the results found
+	var body bytes.Buffer
+	if envelope && mediaType == serialization.JsonMediaType {
+		err = h.pageEncoder.Encode(&body, h.toSearchPage(r, page, limit))
+	} else {
+		err = encoder.Encode(&body, page.Items)
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Error encoding searched %s %v: %v", h.entityType, page.Items, err.Error()))
+		h.writeSearchError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func (h *SearchHandler[T]) readOffset(r *http.Request) (int, error) {
+	offsetStr := r.URL.Query().Get("offset")
+	if offsetStr == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("offset must be a non negative integer, found %s", offsetStr)
+	}
+
+	return offset, nil
+}
+
+// Results are returned as a bare list unless the envelope is requested, to keep existing clients working
+func (h *SearchHandler[T]) readEnvelope(r *http.Request) (bool, error) {
+	envelopeStr := r.URL.Query().Get("envelope")
+	if envelopeStr == "" {
+		return false, nil
+	}
+
+	envelope, err := strconv.ParseBool(envelopeStr)
+	if err != nil {
+		return false, fmt.Errorf("envelope must be a boolean, found %s", envelopeStr)
+	}
+
+	return envelope, nil
+}
+
+func (h *SearchHandler[T]) toSearchPage(r *http.Request, page pagination.Page[T], limit int) SearchPage[T] {
+	result := SearchPage[T]{Items: page.Items, Total: page.Total}
+	// Pages going beyond the results that can be returned would be rejected, so they are not linked
+	nextOffset := page.Offset + limit
+	if page.HasNext() && (h.maxResults == 0 || nextOffset+limit <= h.maxResults) {
+		next := pageLink(r, nextOffset)
+		result.Next = &next
+	}
+	if page.HasPrevious() {
+		previous := pageLink(r, max(0, page.Offset-limit))
+		result.Previous = &previous
+	}
+	return result
+}
+
+// Builds a link to the same request, starting the results at the given offset
+func pageLink(r *http.Request, offset int) string {
+	query := r.URL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	return r.URL.Path + "?" + query.Encode()
 }
 
 func (h *SearchHandler[T]) readLimit(r *http.Request) (int, error) {
+	return readLimit(r, h.defaultLimit, h.maxLimit)
+}
+
+func readLimit(r *http.Request, defaultLimit int, maxLimit int) (int, error) {
 	limitStr := r.URL.Query().Get("limit")
 	if limitStr == "" {
-		return h.defaultLimit, nil
+		return defaultLimit, nil
 	}
 
 	limit, err := strconv.Atoi(limitStr)
@@ -78,29 +204,43 @@ func (h *SearchHandler[T]) readLimit(r *http.Request) (int, error) {
 		return 0, fmt.Errorf("limit must be an integer, found %s", limitStr)
 	}
 
-	if limit < 1 || limit > h.maxLimit {
-		return 0, fmt.Errorf("limit must be in interval [1, %d]", h.maxLimit)
+	if limit < 1 || limit > maxLimit {
+		return 0, fmt.Errorf("limit must be in interval [1, %d]", maxLimit)
 	}
 
 	return limit, nil
 }
 
-func (h *SearchHandler[T]) writeUnexpectedError(w http.ResponseWriter) {
-	http.Error(
-		w,
-		fmt.Sprintf("Unexpected error: could not perform %s search", h.entityType),
-		http.StatusInternalServerError,
-	)
+func (h *SearchHandler[T]) writeSearchError(w http.ResponseWriter, err error) {
+	problem.Write(w, problem.FromError(err, fmt.Sprintf("could not perform %s search", h.entityType)))
 }
 
 func (h *SearchHandler[T]) SetEncoder(encoder serialization.Encoder[[]T]) {
-	h.encoder = encoder
+	h.encoders.Register(serialization.JsonMediaType, encoder)
+}
+
+// Allows returning the results in the given media type, when the client accepts it
+func (h *SearchHandler[T]) RegisterEncoder(mediaType string, encoder serialization.Encoder[[]T]) {
+	h.encoders.Register(mediaType, encoder)
+}
+
+func (h *SearchHandler[T]) SetPageEncoder(encoder serialization.Encoder[SearchPage[T]]) {
+	h.pageEncoder = encoder
 }
 
 func (h *SearchHandler[T]) SetMaxLimit(limit int) {
 	h.maxLimit = limit
 }
 
+// Limits the results that can be paged through. Zero, the default, means no limit
+func (h *SearchHandler[T]) SetMaxResults(maxResults int) {
+	h.maxResults = maxResults
+}
+
+func (h *SearchHandler[T]) SetResultTransformer(transformer ResultTransformer[T]) {
+	h.transformer = transformer
+}
+
 func (h *SearchHandler[T]) SetDefaultLimit(limit int) {
 	h.defaultLimit = limit
 }
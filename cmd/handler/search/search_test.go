@@ -142,17 +142,168 @@ func TestSearcherCalledWithParams(t *testing.T) {
 	assert.Equal(t, request.Context(), actual.Context)
 	assert.Equal(t, defaultQueryParams()["limit"], fmt.Sprint(actual.Limit))
 	assert.Equal(t, defaultQueryParams()["name"], actual.Name)
+	assert.Equal(t, 0, actual.Offset)
+}
+
+func TestOffsetStatusCodeDependingOnValue(t *testing.T) {
+	tests := map[string]struct {
+		offset string
+		status int
+	}{
+		"negative": {
+			offset: "-1",
+			status: http.StatusUnprocessableEntity,
+		},
+		"not an integer": {
+			offset: "something",
+			status: http.StatusUnprocessableEntity,
+		},
+		"zero": {
+			offset: "0",
+			status: http.StatusOK,
+		},
+		"positive": {
+			offset: "20",
+			status: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			params := defaultQueryParams()
+			params["offset"] = test.offset
+			writer, request, handler := setup(t, params)
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.status, writer.Code)
+		})
+	}
+}
+
+func TestSearcherCalledWithOffset(t *testing.T) {
+	searcher := NewFakeSearcher[Result]()
+	handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+	params := defaultQueryParams()
+	params["offset"] = "15"
+
+	handler.ServeHTTP(httptest.NewRecorder(), buildRequestWithParams(t, params))
+
+	assert.Equal(t, 15, searcher.GetSearchArgs().Offset)
+}
+
+func TestSearchReturnsBadRequestIfOffsetGoesBeyondMaxResults(t *testing.T) {
+	searcher := NewFakeSearcher[Result]()
+	handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+	handler.SetMaxResults(1000)
+	params := map[string]string{"name": "someName", "limit": "10", "offset": "995"}
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, params))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, SearchArgs{}, searcher.GetSearchArgs())
+}
+
+func TestSearcherCalledWithAnyOffsetWithoutMaxResults(t *testing.T) {
+	searcher := NewFakeSearcher[Result]()
+	handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+	params := map[string]string{"name": "someName", "limit": "10", "offset": "995"}
+
+	handler.ServeHTTP(httptest.NewRecorder(), buildRequestWithParams(t, params))
+
+	assert.Equal(t, 995, searcher.GetSearchArgs().Offset)
+}
+
+func TestSearchReturnsUnprocessableEntityOnInvalidEnvelope(t *testing.T) {
+	params := defaultQueryParams()
+	params["envelope"] = "something"
+	writer, request, handler := setup(t, params)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+}
+
+func TestSearchReturnsPageEnvelope(t *testing.T) {
+	tests := map[string]struct {
+		offset   string
+		total    int
+		expected string
+	}{
+		"first page": {
+			offset: "0",
+			total:  10,
+			expected: `{"items":[{"id":"1","value":1},{"id":"2","value":2}],"total":10,` +
+				`"next":"/api?envelope=true&limit=2&name=someName&offset=2","previous":null}`,
+		},
+		"middle page": {
+			offset: "3",
+			total:  10,
+			expected: `{"items":[{"id":"1","value":1},{"id":"2","value":2}],"total":10,` +
+				`"next":"/api?envelope=true&limit=2&name=someName&offset=5",` +
+				`"previous":"/api?envelope=true&limit=2&name=someName&offset=1"}`,
+		},
+		"last page": {
+			offset: "1",
+			total:  3,
+			expected: `{"items":[{"id":"1","value":1},{"id":"2","value":2}],"total":3,"next":null,` +
+				`"previous":"/api?envelope=true&limit=2&name=someName&offset=0"}`,
+		},
+		"last page within max results": {
+			offset: "998",
+			total:  5000,
+			expected: `{"items":[{"id":"1","value":1},{"id":"2","value":2}],"total":5000,"next":null,` +
+				`"previous":"/api?envelope=true&limit=2&name=someName&offset=996"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			searcher := NewFakeSearcher[Result]()
+			searcher.SetSearchResult(defaultResults()[:2])
+			searcher.SetSearchTotal(test.total)
+			handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+			handler.SetMaxResults(1000)
+			params := map[string]string{"name": "someName", "limit": "2", "offset": test.offset, "envelope": "true"}
+			writer := httptest.NewRecorder()
+
+			handler.ServeHTTP(writer, buildRequestWithParams(t, params))
+
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.JSONEq(t, test.expected, writer.Body.String())
+		})
+	}
+}
+
+func TestSearchReturnsInternalErrorOnPageEncoderError(t *testing.T) {
+	encoder := serialization.FakeEncoder[SearchPage[Result]]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	params := defaultQueryParams()
+	params["envelope"] = "true"
+	writer, request, handler := setup(t, params)
+	handler.SetPageEncoder(encoder)
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
 }
 
 func TestSearchReturnsInternalErrorOnEncoderError(t *testing.T) {
 	encoder := serialization.FakeEncoder[[]Result]{}
 	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
 	writer, request, handler := setup(t, defaultQueryParams())
 	handler.SetEncoder(encoder)
 
 	handler.ServeHTTP(writer, request)
 
 	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
 }
 
 func TestSearchReturnsExpectedResult(t *testing.T) {
@@ -165,3 +316,106 @@ func TestSearchReturnsExpectedResult(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, defaultResults(), unmarshalSearchResponse(t, writer.Body.Bytes()))
 }
+
+func TestSearchReturnsTransformedResult(t *testing.T) {
+	writer, request, handler := setup(t, defaultQueryParams())
+	handler.SetResultTransformer(func(r *http.Request) (func([]Result) []Result, error) {
+		return func(results []Result) []Result { return results[:1] }, nil
+	})
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, defaultResults()[:1], unmarshalSearchResponse(t, writer.Body.Bytes()))
+}
+
+func TestSearchReturnsUnprocessableEntityOnTransformerError(t *testing.T) {
+	searcher := NewFakeSearcher[Result]()
+	handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+	handler.SetResultTransformer(func(r *http.Request) (func([]Result) []Result, error) {
+		return nil, errors.New("test error")
+	})
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, buildRequestWithParams(t, defaultQueryParams()))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+	assert.Equal(t, SearchArgs{}, searcher.GetSearchArgs())
+}
+
+func resultsCsvEncoder() serialization.CsvEncoder[[]Result] {
+	return serialization.NewCsvEncoder([]string{"id", "value"}, func(results []Result) [][]string {
+		records := [][]string{}
+		for _, result := range results {
+			records = append(records, []string{result.Id, fmt.Sprint(result.Value)})
+		}
+		return records
+	})
+}
+
+func TestSearchReturnsResultsInAcceptedMediaType(t *testing.T) {
+	tests := map[string]struct {
+		accept      string
+		params      map[string]string
+		contentType string
+		expected    string
+	}{
+		"json by default": {
+			accept:      "",
+			contentType: "application/json",
+			expected:    "[{\"id\":\"1\",\"value\":1},{\"id\":\"2\",\"value\":2}]\n",
+		},
+		"ndjson": {
+			accept:      "application/x-ndjson",
+			contentType: "application/x-ndjson",
+			expected:    "{\"id\":\"1\",\"value\":1}\n{\"id\":\"2\",\"value\":2}\n",
+		},
+		"csv": {
+			accept:      "text/csv",
+			contentType: "text/csv",
+			expected:    "id,value\n1,1\n2,2\n",
+		},
+		"csv ignores envelope": {
+			accept:      "text/csv",
+			params:      map[string]string{"envelope": "true"},
+			contentType: "text/csv",
+			expected:    "id,value\n1,1\n2,2\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			searcher := NewFakeSearcher[Result]()
+			searcher.SetSearchResult(defaultResults()[:2])
+			handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+			handler.RegisterEncoder(serialization.CsvMediaType, resultsCsvEncoder())
+			params := defaultQueryParams()
+			for name, value := range test.params {
+				params[name] = value
+			}
+			request := buildRequestWithParams(t, params)
+			request.Header.Set("Accept", test.accept)
+			writer := httptest.NewRecorder()
+
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, test.contentType, writer.Header().Get("Content-Type"))
+			assert.Equal(t, test.expected, writer.Body.String())
+		})
+	}
+}
+
+func TestSearchReturnsNotAcceptableOnUnsupportedMediaType(t *testing.T) {
+	searcher := NewFakeSearcher[Result]()
+	handler := NewSearchHandler(searcher, "someType", logging.NoopLogger{})
+	request := buildRequestWithParams(t, defaultQueryParams())
+	request.Header.Set("Accept", "text/csv")
+	writer := httptest.NewRecorder()
+
+	handler.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusNotAcceptable, writer.Code)
+	assert.Equal(t, SearchArgs{}, searcher.GetSearchArgs())
+}
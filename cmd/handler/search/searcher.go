@@ -1,7 +1,11 @@
 package search
 
-import "context"
+import (
+	"context"
+
+	"festwrap/internal/pagination"
+)
 
 type Searcher[T any] interface {
-	Search(ctx context.Context, name string, limit int) ([]T, error)
+	Search(ctx context.Context, name string, offset int, limit int) (pagination.Page[T], error)
 }
@@ -0,0 +1,101 @@
+package setlist
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"festwrap/internal/http/problem"
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+	"festwrap/internal/setlist"
+)
+
+const previewDateLayout = "2006-01-02"
+
+// Setlist that would be added to a playlist for the artist
+type SetlistPreview struct {
+	Artist string         `json:"artist"`
+	Date   string         `json:"date,omitempty"`
+	Venue  string         `json:"venue,omitempty"`
+	Tour   string         `json:"tour,omitempty"`
+	Source setlist.Source `json:"source,omitempty"`
+	Songs  []string       `json:"songs"`
+}
+
+type SetlistPreviewHandler struct {
+	setlistRepository setlist.SetlistRepository
+	logger            logging.Logger
+	encoder           serialization.Encoder[SetlistPreview]
+	minSongs          int
+}
+
+// Returns the setlist of the artist without adding it to any playlist, so it can be checked beforehand
+func NewSetlistPreviewHandler(setlistRepository setlist.SetlistRepository, logger logging.Logger) SetlistPreviewHandler {
+	return SetlistPreviewHandler{
+		setlistRepository: setlistRepository,
+		logger:            logger,
+		encoder:           serialization.NewJsonEncoder[SetlistPreview](),
+		minSongs:          4,
+	}
+}
+
+func (h *SetlistPreviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	artist := setlist.SetlistArtist{
+		Name:          strings.TrimSpace(query.Get("artist")),
+		MusicBrainzId: query.Get("musicBrainzId"),
+		SpotifyId:     query.Get("spotifyId"),
+	}
+	if artist.Name == "" {
+		detail := "artist was not provided"
+		h.logger.Warn(fmt.Sprintf("validation error: %s", detail))
+		problem.Write(w, problem.MissingParameter(detail))
+		return
+	}
+
+	result, err := h.setlistRepository.GetSetlist(r.Context(), artist, h.minSongs)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("could not retrieve setlist of %s: %v", artist.Name, err))
+		problem.Write(w, problem.FromError(err, "could not retrieve setlist"))
+		return
+	}
+
+	var body bytes.Buffer
+	if err = h.encoder.Encode(&body, toSetlistPreview(artist.Name, *result)); err != nil {
+		h.logger.Error(fmt.Sprintf("encoding error: could not encode setlist of %s: %v", artist.Name, err))
+		problem.Write(w, problem.Internal("could not encode setlist"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = body.WriteTo(w); err != nil {
+		h.logger.Warn(fmt.Sprintf("could not write response: %v", err))
+	}
+}
+
+func toSetlistPreview(artist string, result setlist.Setlist) SetlistPreview {
+	preview := SetlistPreview{
+		Artist: artist,
+		Venue:  result.GetVenue(),
+		Tour:   result.GetTour(),
+		Source: result.GetSource(),
+		Songs:  make([]string, len(result.GetSongs())),
+	}
+	if !result.GetDate().IsZero() {
+		preview.Date = result.GetDate().Format(previewDateLayout)
+	}
+	for i, song := range result.GetSongs() {
+		preview.Songs[i] = song.GetTitle()
+	}
+	return preview
+}
+
+// Setlists with fewer songs are skipped, as when they are added to playlists
+func (h *SetlistPreviewHandler) SetMinSongs(minSongs int) {
+	h.minSongs = minSongs
+}
+
+func (h *SetlistPreviewHandler) SetEncoder(encoder serialization.Encoder[SetlistPreview]) {
+	h.encoder = encoder
+}
@@ -0,0 +1,127 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"festwrap/internal/logging"
+	"festwrap/internal/serialization"
+	"festwrap/internal/setlist"
+	setlisterrors "festwrap/internal/setlist/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func previewSetlist() setlist.Setlist {
+	result := setlist.NewSetlist("Comeback Kid", []setlist.Song{setlist.NewSong("Wake the Dead"), setlist.NewSong("Die Tonight")})
+	result.SetDate(time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC))
+	result.SetVenue("Gruenspan, Hamburg")
+	result.SetSource(setlist.SetlistFMSource)
+	return result
+}
+
+func setlistPreviewSetup() (SetlistPreviewHandler, *httptest.ResponseRecorder, *setlist.FakeSetlistRepository) {
+	repository := setlist.NewFakeSetlistRepository()
+	repository.SetReturnValue(previewSetlist())
+	handler := NewSetlistPreviewHandler(&repository, logging.NoopLogger{})
+	return handler, httptest.NewRecorder(), &repository
+}
+
+func buildPreviewRequest(query string) *http.Request {
+	return httptest.NewRequest("GET", "https://example.com/setlists/preview"+query, nil)
+}
+
+func TestSetlistPreviewHandlerReturnsBadRequestIfArtistNotProvided(t *testing.T) {
+	tests := map[string]struct {
+		query string
+	}{
+		"missing artist": {query: ""},
+		"blank artist":   {query: "?artist=%20"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, writer, _ := setlistPreviewSetup()
+
+			handler.ServeHTTP(writer, buildPreviewRequest(test.query))
+
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+		})
+	}
+}
+
+func TestSetlistPreviewHandlerCallsRepositoryWithArtist(t *testing.T) {
+	handler, writer, repository := setlistPreviewSetup()
+	handler.SetMinSongs(6)
+	request := buildPreviewRequest("?artist=Comeback%20Kid&spotifyId=ckid&musicBrainzId=mbid")
+
+	handler.ServeHTTP(writer, request)
+
+	expected := setlist.GetSetlistArgs{
+		Context:  request.Context(),
+		Artist:   setlist.SetlistArtist{Name: "Comeback Kid", MusicBrainzId: "mbid", SpotifyId: "ckid"},
+		MinSongs: 6,
+	}
+	assert.Equal(t, expected, repository.GetGetSetlistArgs())
+}
+
+func TestSetlistPreviewHandlerReturnsSetlist(t *testing.T) {
+	handler, writer, _ := setlistPreviewSetup()
+
+	handler.ServeHTTP(writer, buildPreviewRequest("?artist=Comeback%20Kid"))
+
+	expected := `{"artist":"Comeback Kid","date":"2024-01-25","venue":"Gruenspan, Hamburg",` +
+		`"source":"setlistfm","songs":["Wake the Dead","Die Tonight"]}` + "\n"
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+	assert.Equal(t, expected, writer.Body.String())
+}
+
+func TestSetlistPreviewHandlerReturnsErrorStatusDependingOnRepositoryError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected int
+	}{
+		"setlist not found": {
+			err:      setlisterrors.NewSetlistNotFoundError("test error"),
+			expected: http.StatusNotFound,
+		},
+		"setlist not retrieved": {
+			err:      setlisterrors.NewCannotRetrieveSetlistError("test error"),
+			expected: http.StatusBadGateway,
+		},
+		"unknown error": {
+			err:      errors.New("test error"),
+			expected: http.StatusInternalServerError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, writer, repository := setlistPreviewSetup()
+			repository.SetError(test.err)
+
+			handler.ServeHTTP(writer, buildPreviewRequest("?artist=Comeback%20Kid"))
+
+			assert.Equal(t, test.expected, writer.Code)
+		})
+	}
+}
+
+func TestSetlistPreviewHandlerReturnsInternalErrorOnEncoderError(t *testing.T) {
+	handler, writer, _ := setlistPreviewSetup()
+	encoder := serialization.FakeEncoder[SetlistPreview]{}
+	encoder.SetError(errors.New("test error"))
+	encoder.SetPartialOutput("partial output")
+	handler.SetEncoder(encoder)
+
+	handler.ServeHTTP(writer, buildPreviewRequest("?artist=Comeback%20Kid"))
+
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.NotContains(t, writer.Body.String(), "partial output")
+}
@@ -1,25 +1,39 @@
 package main
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	artisthandler "festwrap/cmd/handler/artist"
+	authhandler "festwrap/cmd/handler/auth"
+	jobhandler "festwrap/cmd/handler/job"
 	playlisthandler "festwrap/cmd/handler/playlist"
 	"festwrap/cmd/handler/search"
+	setlisthandler "festwrap/cmd/handler/setlist"
 	"festwrap/cmd/middleware"
 	spotifyArtists "festwrap/internal/artist/spotify"
+	"festwrap/internal/auth"
+	spotifyauth "festwrap/internal/auth/spotify"
+	"festwrap/internal/cache"
+	"festwrap/internal/cover"
 	"festwrap/internal/env"
 	httpclient "festwrap/internal/http/client"
 	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/job"
 	"festwrap/internal/logging"
 	"festwrap/internal/playlist"
 	spotifyplaylists "festwrap/internal/playlist/spotify"
+	"festwrap/internal/serialization"
+	"festwrap/internal/setlist"
 	"festwrap/internal/setlist/setlistfm"
 	spotifysongs "festwrap/internal/song/spotify"
+	"festwrap/internal/user"
 	spotifyusers "festwrap/internal/user/spotify"
 )
 
@@ -46,9 +60,25 @@ func main() {
 	timeoutSeconds := GetEnvWithDefaultOrFail[int]("FESTWRAP_TIMEOUT_SECONDS", 5)
 	setlistfmApiKey := GetEnvStringOrFail("FESTWRAP_SETLISTFM_APIKEY")
 	maxSetlistFMNumSearchPages := GetEnvWithDefaultOrFail[int]("FESTWRAP_SETLISTFM_NUM_SEARCH_PAGES", 3)
+	jobWorkers := GetEnvWithDefaultOrFail[int]("FESTWRAP_JOB_WORKERS", 4)
+	jobQueueSize := GetEnvWithDefaultOrFail[int]("FESTWRAP_JOB_QUEUE_SIZE", 100)
+	jobTTLSeconds := GetEnvWithDefaultOrFail[int]("FESTWRAP_JOB_TTL_SECONDS", 3600)
+	jobStoreMaxSize := GetEnvWithDefaultOrFail[int]("FESTWRAP_JOB_STORE_MAX_SIZE", 1000)
+	idempotencyTTLSeconds := GetEnvWithDefaultOrFail[int]("FESTWRAP_IDEMPOTENCY_TTL_SECONDS", 86400)
+	idempotencyMaxSize := GetEnvWithDefaultOrFail[int]("FESTWRAP_IDEMPOTENCY_MAX_SIZE", 1000)
+	descriptionTemplate := GetEnvWithDefaultOrFail[string]("FESTWRAP_DESCRIPTION_TEMPLATE", playlist.DefaultDescriptionTemplate)
+	topTracksFallback := GetEnvWithDefaultOrFail[bool]("FESTWRAP_TOP_TRACKS_FALLBACK", true)
+	userCacheTTLSeconds := GetEnvWithDefaultOrFail[int]("FESTWRAP_USER_CACHE_TTL_SECONDS", 300)
+	userCacheMaxSize := GetEnvWithDefaultOrFail[int]("FESTWRAP_USER_CACHE_MAX_SIZE", 1000)
+	spotifyClientId := GetEnvWithDefaultOrFail[string]("FESTWRAP_SPOTIFY_CLIENT_ID", "")
+	introspectionUrl := GetEnvWithDefaultOrFail[string]("FESTWRAP_TOKEN_INTROSPECTION_URL", "")
 
 	slogLogger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	logger := logging.NewBaseLogger(slogLogger)
+	// The secret is only read along with the client id, so it would be silently ignored otherwise
+	if spotifyClientId == "" && os.Getenv("FESTWRAP_SPOTIFY_CLIENT_SECRET") != "" {
+		logger.Warn("FESTWRAP_SPOTIFY_CLIENT_SECRET is ignored since FESTWRAP_SPOTIFY_CLIENT_ID is not set")
+	}
 
 	httpClient := &http.Client{
 		Transport: &http.Transport{MaxConnsPerHost: maxConnsPerHost},
@@ -58,42 +88,191 @@ func main() {
 	httpSender := httpsender.NewBaseHTTPRequestSender(&baseHttpClient)
 
 	mux := http.NewServeMux()
+	var handler http.Handler = mux
+	authenticator := middleware.NewRouteAuthenticator()
+	// Scopes of the tokens in the Authorization header are only known if they can be introspected
+	if introspectionUrl != "" {
+		introspector := auth.NewHTTPTokenIntrospector(introspectionUrl, &httpSender)
+		if spotifyClientId != "" {
+			introspector.SetClientCredentials(spotifyClientId, GetEnvWithDefaultOrFail[string]("FESTWRAP_SPOTIFY_CLIENT_SECRET", ""))
+		}
+		introspections := cache.NewMemoryCache[string, auth.Introspection](time.Duration(userCacheTTLSeconds) * time.Second)
+		introspections.SetMaxSize(userCacheMaxSize)
+		authenticator.EnableIntrospection(auth.NewCachedTokenIntrospector(&introspector, introspections))
+	}
+	// Login is optional, so clients can keep sending their own tokens if no Spotify app is configured
+	if spotifyClientId != "" {
+		redirectUri := GetEnvWithDefaultOrFail[string]("FESTWRAP_SPOTIFY_REDIRECT_URI", "http://localhost:8080/auth/callback")
+		accountsUrl := GetEnvWithDefaultOrFail[string]("FESTWRAP_SPOTIFY_ACCOUNTS_URL", "https://accounts.spotify.com")
+		loginRedirect := GetEnvWithDefaultOrFail[string]("FESTWRAP_LOGIN_REDIRECT_URL", "/")
+		sessionTTLSeconds := GetEnvWithDefaultOrFail[int]("FESTWRAP_SESSION_TTL_SECONDS", 30*24*3600)
+		secureCookies := GetEnvWithDefaultOrFail[bool]("FESTWRAP_SECURE_COOKIES", true)
+		spotifyClientSecret := GetEnvWithDefaultOrFail[string]("FESTWRAP_SPOTIFY_CLIENT_SECRET", "")
+		allowedOrigins := GetEnvWithDefaultOrFail[string]("FESTWRAP_ALLOWED_ORIGINS", "")
+		sessionKey, err := base64.StdEncoding.DecodeString(GetEnvStringOrFail("FESTWRAP_SESSION_KEY"))
+		if err != nil {
+			log.Fatalf("Session key must be base64 encoded: %v", err)
+		}
+
+		oauthClient := spotifyauth.NewSpotifyOAuthClient(spotifyClientId, &httpSender)
+		oauthClient.SetAccountsUrl(accountsUrl)
+		tokenRefresher := auth.NewTokenRefresher(&oauthClient)
+		sessions, err := auth.NewCookieStore[auth.Token]("festwrap_session", sessionKey, time.Duration(sessionTTLSeconds)*time.Second)
+		if err != nil {
+			log.Fatalf("Could not create session store: %v", err)
+		}
+		sessions.SetSecure(secureCookies)
+		loginStates, err := auth.NewCookieStore[auth.LoginState]("festwrap_login", sessionKey, 10*time.Minute)
+		if err != nil {
+			log.Fatalf("Could not create login state store: %v", err)
+		}
+		loginStates.SetSecure(secureCookies)
+		authenticator.EnableSessions(sessions, tokenRefresher)
+		// Session cookies are sent by browsers along requests from any site, so their origin is checked
+		handler = middleware.NewOriginMiddleware(mux, strings.Split(allowedOrigins, ","))
+		// Public endpoints can be used before logging in if the app can get its own token
+		if spotifyClientSecret != "" {
+			oauthClient.SetClientSecret(spotifyClientSecret)
+			authenticator.EnableAppToken(auth.NewRefreshingAppTokenProvider(&oauthClient))
+		}
+
+		loginHandler := authhandler.NewLoginHandler(&oauthClient, loginStates, redirectUri, logger)
+		mux.HandleFunc("GET /auth/login", loginHandler.ServeHTTP)
+		callbackHandler := authhandler.NewCallbackHandler(&oauthClient, loginStates, sessions, redirectUri, logger)
+		callbackHandler.SetSuccessRedirect(loginRedirect)
+		mux.HandleFunc("GET /auth/callback", callbackHandler.ServeHTTP)
+		refreshHandler := authhandler.NewRefreshHandler(sessions, tokenRefresher, logger)
+		mux.HandleFunc("POST /auth/refresh", refreshHandler.ServeHTTP)
+		logoutHandler := authhandler.NewLogoutHandler(sessions)
+		mux.HandleFunc("POST /auth/logout", logoutHandler.ServeHTTP)
+	}
 
 	artistRepository := spotifyArtists.NewSpotifyArtistRepository(&httpSender)
 	artistSearcher := search.NewFunctionSearcher(artistRepository.SearchArtist)
 	searchArtistsHandler := search.NewSearchHandler(&artistSearcher, "artists", logger)
-	mux.HandleFunc("/artists/search", searchArtistsHandler.ServeHTTP)
+	searchArtistsHandler.SetResultTransformer(search.ArtistImageSizeTransformer)
+	searchArtistsHandler.SetMaxResults(search.SpotifyMaxResults)
+	searchArtistsHandler.RegisterEncoder(serialization.CsvMediaType, search.NewArtistsCsvEncoder())
+	mux.Handle("/artists/search", authenticator.AllowApp(&searchArtistsHandler))
+
+	relatedArtistsHandler := artisthandler.NewRelatedArtistsHandler("artistId", &artistRepository, logger)
+	mux.Handle("GET /artists/{artistId}/related", authenticator.AllowApp(&relatedArtistsHandler))
+	relatedArtistsExpander := playlist.NewRelatedArtistsExpander(&artistRepository)
 
 	playlistRepository := spotifyplaylists.NewSpotifyPlaylistRepository(&httpSender)
-	playlistSearcher := search.NewFunctionSearcher(playlistRepository.SearchPlaylist)
-	userRepository := spotifyusers.NewSpotifyUserRepository(&httpSender)
+	playlistSearcher := search.NewFunctionSearcher(playlistRepository.SearchUserPlaylists)
+	spotifyUserRepository := spotifyusers.NewSpotifyUserRepository(&httpSender)
+	userIds := cache.NewMemoryCache[string, string](time.Duration(userCacheTTLSeconds) * time.Second)
+	userIds.SetMaxSize(userCacheMaxSize)
+	userRepository := user.NewCachedUserRepository(spotifyUserRepository, userIds)
 	searchPlaylistsHandler := search.NewSearchHandler(&playlistSearcher, "playlists", logger)
-	mux.HandleFunc(
-		"/playlists/search",
-		middleware.NewUserIdMiddleware(&searchPlaylistsHandler, userRepository).ServeHTTP,
+	searchPlaylistsHandler.RegisterEncoder(serialization.CsvMediaType, search.NewPlaylistsCsvEncoder())
+	mux.Handle(
+		"GET /playlists/search",
+		authenticator.RequireUser(
+			middleware.NewUserIdMiddleware(&searchPlaylistsHandler, userRepository),
+			spotifyauth.PlaylistReadPrivateScope,
+		),
 	)
 
-	setlistRepository := setlistfm.NewSetlistFMSetlistRepository(setlistfmApiKey, &httpSender)
-	setlistRepository.SetMaxPages(maxSetlistFMNumSearchPages)
+	setlistfmRepository := setlistfm.NewSetlistFMSetlistRepository(setlistfmApiKey, &httpSender)
+	setlistfmRepository.SetMaxPages(maxSetlistFMNumSearchPages)
+	var setlistRepository setlist.SetlistRepository = setlistfmRepository
+	if topTracksFallback {
+		topTracksRepository := setlist.NewTopTracksSetlistRepository(&artistRepository)
+		fallbackRepository := setlist.NewFallbackSetlistRepository(setlistfmRepository, topTracksRepository)
+		setlistRepository = &fallbackRepository
+	}
+	setlistPreviewHandler := setlisthandler.NewSetlistPreviewHandler(setlistRepository, logger)
+	mux.Handle("GET /setlists/preview", authenticator.AllowApp(&setlistPreviewHandler))
+
 	songRepository := spotifysongs.NewSpotifySongRepository(&httpSender)
+	trackSearcher := search.NewFunctionSearcher(songRepository.SearchTracks)
+	multiSearchHandler := search.NewMultiSearchHandler(
+		map[string]search.TypeSearcher{
+			"artist":   search.NewTypeSearcher(&artistSearcher),
+			"playlist": search.NewTypeSearcher(&playlistSearcher),
+			"track":    search.NewTypeSearcher(&trackSearcher),
+		},
+		logger,
+	)
+	mux.Handle(
+		"GET /search",
+		authenticator.RequireUser(
+			middleware.NewUserIdMiddleware(&multiSearchHandler, userRepository),
+			spotifyauth.PlaylistReadPrivateScope,
+		),
+	)
 	playlistService := playlist.NewConcurrentPlaylistService(
 		&playlistRepository,
 		setlistRepository,
 		songRepository,
 	)
+	jobStore := job.NewMemoryJobStore(time.Duration(jobTTLSeconds) * time.Second)
+	jobStore.SetMaxSize(jobStoreMaxSize)
+	jobExecutor := job.NewWorkerPool(jobWorkers, jobQueueSize, logger)
+	defer jobExecutor.Stop()
+	idempotentResponses := cache.NewMemoryCache[string, middleware.IdempotentResponse](
+		time.Duration(idempotencyTTLSeconds) * time.Second,
+	)
+	idempotentResponses.SetMaxSize(idempotencyMaxSize)
+	getJobHandler := jobhandler.NewGetJobHandler("jobId", jobStore, logger)
+	mux.Handle("GET /jobs/{jobId}", authenticator.RequireUser(&getJobHandler))
+
 	existingPlaylistUpdateHandler := playlisthandler.NewUpdateExistingPlaylistHandler("playlistId", &playlistService, logger)
-	mux.HandleFunc("/playlists/{playlistId}", existingPlaylistUpdateHandler.ServeHTTP)
+	existingPlaylistUpdateHandler.EnableJobs(jobStore, jobExecutor)
+	existingPlaylistUpdateHandler.EnableRelatedArtists(&relatedArtistsExpander)
+	mux.Handle(
+		"POST /playlists/{playlistId}",
+		authenticator.RequireUserWithAnyScope(
+			middleware.NewUserIdMiddleware(
+				middleware.NewIdempotencyMiddleware(&existingPlaylistUpdateHandler, idempotentResponses),
+				userRepository,
+			),
+			spotifyauth.PlaylistModifyScopes...,
+		),
+	)
+
+	getPlaylistHandler := playlisthandler.NewGetPlaylistHandler("playlistId", &playlistService, logger)
+	mux.Handle("GET /playlists/{playlistId}", authenticator.RequireUser(&getPlaylistHandler, spotifyauth.PlaylistReadPrivateScope))
+
+	updatePlaylistDetailsHandler := playlisthandler.NewUpdatePlaylistDetailsHandler("playlistId", &playlistService, logger)
+	mux.Handle(
+		"PATCH /playlists/{playlistId}",
+		authenticator.RequireUserWithAnyScope(&updatePlaylistDetailsHandler, spotifyauth.PlaylistModifyScopes...),
+	)
+
+	removeArtistHandler := playlisthandler.NewRemoveArtistHandler("playlistId", "artistName", &playlistService, logger)
+	mux.Handle(
+		"DELETE /playlists/{playlistId}/artists/{artistName}",
+		authenticator.RequireUserWithAnyScope(&removeArtistHandler, spotifyauth.PlaylistModifyScopes...),
+	)
 
 	newPlaylistUpdateHandler := playlisthandler.NewUpdateNewPlaylistHandler(&playlistService, logger)
-	mux.HandleFunc(
+	newPlaylistUpdateHandler.EnableJobs(jobStore, jobExecutor)
+	newPlaylistUpdateHandler.EnableRelatedArtists(&relatedArtistsExpander)
+	imageFetcher := cover.NewHTTPImageFetcher(&httpSender)
+	coverService := cover.NewArtistCollageCoverService(&artistRepository, &playlistRepository, &imageFetcher)
+	newPlaylistUpdateHandler.EnableCovers(&coverService)
+	descriptionGenerator := playlist.NewDescriptionGenerator()
+	if err := descriptionGenerator.SetTemplate(descriptionTemplate); err != nil {
+		log.Fatalf("Invalid description template: %v", err)
+	}
+	newPlaylistUpdateHandler.SetDescriptionGenerator(descriptionGenerator)
+	mux.Handle(
 		"/playlists",
-		middleware.NewUserIdMiddleware(&newPlaylistUpdateHandler, userRepository).ServeHTTP,
+		authenticator.RequireUserWithAnyScope(
+			middleware.NewUserIdMiddleware(
+				middleware.NewIdempotencyMiddleware(&newPlaylistUpdateHandler, idempotentResponses),
+				userRepository,
+			),
+			spotifyauth.PlaylistModifyScopes...,
+		),
 	)
 
-	wrappedMux := middleware.NewAuthTokenMiddleware(mux)
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", port),
-		Handler: wrappedMux,
+		Handler: handler,
 	}
 
 	server.ListenAndServe()
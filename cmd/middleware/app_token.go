@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	types "festwrap/internal"
+	"festwrap/internal/auth"
+	"festwrap/internal/http/problem"
+)
+
+// Stores the token of the app in the context variable with the given key, so requests do not need
+// a user. Only useful for endpoints accessing public data
+type AppTokenMiddleware struct {
+	tokenKey  types.ContextKey
+	appTokens auth.AppTokenProvider
+	handler   http.Handler
+}
+
+func NewAppTokenMiddleware(handler http.Handler, appTokens auth.AppTokenProvider) AppTokenMiddleware {
+	return AppTokenMiddleware{tokenKey: types.ContextKey("token"), appTokens: appTokens, handler: handler}
+}
+
+func (m AppTokenMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, err := m.appTokens.AccessToken()
+	if err != nil {
+		problem.Write(w, problem.FromError(err, "could not obtain app token"))
+		return
+	}
+
+	ctxWithToken := context.WithValue(r.Context(), m.tokenKey, token)
+	m.handler.ServeHTTP(w, r.WithContext(ctxWithToken))
+}
+
+func (m *AppTokenMiddleware) SetTokenKey(key types.ContextKey) {
+	m.tokenKey = key
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appTokenMiddlewareTestSetup() (AppTokenMiddleware, *auth.FakeAppTokenProvider) {
+	appTokens := &auth.FakeAppTokenProvider{}
+	appTokens.SetAccessToken("app_token")
+	return NewAppTokenMiddleware(GetTokenHandler{}, appTokens), appTokens
+}
+
+func TestAppTokenIsPlacedInExpectedContextKey(t *testing.T) {
+	middleware, _ := appTokenMiddlewareTestSetup()
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, httptest.NewRequest("GET", "http://example.com", nil))
+
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+	assert.Equal(t, "app_token", writer.Body.String())
+}
+
+func TestAppTokenMiddlewareReturnsErrorIfTokenNotAvailable(t *testing.T) {
+	middleware, appTokens := appTokenMiddlewareTestSetup()
+	appTokens.SetError(autherrors.NewCannotRetrieveTokenError("test error"))
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, httptest.NewRequest("GET", "http://example.com", nil))
+
+	assert.Equal(t, http.StatusBadGateway, writer.Code)
+}
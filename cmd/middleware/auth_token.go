@@ -2,38 +2,145 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	types "festwrap/internal"
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+	"festwrap/internal/http/problem"
 )
 
-// Extracts the Bearer Auth token in the header and stores in the context variable with the given key
+// Extracts the Bearer Auth token in the header and stores in the context variable with the given key.
+// If sessions are enabled, requests without the header use the token of the session instead. The scopes
+// granted to the token and its expiration are stored too when known, which is the case for sessions and
+// introspected tokens
 type AuthTokenMiddleware struct {
-	tokenKey types.ContextKey
-	handler  http.Handler
+	tokenKey     types.ContextKey
+	scopesKey    types.ContextKey
+	expiryKey    types.ContextKey
+	handler      http.Handler
+	sessions     auth.SessionStore
+	refresher    auth.TokenRefresher
+	introspector auth.TokenIntrospector
 }
 
 func NewAuthTokenMiddleware(handler http.Handler) AuthTokenMiddleware {
-	return AuthTokenMiddleware{tokenKey: types.ContextKey("token"), handler: handler}
+	return AuthTokenMiddleware{
+		tokenKey:  types.ContextKey("token"),
+		scopesKey: types.ContextKey("scopes"),
+		expiryKey: types.ContextKey("token_expiry"),
+		handler:   handler,
+	}
 }
 
 func (m AuthTokenMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		http.Error(w, "Missing authorization header", http.StatusBadRequest)
+	if authHeader == "" && m.sessions != nil {
+		m.serveSession(w, r)
+		return
+	} else if authHeader == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		problem.Write(w, problem.New(http.StatusUnauthorized, problem.MissingCredentialsCode, "missing authorization header"))
 		return
 	} else if !strings.HasPrefix(authHeader, "Bearer ") {
-		http.Error(w, "Unexpected authorization header format", http.StatusUnprocessableEntity)
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_request"`)
+		detail := "authorization header must use the Bearer scheme"
+		problem.Write(w, problem.New(http.StatusUnauthorized, problem.InvalidCredentialsCode, detail))
 		return
 	}
 
 	token := strings.TrimPrefix(authHeader, "Bearer ")
-	ctxWithToken := context.WithValue(r.Context(), m.tokenKey, token)
-	requestWithToken := r.WithContext(ctxWithToken)
-	m.handler.ServeHTTP(w, requestWithToken)
+	if m.introspector == nil {
+		m.serveWithToken(w, r, token, nil, time.Time{})
+		return
+	}
+
+	introspection, err := m.introspector.Introspect(token)
+	if err != nil {
+		problem.Write(w, problem.FromError(err, "could not validate token"))
+		return
+	} else if !introspection.Active {
+		problem.Write(w, problem.New(http.StatusUnauthorized, problem.InvalidCredentialsCode, "token is not active"))
+		return
+	}
+	m.serveWithToken(w, r, token, introspection.Scopes, introspection.ExpiresAt)
+}
+
+// Tokens about to expire are refreshed, so clients using sessions never need to refresh them.
+// Concurrent requests of the same session may refresh it at once, and refresh tokens may be rotated
+// by the first of them. Others failing to refresh keep using the current token while it is valid
+func (m AuthTokenMiddleware) serveSession(w http.ResponseWriter, r *http.Request) {
+	current, err := m.sessions.Get(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		problem.Write(w, problem.FromError(err, "missing authorization header or session"))
+		return
+	}
+
+	token, refreshed, err := m.refresher.RefreshIfExpiring(current)
+	if err != nil && !m.refresher.IsExpired(current) {
+		m.serveWithToken(w, r, current.AccessToken, current.Scopes, current.ExpiresAt)
+		return
+	}
+	var grantErr *autherrors.InvalidGrantError
+	if errors.As(err, &grantErr) {
+		m.sessions.Clear(w)
+		problem.Write(w, problem.FromError(err, "session has expired, login is needed"))
+		return
+	} else if err != nil {
+		problem.Write(w, problem.FromError(err, "could not refresh session"))
+		return
+	}
+
+	if refreshed {
+		if err = m.sessions.Save(w, token); err != nil {
+			problem.Write(w, problem.Internal("could not save session"))
+			return
+		}
+	}
+	m.serveWithToken(w, r, token.AccessToken, token.Scopes, token.ExpiresAt)
+}
+
+// Scopes and expiration are left out of the context if unknown, so they are not mistaken for no scopes
+// granted or an expired token
+func (m AuthTokenMiddleware) serveWithToken(
+	w http.ResponseWriter,
+	r *http.Request,
+	token string,
+	scopes []string,
+	expiresAt time.Time,
+) {
+	ctx := context.WithValue(r.Context(), m.tokenKey, token)
+	if scopes != nil {
+		ctx = context.WithValue(ctx, m.scopesKey, scopes)
+	}
+	if !expiresAt.IsZero() {
+		ctx = context.WithValue(ctx, m.expiryKey, expiresAt)
+	}
+	m.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func (m *AuthTokenMiddleware) EnableSessions(sessions auth.SessionStore, refresher auth.TokenRefresher) {
+	m.sessions = sessions
+	m.refresher = refresher
+}
+
+// Asks the authorization server about the tokens in the header, so their scopes are known
+func (m *AuthTokenMiddleware) EnableIntrospection(introspector auth.TokenIntrospector) {
+	m.introspector = introspector
 }
 
 func (m *AuthTokenMiddleware) SetTokenKey(key types.ContextKey) {
 	m.tokenKey = key
 }
+
+func (m *AuthTokenMiddleware) SetScopesKey(key types.ContextKey) {
+	m.scopesKey = key
+}
+
+func (m *AuthTokenMiddleware) SetExpiryKey(key types.ContextKey) {
+	m.expiryKey = key
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	types "festwrap/internal"
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,21 +35,24 @@ func tokenAuthMiddlewareTestSetup() (AuthTokenMiddleware, *http.Request, *httpte
 	return middleware, request, writer
 }
 
-func TestBadRequestErrorOnMissingAuthHeader(t *testing.T) {
+func TestUnauthorizedErrorOnMissingAuthHeader(t *testing.T) {
 	middleware, request, writer := tokenAuthMiddlewareTestSetup()
 
 	middleware.ServeHTTP(writer, request)
 
-	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	assert.Equal(t, "Bearer", writer.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, "application/problem+json", writer.Header().Get("Content-Type"))
 }
 
-func TestUnprocessableEntityErrorOnWronglyFormattedAuthHeader(t *testing.T) {
+func TestUnauthorizedErrorOnWronglyFormattedAuthHeader(t *testing.T) {
 	middleware, request, writer := tokenAuthMiddlewareTestSetup()
 	request.Header.Set("Authorization", "something")
 
 	middleware.ServeHTTP(writer, request)
 
-	assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	assert.Equal(t, `Bearer error="invalid_request"`, writer.Header().Get("WWW-Authenticate"))
 }
 
 func TestTokenIsPlacedInExpectedContextKey(t *testing.T) {
@@ -65,3 +72,239 @@ func TestMiddlewareReturnsStatusCodeofTheHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusAccepted, writer.Code)
 }
+
+func sessionToken(expiresAt time.Time) auth.Token {
+	return auth.Token{AccessToken: "session_access", RefreshToken: "session_refresh", ExpiresAt: expiresAt}
+}
+
+func sessionMiddlewareTestSetup(t *testing.T) (AuthTokenMiddleware, *auth.FakeOAuthClient, auth.CookieStore[auth.Token]) {
+	sessions, err := auth.NewCookieStore[auth.Token]("session", []byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("could not create session store: %v", err)
+	}
+	client := &auth.FakeOAuthClient{}
+	client.SetRefreshTokenResult(auth.TokenResult{Token: auth.Token{AccessToken: "refreshed_access"}})
+	middleware := NewAuthTokenMiddleware(GetTokenHandler{})
+	middleware.EnableSessions(sessions, auth.NewTokenRefresher(client))
+	return middleware, client, sessions
+}
+
+func requestWithSession(t *testing.T, sessions auth.CookieStore[auth.Token], token auth.Token) *http.Request {
+	writer := httptest.NewRecorder()
+	if err := sessions.Save(writer, token); err != nil {
+		t.Fatalf("could not save session: %v", err)
+	}
+	request := httptest.NewRequest("GET", "http://example.com", nil)
+	request.AddCookie(writer.Result().Cookies()[0])
+	return request
+}
+
+func TestSessionTokenIsPlacedInExpectedContextKey(t *testing.T) {
+	middleware, client, sessions := sessionMiddlewareTestSetup(t)
+	request := requestWithSession(t, sessions, sessionToken(time.Now().Add(time.Hour)))
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+	assert.Equal(t, "session_access", writer.Body.String())
+	assert.Empty(t, client.GetRefreshTokenArgs())
+}
+
+func TestAuthHeaderTakesPrecedenceOverSession(t *testing.T) {
+	middleware, _, sessions := sessionMiddlewareTestSetup(t)
+	request := requestWithSession(t, sessions, sessionToken(time.Now().Add(time.Hour)))
+	request.Header.Set("Authorization", "Bearer 1234")
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, "1234", writer.Body.String())
+}
+
+func TestExpiringSessionTokenIsRefreshed(t *testing.T) {
+	middleware, client, sessions := sessionMiddlewareTestSetup(t)
+	request := requestWithSession(t, sessions, sessionToken(time.Now().Add(10*time.Second)))
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, []string{"session_refresh"}, client.GetRefreshTokenArgs())
+	assert.Equal(t, "refreshed_access", writer.Body.String())
+	saved, err := sessions.Get(requestWithCookies(writer.Result().Cookies()))
+	assert.Nil(t, err)
+	assert.Equal(t, "refreshed_access", saved.AccessToken)
+	assert.Equal(t, "session_refresh", saved.RefreshToken)
+}
+
+func TestUnauthorizedErrorOnMissingSession(t *testing.T) {
+	middleware, _, _ := sessionMiddlewareTestSetup(t)
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, httptest.NewRequest("GET", "http://example.com", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	assert.Equal(t, "Bearer", writer.Header().Get("WWW-Authenticate"))
+}
+
+func TestSessionIsClearedIfRefreshTokenRejected(t *testing.T) {
+	middleware, client, sessions := sessionMiddlewareTestSetup(t)
+	client.SetRefreshTokenResult(auth.TokenResult{Err: autherrors.NewInvalidGrantError("test error")})
+	request := requestWithSession(t, sessions, sessionToken(time.Now().Add(-time.Hour)))
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	assert.Equal(t, -1, writer.Result().Cookies()[0].MaxAge)
+}
+
+func TestValidSessionTokenIsUsedIfItCannotBeRefreshed(t *testing.T) {
+	tests := map[string]struct {
+		err error
+	}{
+		"refresh token rejected":  {err: autherrors.NewInvalidGrantError("test error")},
+		"refresh token not given": {err: autherrors.NewCannotRetrieveTokenError("test error")},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			middleware, client, sessions := sessionMiddlewareTestSetup(t)
+			client.SetRefreshTokenResult(auth.TokenResult{Err: test.err})
+			request := requestWithSession(t, sessions, sessionToken(time.Now().Add(10*time.Second)))
+			writer := httptest.NewRecorder()
+
+			middleware.ServeHTTP(writer, request)
+
+			assert.Equal(t, http.StatusAccepted, writer.Code)
+			assert.Equal(t, "session_access", writer.Body.String())
+			assert.Empty(t, writer.Result().Cookies())
+		})
+	}
+}
+
+func TestBadGatewayIfSessionCannotBeRefreshed(t *testing.T) {
+	middleware, client, sessions := sessionMiddlewareTestSetup(t)
+	client.SetRefreshTokenResult(auth.TokenResult{Err: autherrors.NewCannotRetrieveTokenError("test error")})
+	request := requestWithSession(t, sessions, sessionToken(time.Now().Add(-time.Hour)))
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusBadGateway, writer.Code)
+}
+
+func TestSessionScopesArePlacedInExpectedContextKey(t *testing.T) {
+	sessions, err := auth.NewCookieStore[auth.Token]("session", []byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("could not create session store: %v", err)
+	}
+	token := sessionToken(time.Now().Add(time.Hour))
+	token.Scopes = []string{"playlist-read-private"}
+	var actual []string
+	middleware := NewAuthTokenMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual, _ = r.Context().Value(defaultScopesKey()).([]string)
+	}))
+	middleware.EnableSessions(sessions, auth.NewTokenRefresher(&auth.FakeOAuthClient{}))
+
+	middleware.ServeHTTP(httptest.NewRecorder(), requestWithSession(t, sessions, token))
+
+	assert.Equal(t, []string{"playlist-read-private"}, actual)
+}
+
+func TestSessionExpiryIsPlacedInExpectedContextKey(t *testing.T) {
+	sessions, err := auth.NewCookieStore[auth.Token]("session", []byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("could not create session store: %v", err)
+	}
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	var actual time.Time
+	middleware := NewAuthTokenMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual, _ = r.Context().Value(types.ContextKey("token_expiry")).(time.Time)
+	}))
+	middleware.EnableSessions(sessions, auth.NewTokenRefresher(&auth.FakeOAuthClient{}))
+
+	middleware.ServeHTTP(httptest.NewRecorder(), requestWithSession(t, sessions, sessionToken(expiresAt)))
+
+	assert.True(t, expiresAt.Equal(actual))
+}
+
+func TestExpiryIsLeftOutOfContextIfUnknown(t *testing.T) {
+	found := true
+	middleware := NewAuthTokenMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, found = r.Context().Value(types.ContextKey("token_expiry")).(time.Time)
+	}))
+	request := httptest.NewRequest("GET", "http://example.com", nil)
+	request.Header.Set("Authorization", "Bearer 1234")
+
+	middleware.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.False(t, found)
+}
+
+func introspectionMiddlewareTestSetup(result auth.IntrospectionResult) (AuthTokenMiddleware, *auth.FakeTokenIntrospector, *http.Request) {
+	introspector := &auth.FakeTokenIntrospector{}
+	introspector.SetResult(result)
+	middleware := NewAuthTokenMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes, _ := r.Context().Value(defaultScopesKey()).([]string)
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, strings.Join(scopes, " "))
+	}))
+	middleware.EnableIntrospection(introspector)
+	request := httptest.NewRequest("GET", "http://example.com", nil)
+	request.Header.Set("Authorization", "Bearer 1234")
+	return middleware, introspector, request
+}
+
+func TestIntrospectedScopesArePlacedInExpectedContextKey(t *testing.T) {
+	result := auth.IntrospectionResult{
+		Introspection: auth.Introspection{Active: true, Scopes: []string{"playlist-read-private", "ugc-image-upload"}},
+	}
+	middleware, introspector, request := introspectionMiddlewareTestSetup(result)
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, []string{"1234"}, introspector.GetIntrospectArgs())
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+	assert.Equal(t, "playlist-read-private ugc-image-upload", writer.Body.String())
+}
+
+func TestIntrospectionErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		result       auth.IntrospectionResult
+		expectedCode int
+	}{
+		"inactive token": {
+			result:       auth.IntrospectionResult{Introspection: auth.Introspection{Active: false}},
+			expectedCode: http.StatusUnauthorized,
+		},
+		"introspection unavailable": {
+			result:       auth.IntrospectionResult{Err: autherrors.NewCannotIntrospectTokenError("test error")},
+			expectedCode: http.StatusBadGateway,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			middleware, _, request := introspectionMiddlewareTestSetup(test.result)
+			writer := httptest.NewRecorder()
+
+			middleware.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expectedCode, writer.Code)
+		})
+	}
+}
+
+func requestWithCookies(cookies []*http.Cookie) *http.Request {
+	request := httptest.NewRequest("GET", "http://example.com", nil)
+	for _, cookie := range cookies {
+		request.AddCookie(cookie)
+	}
+	return request
+}
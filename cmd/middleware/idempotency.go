@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	types "festwrap/internal"
+	"festwrap/internal/cache"
+	"festwrap/internal/http/problem"
+)
+
+type IdempotentResponse struct {
+	Fingerprint string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+}
+
+// Replays the stored response of requests sharing the same Idempotency-Key header for the same user,
+// so retried requests do not repeat their side effects (e.g. creating a playlist twice)
+type IdempotencyMiddleware struct {
+	userIdKey types.ContextKey
+	responses cache.Cache[string, IdempotentResponse]
+	inFlight  *inFlightKeys
+	handler   http.Handler
+}
+
+func NewIdempotencyMiddleware(
+	handler http.Handler,
+	responses cache.Cache[string, IdempotentResponse],
+) IdempotencyMiddleware {
+	return IdempotencyMiddleware{
+		userIdKey: types.ContextKey("user_id"),
+		responses: responses,
+		inFlight:  &inFlightKeys{keys: map[string]bool{}},
+		handler:   handler,
+	}
+}
+
+func (m IdempotencyMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if idempotencyKey == "" {
+		m.handler.ServeHTTP(w, r)
+		return
+	}
+
+	userId, ok := r.Context().Value(m.userIdKey).(string)
+	if !ok {
+		problem.Write(w, problem.Internal("could not retrieve user id"))
+		return
+	}
+
+	fingerprint, err := requestFingerprint(r)
+	if err != nil {
+		problem.Write(w, problem.InvalidBody("could not read request body"))
+		return
+	}
+
+	key := fmt.Sprintf("%s:%s", userId, idempotencyKey)
+	if stored, ok := m.responses.Get(key); ok {
+		if stored.Fingerprint != fingerprint {
+			detail := "idempotency key was already used for a different request"
+			problem.Write(w, problem.New(http.StatusUnprocessableEntity, problem.IdempotencyKeyCode, detail))
+			return
+		}
+		replayResponse(w, stored)
+		return
+	}
+
+	if !m.inFlight.Acquire(key) {
+		detail := "a request with the same idempotency key is being processed"
+		problem.Write(w, problem.New(http.StatusConflict, problem.RequestInProgressCode, detail))
+		return
+	}
+	defer m.inFlight.Release(key)
+
+	recorder := &responseRecorder{ResponseWriter: w}
+	m.handler.ServeHTTP(recorder, r)
+	// Streamed responses start with a success status before knowing whether the request succeeds
+	if recorder.streamed || !isReplayable(recorder.StatusCode()) {
+		return
+	}
+	m.responses.Set(key, IdempotentResponse{
+		Fingerprint: fingerprint,
+		StatusCode:  recorder.StatusCode(),
+		Header:      w.Header().Clone(),
+		Body:        recorder.body.Bytes(),
+	})
+}
+
+func (m *IdempotencyMiddleware) SetUserIdKey(key types.ContextKey) {
+	m.userIdKey = key
+}
+
+// Identifies the request so the same key cannot be reused for a different one. Headers negotiating
+// the response are included, since otherwise a response could be replayed in another format
+func requestFingerprint(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.Path)
+	for _, header := range []string{"Accept", "Prefer"} {
+		fmt.Fprintf(hash, "%s: %s\n", header, strings.Join(r.Header.Values(header), ", "))
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Failures which may not happen again, such as upstream errors or expired tokens, are not stored
+// so the client can retry the request with the same key
+func isReplayable(statusCode int) bool {
+	return statusCode < http.StatusInternalServerError &&
+		statusCode != http.StatusUnauthorized &&
+		statusCode != http.StatusForbidden
+}
+
+func replayResponse(w http.ResponseWriter, stored IdempotentResponse) {
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+}
+
+type inFlightKeys struct {
+	mutex sync.Mutex
+	keys  map[string]bool
+}
+
+func (k *inFlightKeys) Acquire(key string) bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.keys[key] {
+		return false
+	}
+	k.keys[key] = true
+	return true
+}
+
+func (k *inFlightKeys) Release(key string) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	delete(k.keys, key)
+}
+
+// Keeps a copy of the response written by the handler
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+	streamed   bool
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(bytes []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	r.body.Write(bytes)
+	return r.ResponseWriter.Write(bytes)
+}
+
+// Allows streaming handlers to work behind the middleware
+func (r *responseRecorder) Flush() {
+	r.streamed = true
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseRecorder) StatusCode() int {
+	if r.statusCode == 0 {
+		return http.StatusOK
+	}
+	return r.statusCode
+}
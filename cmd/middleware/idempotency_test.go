@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"festwrap/internal/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CountingHandler struct {
+	calls  int
+	status int
+}
+
+func (h *CountingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls += 1
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "text/plain")
+	if h.status != 0 {
+		w.WriteHeader(h.status)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	fmt.Fprintf(w, "call %d: %s", h.calls, body)
+}
+
+type BlockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h BlockingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	close(h.started)
+	<-h.release
+	w.WriteHeader(http.StatusCreated)
+}
+
+type StreamingHandler struct {
+	calls int
+}
+
+func (h *StreamingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls += 1
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "event: finished\ndata: {\"status\":502}\n\n")
+	w.(http.Flusher).Flush()
+}
+
+func idempotencyMiddlewareTestSetup() (IdempotencyMiddleware, *CountingHandler) {
+	handler := &CountingHandler{}
+	responses := cache.NewMemoryCache[string, IdempotentResponse](time.Hour)
+	middleware := NewIdempotencyMiddleware(handler, responses)
+	middleware.SetUserIdKey(defaultUserIdKey())
+	return middleware, handler
+}
+
+func idempotentRequest(userId string, key string, body string) *http.Request {
+	request := httptest.NewRequest("POST", "http://example.com/playlists", strings.NewReader(body))
+	if key != "" {
+		request.Header.Set("Idempotency-Key", key)
+	}
+	ctx := context.WithValue(request.Context(), defaultUserIdKey(), userId)
+	return request.WithContext(ctx)
+}
+
+func serveIdempotent(middleware IdempotencyMiddleware, request *http.Request) *httptest.ResponseRecorder {
+	writer := httptest.NewRecorder()
+	middleware.ServeHTTP(writer, request)
+	return writer
+}
+
+func TestIdempotencyMiddlewareCallsHandlerEveryTimeWithoutKey(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+
+	serveIdempotent(middleware, idempotentRequest("some_user", "", "body"))
+	serveIdempotent(middleware, idempotentRequest("some_user", "", "body"))
+
+	assert.Equal(t, 2, handler.calls)
+}
+
+func TestIdempotencyMiddlewareReplaysStoredResponseForRepeatedKey(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+
+	first := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	second := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+
+	assert.Equal(t, 1, handler.calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "text/plain", second.Header().Get("Content-Type"))
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotencyMiddlewareReplaysClientErrors(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+	handler.status = http.StatusBadRequest
+
+	serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	second := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+
+	assert.Equal(t, 1, handler.calls)
+	assert.Equal(t, http.StatusBadRequest, second.Code)
+}
+
+func TestIdempotencyMiddlewareDoesNotStoreRetryableFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status int
+	}{
+		"internal error":      {status: http.StatusInternalServerError},
+		"upstream error":      {status: http.StatusBadGateway},
+		"invalid credentials": {status: http.StatusUnauthorized},
+		"insufficient scope":  {status: http.StatusForbidden},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			middleware, handler := idempotencyMiddlewareTestSetup()
+			handler.status = test.status
+
+			serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+			second := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+
+			assert.Equal(t, 2, handler.calls)
+			assert.Empty(t, second.Header().Get("Idempotent-Replayed"))
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareDoesNotStoreStreamedResponses(t *testing.T) {
+	handler := &StreamingHandler{}
+	responses := cache.NewMemoryCache[string, IdempotentResponse](time.Hour)
+	middleware := NewIdempotencyMiddleware(handler, responses)
+	middleware.SetUserIdKey(defaultUserIdKey())
+
+	serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	second := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+
+	assert.Equal(t, 2, handler.calls)
+	assert.Empty(t, second.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotencyMiddlewarePassesBodyToHandler(t *testing.T) {
+	middleware, _ := idempotencyMiddlewareTestSetup()
+
+	writer := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "some body"))
+
+	assert.Equal(t, "call 1: some body", writer.Body.String())
+}
+
+func TestIdempotencyMiddlewareScopesKeysByUser(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+
+	serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	serveIdempotent(middleware, idempotentRequest("another_user", "some_key", "body"))
+
+	assert.Equal(t, 2, handler.calls)
+}
+
+func TestIdempotencyMiddlewareCallsHandlerAgainOnceResponseExpires(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+	now := time.Now()
+	responses := cache.NewMemoryCache[string, IdempotentResponse](time.Minute)
+	responses.SetClock(func() time.Time { return now })
+	middleware.responses = responses
+
+	serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	now = now.Add(2 * time.Minute)
+	serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+
+	assert.Equal(t, 2, handler.calls)
+}
+
+func TestIdempotencyMiddlewareRejectsKeyReusedForDifferentRequest(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+
+	serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	writer := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "another body"))
+
+	assert.Equal(t, 1, handler.calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+}
+
+func TestIdempotencyMiddlewareRejectsKeyReusedForDifferentNegotiation(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		value  string
+	}{
+		"different media type":    {header: "Accept", value: "text/event-stream"},
+		"different response mode": {header: "Prefer", value: "respond-async"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			middleware, handler := idempotencyMiddlewareTestSetup()
+			serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+			request := idempotentRequest("some_user", "some_key", "body")
+			request.Header.Set(test.header, test.value)
+
+			writer := serveIdempotent(middleware, request)
+
+			assert.Equal(t, 1, handler.calls)
+			assert.Equal(t, http.StatusUnprocessableEntity, writer.Code)
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareReturnsInternalErrorWithoutUserId(t *testing.T) {
+	middleware, handler := idempotencyMiddlewareTestSetup()
+	request := httptest.NewRequest("POST", "http://example.com/playlists", nil)
+	request.Header.Set("Idempotency-Key", "some_key")
+
+	writer := serveIdempotent(middleware, request)
+
+	assert.Equal(t, 0, handler.calls)
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestIdempotencyMiddlewareReturnsConflictWhileSameKeyIsInProgress(t *testing.T) {
+	handler := BlockingHandler{started: make(chan struct{}), release: make(chan struct{})}
+	responses := cache.NewMemoryCache[string, IdempotentResponse](time.Hour)
+	middleware := NewIdempotencyMiddleware(handler, responses)
+	middleware.SetUserIdKey(defaultUserIdKey())
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	}()
+	<-handler.started
+	concurrent := serveIdempotent(middleware, idempotentRequest("some_user", "some_key", "body"))
+	close(handler.release)
+	first := <-done
+
+	assert.Equal(t, http.StatusConflict, concurrent.Code)
+	assert.Equal(t, http.StatusCreated, first.Code)
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"festwrap/internal/http/problem"
+)
+
+// Rejects requests changing data which come from sites other than the allowed ones, since browsers
+// attach the session cookie to them. Requests with the Authorization header do not rely on cookies,
+// and requests without Origin do not come from browsers, which always send it when changing data
+type OriginMiddleware struct {
+	allowedOrigins map[string]bool
+	handler        http.Handler
+}
+
+// Requests from the same host the server is reached at are always allowed
+func NewOriginMiddleware(handler http.Handler, allowedOrigins []string) OriginMiddleware {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if normalized := normalizeOrigin(origin); normalized != "" {
+			allowed[normalized] = true
+		}
+	}
+	return OriginMiddleware{allowedOrigins: allowed, handler: handler}
+}
+
+func (m OriginMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if isSafeMethod(r.Method) || r.Header.Get("Authorization") != "" || origin == "" || m.isAllowed(origin, r) {
+		m.handler.ServeHTTP(w, r)
+		return
+	}
+	problem.Write(w, problem.New(http.StatusForbidden, problem.ForbiddenCode, "origin of the request is not allowed"))
+}
+
+func (m OriginMiddleware) isAllowed(origin string, r *http.Request) bool {
+	if m.allowedOrigins[normalizeOrigin(origin)] {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && parsed.Host != "" && strings.EqualFold(parsed.Host, r.Host)
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+}
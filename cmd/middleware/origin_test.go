@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func originMiddlewareTestSetup() OriginMiddleware {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return NewOriginMiddleware(handler, []string{"https://festwrap.example.com"})
+}
+
+func TestOriginMiddlewareStatusDependingOnRequest(t *testing.T) {
+	tests := map[string]struct {
+		method        string
+		origin        string
+		authorization string
+		expected      int
+	}{
+		"safe method from other origin": {
+			method:   http.MethodGet,
+			origin:   "https://evil.example.com",
+			expected: http.StatusAccepted,
+		},
+		"mutating method without origin": {
+			method:   http.MethodPost,
+			expected: http.StatusAccepted,
+		},
+		"mutating method from allowed origin": {
+			method:   http.MethodPost,
+			origin:   "https://Festwrap.example.com/",
+			expected: http.StatusAccepted,
+		},
+		"mutating method from same host": {
+			method:   http.MethodDelete,
+			origin:   "http://api.example.com",
+			expected: http.StatusAccepted,
+		},
+		"mutating method with authorization header": {
+			method:        http.MethodPut,
+			origin:        "https://evil.example.com",
+			authorization: "Bearer 1234",
+			expected:      http.StatusAccepted,
+		},
+		"mutating method from other origin": {
+			method:   http.MethodPost,
+			origin:   "https://evil.example.com",
+			expected: http.StatusForbidden,
+		},
+		"mutating method from opaque origin": {
+			method:   http.MethodPatch,
+			origin:   "null",
+			expected: http.StatusForbidden,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			middleware := originMiddlewareTestSetup()
+			request := httptest.NewRequest(test.method, "http://api.example.com/playlists", nil)
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+			writer := httptest.NewRecorder()
+
+			middleware.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expected, writer.Code)
+		})
+	}
+}
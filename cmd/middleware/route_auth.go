@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+
+	"festwrap/internal/auth"
+)
+
+// Builds the authentication of each route, which either needs the token of the user or can
+// use the one of the app when only public data is accessed
+type RouteAuthenticator struct {
+	sessions     auth.SessionStore
+	refresher    auth.TokenRefresher
+	appTokens    auth.AppTokenProvider
+	introspector auth.TokenIntrospector
+}
+
+func NewRouteAuthenticator() RouteAuthenticator {
+	return RouteAuthenticator{}
+}
+
+// Requires a token of the user, either in the Authorization header or in the session if enabled.
+// The token must have been granted the given scopes, if they are known
+func (a RouteAuthenticator) RequireUser(handler http.Handler, scopes ...string) http.Handler {
+	if len(scopes) > 0 {
+		handler = NewScopeMiddleware(handler, scopes)
+	}
+	middleware := NewAuthTokenMiddleware(handler)
+	if a.sessions != nil {
+		middleware.EnableSessions(a.sessions, a.refresher)
+	}
+	if a.introspector != nil {
+		middleware.EnableIntrospection(a.introspector)
+	}
+	return middleware
+}
+
+// Same as RequireUser, but the token only needs one of the given scopes
+func (a RouteAuthenticator) RequireUserWithAnyScope(handler http.Handler, scopes ...string) http.Handler {
+	return a.RequireUser(NewAnyScopeMiddleware(handler, scopes))
+}
+
+// Uses the token of the app if enabled. Otherwise, the token of the user is required
+func (a RouteAuthenticator) AllowApp(handler http.Handler) http.Handler {
+	if a.appTokens == nil {
+		return a.RequireUser(handler)
+	}
+	return NewAppTokenMiddleware(handler, a.appTokens)
+}
+
+func (a *RouteAuthenticator) EnableSessions(sessions auth.SessionStore, refresher auth.TokenRefresher) {
+	a.sessions = sessions
+	a.refresher = refresher
+}
+
+func (a *RouteAuthenticator) EnableAppToken(appTokens auth.AppTokenProvider) {
+	a.appTokens = appTokens
+}
+
+func (a *RouteAuthenticator) EnableIntrospection(introspector auth.TokenIntrospector) {
+	a.introspector = introspector
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"festwrap/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appTokens() *auth.FakeAppTokenProvider {
+	appTokens := &auth.FakeAppTokenProvider{}
+	appTokens.SetAccessToken("app_token")
+	return appTokens
+}
+
+func TestRequireUserRejectsRequestsWithoutUserToken(t *testing.T) {
+	authenticator := NewRouteAuthenticator()
+	authenticator.EnableAppToken(appTokens())
+	writer := httptest.NewRecorder()
+
+	authenticator.RequireUser(GetTokenHandler{}).ServeHTTP(writer, httptest.NewRequest("GET", "http://example.com", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+}
+
+func TestRequireUserUsesSessionsIfEnabled(t *testing.T) {
+	_, _, sessions := sessionMiddlewareTestSetup(t)
+	authenticator := NewRouteAuthenticator()
+	authenticator.EnableSessions(sessions, auth.NewTokenRefresher(&auth.FakeOAuthClient{}))
+	request := requestWithSession(t, sessions, sessionToken(time.Now().Add(time.Hour)))
+	writer := httptest.NewRecorder()
+
+	authenticator.RequireUser(GetTokenHandler{}).ServeHTTP(writer, request)
+
+	assert.Equal(t, "session_access", writer.Body.String())
+}
+
+func TestAllowAppUsesAppToken(t *testing.T) {
+	authenticator := NewRouteAuthenticator()
+	authenticator.EnableAppToken(appTokens())
+	writer := httptest.NewRecorder()
+
+	authenticator.AllowApp(GetTokenHandler{}).ServeHTTP(writer, httptest.NewRequest("GET", "http://example.com", nil))
+
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+	assert.Equal(t, "app_token", writer.Body.String())
+}
+
+func TestAllowAppRequiresUserIfAppTokenNotEnabled(t *testing.T) {
+	authenticator := NewRouteAuthenticator()
+	writer := httptest.NewRecorder()
+
+	authenticator.AllowApp(GetTokenHandler{}).ServeHTTP(writer, httptest.NewRequest("GET", "http://example.com", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+}
+
+func TestRequireUserChecksScopesIfKnown(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		granted      []string
+		expectedCode int
+	}{
+		"scopes granted": {
+			granted:      []string{"playlist-read-private", "playlist-modify-public"},
+			expectedCode: http.StatusAccepted,
+		},
+		"scopes missing": {
+			granted:      []string{"playlist-read-private"},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			introspector := &auth.FakeTokenIntrospector{}
+			introspector.SetResult(auth.IntrospectionResult{Introspection: auth.Introspection{Active: true, Scopes: test.granted}})
+			authenticator := NewRouteAuthenticator()
+			authenticator.EnableIntrospection(introspector)
+			request := httptest.NewRequest("GET", "http://example.com", nil)
+			request.Header.Set("Authorization", "Bearer 1234")
+			writer := httptest.NewRecorder()
+
+			authenticator.RequireUser(GetTokenHandler{}, "playlist-modify-public").ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expectedCode, writer.Code)
+		})
+	}
+}
+
+func TestRequireUserWithAnyScopeChecksScopesIfKnown(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		granted      []string
+		expectedCode int
+	}{
+		"first scope granted": {
+			granted:      []string{"playlist-modify-public"},
+			expectedCode: http.StatusAccepted,
+		},
+		"second scope granted": {
+			granted:      []string{"playlist-modify-private"},
+			expectedCode: http.StatusAccepted,
+		},
+		"scopes missing": {
+			granted:      []string{"playlist-read-private"},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			introspector := &auth.FakeTokenIntrospector{}
+			introspector.SetResult(auth.IntrospectionResult{Introspection: auth.Introspection{Active: true, Scopes: test.granted}})
+			authenticator := NewRouteAuthenticator()
+			authenticator.EnableIntrospection(introspector)
+			request := httptest.NewRequest("GET", "http://example.com", nil)
+			request.Header.Set("Authorization", "Bearer 1234")
+			writer := httptest.NewRecorder()
+
+			handler := authenticator.RequireUserWithAnyScope(GetTokenHandler{}, "playlist-modify-public", "playlist-modify-private")
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expectedCode, writer.Code)
+		})
+	}
+}
+
+func TestRequireUserSkipsScopesIfUnknown(t *testing.T) {
+	authenticator := NewRouteAuthenticator()
+	request := httptest.NewRequest("GET", "http://example.com", nil)
+	request.Header.Set("Authorization", "Bearer 1234")
+	writer := httptest.NewRecorder()
+
+	authenticator.RequireUser(GetTokenHandler{}, "playlist-modify-public").ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	types "festwrap/internal"
+	"festwrap/internal/auth"
+	"festwrap/internal/http/problem"
+)
+
+// Rejects requests whose token lacks the scopes needed by the handler, before any work is done.
+// Requests are let through if the granted scopes are unknown, in which case Spotify rejects them
+type ScopeMiddleware struct {
+	scopesKey      types.ContextKey
+	requiredScopes []string
+	anyScope       bool
+	handler        http.Handler
+}
+
+func NewScopeMiddleware(handler http.Handler, requiredScopes []string) ScopeMiddleware {
+	return ScopeMiddleware{scopesKey: types.ContextKey("scopes"), requiredScopes: requiredScopes, handler: handler}
+}
+
+// Only requires one of the scopes, for handlers where each of them allows part of the work
+func NewAnyScopeMiddleware(handler http.Handler, requiredScopes []string) ScopeMiddleware {
+	middleware := NewScopeMiddleware(handler, requiredScopes)
+	middleware.anyScope = true
+	return middleware
+}
+
+func (m ScopeMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	granted, ok := r.Context().Value(m.scopesKey).([]string)
+	if !ok {
+		m.handler.ServeHTTP(w, r)
+		return
+	}
+
+	missing := auth.MissingScopes(granted, m.requiredScopes)
+	if len(missing) > 0 && !(m.anyScope && len(missing) < len(m.requiredScopes)) {
+		challenge := fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(m.requiredScopes, " "))
+		w.Header().Set("WWW-Authenticate", challenge)
+		problem.Write(w, problem.InsufficientScope(missing))
+		return
+	}
+	m.handler.ServeHTTP(w, r)
+}
+
+func (m *ScopeMiddleware) SetScopesKey(key types.ContextKey) {
+	m.scopesKey = key
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	types "festwrap/internal"
+	"festwrap/internal/http/problem"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func defaultScopesKey() types.ContextKey {
+	var scopesKey types.ContextKey = "scopes"
+	return scopesKey
+}
+
+func requestWithScopes(scopes []string) *http.Request {
+	request := httptest.NewRequest("GET", "http://example.com", nil)
+	return request.WithContext(context.WithValue(request.Context(), defaultScopesKey(), scopes))
+}
+
+func scopeMiddlewareTestSetup() ScopeMiddleware {
+	return NewScopeMiddleware(GetTokenHandler{}, []string{"playlist-modify-public", "playlist-modify-private"})
+}
+
+func TestScopeMiddlewareCallsHandlerIfScopesGranted(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		request *http.Request
+	}{
+		"all scopes granted": {
+			request: requestWithScopes([]string{"playlist-modify-private", "playlist-read-private", "playlist-modify-public"}),
+		},
+		"unknown scopes": {
+			request: httptest.NewRequest("GET", "http://example.com", nil),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			middleware := scopeMiddlewareTestSetup()
+			writer := httptest.NewRecorder()
+
+			middleware.ServeHTTP(writer, test.request)
+
+			assert.Equal(t, http.StatusAccepted, writer.Code)
+		})
+	}
+}
+
+func TestScopeMiddlewareReturnsForbiddenOnMissingScopes(t *testing.T) {
+	middleware := scopeMiddlewareTestSetup()
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, requestWithScopes([]string{"playlist-modify-public"}))
+
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+	assert.Equal(
+		t,
+		`Bearer error="insufficient_scope", scope="playlist-modify-public playlist-modify-private"`,
+		writer.Header().Get("WWW-Authenticate"),
+	)
+	var actual problem.Problem
+	assert.Nil(t, json.Unmarshal(writer.Body.Bytes(), &actual))
+	assert.Equal(t, problem.InsufficientScopeCode, actual.Code)
+	assert.Equal(t, []string{"playlist-modify-private"}, actual.MissingScopes)
+}
+
+func TestScopeMiddlewareReturnsForbiddenIfNoScopesGranted(t *testing.T) {
+	middleware := scopeMiddlewareTestSetup()
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, requestWithScopes([]string{}))
+
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+}
+
+func TestAnyScopeMiddlewareCallsHandlerIfSomeScopeGranted(t *testing.T) {
+	middleware := NewAnyScopeMiddleware(GetTokenHandler{}, []string{"playlist-modify-public", "playlist-modify-private"})
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, requestWithScopes([]string{"playlist-modify-private"}))
+
+	assert.Equal(t, http.StatusAccepted, writer.Code)
+}
+
+func TestAnyScopeMiddlewareReturnsForbiddenIfNoScopeGranted(t *testing.T) {
+	middleware := NewAnyScopeMiddleware(GetTokenHandler{}, []string{"playlist-modify-public", "playlist-modify-private"})
+	writer := httptest.NewRecorder()
+
+	middleware.ServeHTTP(writer, requestWithScopes([]string{"playlist-read-private"}))
+
+	var actual problem.Problem
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+	assert.Nil(t, json.Unmarshal(writer.Body.Bytes(), &actual))
+	assert.Equal(t, []string{"playlist-modify-public", "playlist-modify-private"}, actual.MissingScopes)
+}
@@ -5,6 +5,7 @@ import (
 	types "festwrap/internal"
 	"net/http"
 
+	"festwrap/internal/http/problem"
 	"festwrap/internal/user"
 )
 
@@ -22,7 +23,8 @@ func NewUserIdMiddleware(handler http.Handler, userRepository user.UserRepositor
 func (m UserIdMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	currentUserId, err := m.userRepository.GetCurrentUserId(r.Context())
 	if err != nil {
-		http.Error(w, "Unexpected error: could not retrieve user id", http.StatusInternalServerError)
+		problem.Write(w, problem.FromError(err, "could not retrieve user id"))
+		return
 	}
 
 	ctxWithUserId := context.WithValue(r.Context(), m.userIdKey, currentUserId)
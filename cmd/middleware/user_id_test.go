@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	types "festwrap/internal"
+	senderrors "festwrap/internal/http/sender/errors"
 	"festwrap/internal/user"
 
 	"github.com/stretchr/testify/assert"
@@ -63,6 +64,31 @@ func TestGetUserReturnsInternalErrorOnRepositoryError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, writer.Result().StatusCode)
 }
 
+func TestGetUserDoesNotCallHandlerOnRepositoryError(t *testing.T) {
+	middleware, request, writer := userIdMiddlewareTestSetup()
+	userRepository := user.FakeUserRepository{}
+	userRepository.SetGetCurrentIdValue(user.GetCurrentIdValue{UserId: "", Err: errors.New("test error")})
+	middleware.SetUserRepository(&userRepository)
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.NotEqual(t, http.StatusContinue, writer.Code)
+	assert.Contains(t, writer.Body.String(), "could not retrieve user id")
+}
+
+func TestGetUserReturnsUnauthorizedOnUpstreamAuthError(t *testing.T) {
+	middleware, request, writer := userIdMiddlewareTestSetup()
+	userRepository := user.FakeUserRepository{}
+	authErr := senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, "")
+	userRepository.SetGetCurrentIdValue(user.GetCurrentIdValue{UserId: "", Err: authErr})
+	middleware.SetUserRepository(&userRepository)
+
+	middleware.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusUnauthorized, writer.Result().StatusCode)
+	assert.Equal(t, `Bearer error="invalid_token"`, writer.Header().Get("WWW-Authenticate"))
+}
+
 func TestUserIsPlacedInExpectedContextKey(t *testing.T) {
 	middleware, request, writer := userIdMiddlewareTestSetup()
 
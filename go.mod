@@ -1,8 +1,11 @@
 module festwrap
 
-go 1.24
+go 1.24.0
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.30.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -1,8 +1,20 @@
 package artist
 
+type Image struct {
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
 type Artist struct {
-	Name     string `json:"name"`
-	ImageUri string `json:"imageUri,omitempty"`
+	Id         string   `json:"id,omitempty"`
+	Uri        string   `json:"uri,omitempty"`
+	Name       string   `json:"name"`
+	ImageUri   string   `json:"imageUri,omitempty"`
+	Images     []Image  `json:"images,omitempty"`
+	Genres     []string `json:"genres,omitempty"`
+	Popularity int      `json:"popularity"`
+	Followers  int      `json:"followers"`
 }
 
 func NewArtist(name string) Artist {
@@ -16,3 +28,31 @@ func NewArtistWithImageUri(name string, imageUri string) Artist {
 func (a *Artist) SetImageUri(imageUri string) {
 	a.ImageUri = imageUri
 }
+
+// Returns the image whose largest side is closest to the given size, preferring the bigger one on ties
+func (a Artist) ClosestImage(size int) (Image, bool) {
+	if len(a.Images) == 0 {
+		return Image{}, false
+	}
+
+	closest := a.Images[0]
+	for _, image := range a.Images[1:] {
+		distance, closestDistance := sizeDistance(image, size), sizeDistance(closest, size)
+		if distance < closestDistance || (distance == closestDistance && image.longestSide() > closest.longestSide()) {
+			closest = image
+		}
+	}
+	return closest, true
+}
+
+func (i Image) longestSide() int {
+	return max(i.Width, i.Height)
+}
+
+func sizeDistance(image Image, size int) int {
+	distance := image.longestSide() - size
+	if distance < 0 {
+		return -distance
+	}
+	return distance
+}
@@ -0,0 +1,24 @@
+package artist
+
+import (
+	"context"
+	"fmt"
+
+	"festwrap/internal/artist/errors"
+	"festwrap/internal/text"
+)
+
+// Returns the Spotify id of the artist with the given name. Only the first search result is
+// considered, and its name has to be the same ignoring case and accents, so songs of other
+// artists are not picked instead
+func FindArtistId(ctx context.Context, repository ArtistRepository, name string) (string, error) {
+	found, err := repository.SearchArtist(ctx, name, 0, 1)
+	if err != nil {
+		return "", err
+	}
+
+	if len(found.Items) == 0 || text.Normalize(found.Items[0].Name) != text.Normalize(name) {
+		return "", errors.NewArtistNotFoundError(fmt.Sprintf("could not find Spotify artist %s", name))
+	}
+	return found.Items[0].Id, nil
+}
@@ -0,0 +1,77 @@
+package artist
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lookupRepository(found ...Artist) *FakeArtistRepository {
+	repository := &FakeArtistRepository{}
+	repository.SetSearchReturnValue(found)
+	return repository
+}
+
+func TestFindArtistIdSearchesBestMatch(t *testing.T) {
+	repository := lookupRepository(Artist{Id: "toucheAmore", Name: "Touché Amoré"})
+
+	_, err := FindArtistId(context.Background(), repository, "Touche Amore")
+
+	expected := SearchArtistArgs{Context: context.Background(), Name: "Touche Amore", Offset: 0, Limit: 1}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, repository.GetSearchArtistArgs())
+}
+
+func TestFindArtistIdReturnsIdOfArtistWithSameName(t *testing.T) {
+	tests := map[string]struct {
+		name string
+	}{
+		"same name":          {name: "Touché Amoré"},
+		"different case":     {name: "TOUCHÉ AMORÉ"},
+		"without accents":    {name: "Touche Amore"},
+		"surrounding spaces": {name: " Touché Amoré  "},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			repository := lookupRepository(Artist{Id: "toucheAmore", Name: "Touché Amoré"})
+
+			actual, err := FindArtistId(context.Background(), repository, test.name)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "toucheAmore", actual)
+		})
+	}
+}
+
+func TestFindArtistIdReturnsError(t *testing.T) {
+	tests := map[string]struct {
+		repository *FakeArtistRepository
+	}{
+		"search error": {
+			repository: func() *FakeArtistRepository {
+				repository := lookupRepository()
+				repository.SetSearchArtistError(errors.New("test error"))
+				return repository
+			}(),
+		},
+		"no results": {
+			repository: lookupRepository(),
+		},
+		"different artist": {
+			repository: lookupRepository(Artist{Id: "toucheAmore", Name: "Touché Amoré"}),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := FindArtistId(context.Background(), test.repository, "Pianos Become the Teeth")
+
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -1,7 +1,15 @@
 package artist
 
-import "context"
+import (
+	"context"
+
+	"festwrap/internal/pagination"
+)
 
 type ArtistRepository interface {
-	SearchArtist(ctx context.Context, name string, limit int) ([]Artist, error)
+	SearchArtist(ctx context.Context, name string, offset int, limit int) (pagination.Page[Artist], error)
+	// Returns the artists Spotify considers similar to the given one, most related first
+	GetRelatedArtists(ctx context.Context, artistId string) ([]Artist, error)
+	// Returns the names of the most popular tracks of the artist, most popular first
+	GetTopTracks(ctx context.Context, artistId string) ([]string, error)
 }
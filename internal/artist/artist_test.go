@@ -0,0 +1,58 @@
+package artist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func artistImages() []Image {
+	return []Image{
+		{Url: "large", Width: 640, Height: 640},
+		{Url: "medium", Width: 300, Height: 300},
+		{Url: "small", Width: 64, Height: 64},
+	}
+}
+
+func TestClosestImage(t *testing.T) {
+	tests := map[string]struct {
+		size     int
+		expected string
+	}{
+		"exact size": {
+			size:     300,
+			expected: "medium",
+		},
+		"closer to smaller image": {
+			size:     100,
+			expected: "small",
+		},
+		"bigger than all images": {
+			size:     1000,
+			expected: "large",
+		},
+		"tie between images": {
+			size:     470,
+			expected: "large",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			artist := Artist{Name: "Movements", Images: artistImages()}
+
+			actual, found := artist.ClosestImage(test.size)
+
+			assert.True(t, found)
+			assert.Equal(t, test.expected, actual.Url)
+		})
+	}
+}
+
+func TestClosestImageNotFoundIfArtistHasNoImages(t *testing.T) {
+	artist := NewArtist("Movements")
+
+	_, found := artist.ClosestImage(300)
+
+	assert.False(t, found)
+}
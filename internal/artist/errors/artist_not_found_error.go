@@ -0,0 +1,13 @@
+package errors
+
+type ArtistNotFoundError struct {
+	message string
+}
+
+func NewArtistNotFoundError(message string) error {
+	return &ArtistNotFoundError{message: message}
+}
+
+func (e *ArtistNotFoundError) Error() string {
+	return e.message
+}
@@ -1,10 +1,15 @@
 package artist
 
-import "context"
+import (
+	"context"
+
+	"festwrap/internal/pagination"
+)
 
 type SearchArtistArgs struct {
 	Context context.Context
 	Name    string
+	Offset  int
 	Limit   int
 }
 
@@ -13,14 +18,59 @@ type SearchArtistReturn struct {
 	err   error
 }
 
+type GetRelatedArtistsArgs struct {
+	Context  context.Context
+	ArtistId string
+}
+
+type GetTopTracksArgs struct {
+	Context  context.Context
+	ArtistId string
+}
+
 type FakeArtistRepository struct {
-	searchArgs   SearchArtistArgs
-	searchReturn SearchArtistReturn
+	searchArgs     SearchArtistArgs
+	searchReturn   SearchArtistReturn
+	relatedArgs    []GetRelatedArtistsArgs
+	relatedArtists map[string][]Artist
+	relatedErr     error
+	topTracksArgs  []GetTopTracksArgs
+	topTracks      map[string][]string
+	topTracksErr   error
 }
 
-func (r *FakeArtistRepository) SearchArtist(ctx context.Context, name string, limit int) ([]Artist, error) {
-	r.searchArgs = SearchArtistArgs{Name: name, Limit: limit, Context: ctx}
-	return r.searchReturn.value, r.searchReturn.err
+func (r *FakeArtistRepository) SearchArtist(
+	ctx context.Context,
+	name string,
+	offset int,
+	limit int,
+) (pagination.Page[Artist], error) {
+	r.searchArgs = SearchArtistArgs{Name: name, Offset: offset, Limit: limit, Context: ctx}
+	return pagination.NewPage(r.searchReturn.value, offset, len(r.searchReturn.value)), r.searchReturn.err
+}
+
+func (r *FakeArtistRepository) GetRelatedArtists(ctx context.Context, artistId string) ([]Artist, error) {
+	r.relatedArgs = append(r.relatedArgs, GetRelatedArtistsArgs{Context: ctx, ArtistId: artistId})
+	if r.relatedErr != nil {
+		return nil, r.relatedErr
+	}
+	related, ok := r.relatedArtists[artistId]
+	if !ok {
+		return []Artist{}, nil
+	}
+	return related, nil
+}
+
+func (r *FakeArtistRepository) GetTopTracks(ctx context.Context, artistId string) ([]string, error) {
+	r.topTracksArgs = append(r.topTracksArgs, GetTopTracksArgs{Context: ctx, ArtistId: artistId})
+	if r.topTracksErr != nil {
+		return nil, r.topTracksErr
+	}
+	tracks, ok := r.topTracks[artistId]
+	if !ok {
+		return []string{}, nil
+	}
+	return tracks, nil
 }
 
 func (r *FakeArtistRepository) GetSearchArtistArgs() SearchArtistArgs {
@@ -34,3 +84,33 @@ func (r *FakeArtistRepository) SetSearchReturnValue(value []Artist) {
 func (r *FakeArtistRepository) SetSearchArtistError(err error) {
 	r.searchReturn.err = err
 }
+
+func (r *FakeArtistRepository) GetGetRelatedArtistsArgs() []GetRelatedArtistsArgs {
+	return r.relatedArgs
+}
+
+func (r *FakeArtistRepository) SetRelatedArtists(artistId string, related []Artist) {
+	if r.relatedArtists == nil {
+		r.relatedArtists = map[string][]Artist{}
+	}
+	r.relatedArtists[artistId] = related
+}
+
+func (r *FakeArtistRepository) SetRelatedArtistsError(err error) {
+	r.relatedErr = err
+}
+
+func (r *FakeArtistRepository) GetGetTopTracksArgs() []GetTopTracksArgs {
+	return r.topTracksArgs
+}
+
+func (r *FakeArtistRepository) SetTopTracks(artistId string, tracks []string) {
+	if r.topTracks == nil {
+		r.topTracks = map[string][]string{}
+	}
+	r.topTracks[artistId] = tracks
+}
+
+func (r *FakeArtistRepository) SetTopTracksError(err error) {
+	r.topTracksErr = err
+}
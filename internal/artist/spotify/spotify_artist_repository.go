@@ -6,25 +6,32 @@ import (
 	"festwrap/internal/artist"
 	"festwrap/internal/artist/errors"
 	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/pagination"
 	"festwrap/internal/serialization"
 	"fmt"
 	"net/url"
 )
 
 type SpotifyArtistRepository struct {
-	tokenKey     types.ContextKey
-	host         string
-	deserializer serialization.Deserializer[spotifyResponse]
-	httpSender   httpsender.HTTPRequestSender
+	tokenKey            types.ContextKey
+	host                string
+	deserializer        serialization.Deserializer[spotifyResponse]
+	relatedDeserializer serialization.Deserializer[spotifyRelatedArtistsResponse]
+	topDeserializer     serialization.Deserializer[spotifyTopTracksResponse]
+	httpSender          httpsender.HTTPRequestSender
 }
 
 func NewSpotifyArtistRepository(httpSender httpsender.HTTPRequestSender) SpotifyArtistRepository {
 	deserializer := serialization.NewJsonDeserializer[spotifyResponse]()
+	relatedDeserializer := serialization.NewJsonDeserializer[spotifyRelatedArtistsResponse]()
+	topDeserializer := serialization.NewJsonDeserializer[spotifyTopTracksResponse]()
 	return SpotifyArtistRepository{
-		tokenKey:     "token",
-		host:         "api.spotify.com",
-		deserializer: deserializer,
-		httpSender:   httpSender,
+		tokenKey:            "token",
+		host:                "api.spotify.com",
+		deserializer:        deserializer,
+		relatedDeserializer: relatedDeserializer,
+		topDeserializer:     topDeserializer,
+		httpSender:          httpSender,
 	}
 }
 
@@ -36,20 +43,50 @@ func (r *SpotifyArtistRepository) SetDeserializer(deserializer serialization.Des
 	r.deserializer = deserializer
 }
 
-func (r *SpotifyArtistRepository) SearchArtist(ctx context.Context, name string, limit int) ([]artist.Artist, error) {
+func (r *SpotifyArtistRepository) SearchArtist(
+	ctx context.Context,
+	name string,
+	offset int,
+	limit int,
+) (pagination.Page[artist.Artist], error) {
 	token, ok := ctx.Value(r.tokenKey).(string)
 	if !ok {
-		return nil, errors.NewCannotRetrieveArtistsError("Could not retrieve token from context")
+		return pagination.Page[artist.Artist]{}, errors.NewCannotRetrieveArtistsError("Could not retrieve token from context")
 	}
 
-	httpOptions := r.createSetlistHttpOptions(name, limit, token)
+	httpOptions := r.createSetlistHttpOptions(name, offset, limit, token)
 	responseBody, err := r.httpSender.Send(httpOptions)
 	if err != nil {
-		return nil, errors.NewCannotRetrieveArtistsError(err.Error())
+		return pagination.Page[artist.Artist]{}, httpsender.WrapError(err, errors.NewCannotRetrieveArtistsError)
 	}
 
 	var response spotifyResponse
 	err = r.deserializer.Deserialize(*responseBody, &response)
+	if err != nil {
+		return pagination.Page[artist.Artist]{}, errors.NewCannotRetrieveArtistsError(err.Error())
+	}
+
+	return pagination.NewPage(response.GetArtists(), offset, response.Artists.Total), nil
+}
+
+func (r *SpotifyArtistRepository) GetRelatedArtists(ctx context.Context, artistId string) ([]artist.Artist, error) {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return nil, errors.NewCannotRetrieveArtistsError("Could not retrieve token from context")
+	}
+
+	if artistId == "" {
+		return nil, errors.NewCannotRetrieveArtistsError("Artist id must be provided to retrieve related artists")
+	}
+
+	httpOptions := r.createArtistHttpOptions(artistId, "related-artists", token)
+	responseBody, err := r.httpSender.Send(httpOptions)
+	if err != nil {
+		return nil, httpsender.WrapError(err, errors.NewCannotRetrieveArtistsError)
+	}
+
+	var response spotifyRelatedArtistsResponse
+	err = r.relatedDeserializer.Deserialize(*responseBody, &response)
 	if err != nil {
 		return nil, errors.NewCannotRetrieveArtistsError(err.Error())
 	}
@@ -57,22 +94,63 @@ func (r *SpotifyArtistRepository) SearchArtist(ctx context.Context, name string,
 	return response.GetArtists(), nil
 }
 
+func (r *SpotifyArtistRepository) GetTopTracks(ctx context.Context, artistId string) ([]string, error) {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return nil, errors.NewCannotRetrieveArtistsError("Could not retrieve token from context")
+	}
+
+	if artistId == "" {
+		return nil, errors.NewCannotRetrieveArtistsError("Artist id must be provided to retrieve top tracks")
+	}
+
+	httpOptions := r.createArtistHttpOptions(artistId, "top-tracks", token)
+	responseBody, err := r.httpSender.Send(httpOptions)
+	if err != nil {
+		return nil, httpsender.WrapError(err, errors.NewCannotRetrieveArtistsError)
+	}
+
+	var response spotifyTopTracksResponse
+	err = r.topDeserializer.Deserialize(*responseBody, &response)
+	if err != nil {
+		return nil, errors.NewCannotRetrieveArtistsError(err.Error())
+	}
+
+	return response.GetTrackNames(), nil
+}
+
+// Options for requests on resources of a given artist, such as its related artists
+func (r *SpotifyArtistRepository) createArtistHttpOptions(
+	artistId string,
+	resource string,
+	token string,
+) httpsender.HTTPRequestOptions {
+	requestUrl := fmt.Sprintf("https://%s/v1/artists/%s/%s", r.host, url.PathEscape(artistId), resource)
+	httpOptions := httpsender.NewHTTPRequestOptions(requestUrl, httpsender.GET, 200)
+	httpOptions.SetHeaders(
+		map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token)},
+	)
+	return httpOptions
+}
+
 func (r *SpotifyArtistRepository) createSetlistHttpOptions(
 	artist string,
+	offset int,
 	limit int,
 	token string,
 ) httpsender.HTTPRequestOptions {
-	httpOptions := httpsender.NewHTTPRequestOptions(r.getSearchUrl(artist, limit), httpsender.GET, 200)
+	httpOptions := httpsender.NewHTTPRequestOptions(r.getSearchUrl(artist, offset, limit), httpsender.GET, 200)
 	httpOptions.SetHeaders(
 		map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token)},
 	)
 	return httpOptions
 }
 
-func (r *SpotifyArtistRepository) getSearchUrl(artistName string, limit int) string {
+func (r *SpotifyArtistRepository) getSearchUrl(artistName string, offset int, limit int) string {
 	queryParams := url.Values{}
 	queryParams.Set("type", "artist")
 	queryParams.Set("q", artistName)
 	queryParams.Set("limit", fmt.Sprint(limit))
+	queryParams.Set("offset", fmt.Sprint(offset))
 	return fmt.Sprintf("https://%s/v1/search?%s", r.host, queryParams.Encode())
 }
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"path/filepath"
 
 	types "festwrap/internal"
 	"festwrap/internal/artist"
 	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
+	"festwrap/internal/pagination"
 	"festwrap/internal/testtools"
 	"testing"
 
@@ -17,6 +20,7 @@ import (
 
 const (
 	searchName = "Movements"
+	offset     = 10
 	limit      = 2
 	tokenKey   = types.ContextKey("myKey")
 	authToken  = "some_token"
@@ -30,8 +34,9 @@ func testContext() context.Context {
 
 func searchArtistHttpOptions() httpsender.HTTPRequestOptions {
 	url := fmt.Sprintf(
-		"https://api.spotify.com/v1/search?limit=%d&q=%s&type=artist",
+		"https://api.spotify.com/v1/search?limit=%d&offset=%d&q=%s&type=artist",
 		limit,
+		offset,
 		searchName,
 	)
 	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
@@ -57,22 +62,68 @@ func artistSearchEmptyResponse(t *testing.T) *[]byte {
 	return &result
 }
 
+func spotifyImages(hash string, mediumSize int) []artist.Image {
+	return []artist.Image{
+		{Url: fmt.Sprintf("https://i.scdn.co/image/ab67616d0000b273%s", hash), Width: 640, Height: 640},
+		{Url: fmt.Sprintf("https://i.scdn.co/image/ab67616d00001e02%s", hash), Width: mediumSize, Height: mediumSize},
+		{Url: fmt.Sprintf("https://i.scdn.co/image/ab67616d00004851%s", hash), Width: 64, Height: 64},
+	}
+}
+
 func searchedArtists() []artist.Artist {
 	return []artist.Artist{
-		artist.NewArtistWithImageUri(
-			"The Beatles",
-			"https://i.scdn.co/image/ab6761610000f178e9348cc01ff5d55971b22433",
-		),
-		artist.NewArtistWithImageUri(
-			"The Beatles Tribute Band",
-			"https://i.scdn.co/image/ab67616d00004851a53d58fac4e46d5264adc122",
-		),
-		artist.NewArtist("The Beatles Recovered Band"),
-		artist.NewArtistWithImageUri(
-			"The Beatles Greatest Hits Performed By The Frank Berman Band",
-			"https://i.scdn.co/image/ab67616d00004851f903d75acdce7727b3c4aa2c",
-		),
-		artist.NewArtist("The Beatles Revival Band"),
+		{
+			Id:       "3WrFJ7ztbogyGnTHbHJFl2",
+			Uri:      "spotify:artist:3WrFJ7ztbogyGnTHbHJFl2",
+			Name:     "The Beatles",
+			ImageUri: "https://i.scdn.co/image/ab6761610000f178e9348cc01ff5d55971b22433",
+			Images: []artist.Image{
+				{Url: "https://i.scdn.co/image/ab6761610000e5ebe9348cc01ff5d55971b22433", Width: 640, Height: 640},
+				{Url: "https://i.scdn.co/image/ab67616100005174e9348cc01ff5d55971b22433", Width: 320, Height: 320},
+				{Url: "https://i.scdn.co/image/ab6761610000f178e9348cc01ff5d55971b22433", Width: 160, Height: 160},
+			},
+			Genres:     []string{"british invasion", "classic rock", "merseybeat", "psychedelic rock", "rock"},
+			Popularity: 85,
+			Followers:  28265225,
+		},
+		{
+			Id:         "0rhGLV687CCwGfeJYXd176",
+			Uri:        "spotify:artist:0rhGLV687CCwGfeJYXd176",
+			Name:       "The Beatles Tribute Band",
+			ImageUri:   "https://i.scdn.co/image/ab67616d00004851a53d58fac4e46d5264adc122",
+			Images:     spotifyImages("a53d58fac4e46d5264adc122", 300),
+			Genres:     []string{},
+			Popularity: 24,
+			Followers:  5162,
+		},
+		{
+			Id:         "3cBV24PM5nZsXqopSHvdtS",
+			Uri:        "spotify:artist:3cBV24PM5nZsXqopSHvdtS",
+			Name:       "The Beatles Recovered Band",
+			Images:     []artist.Image{},
+			Genres:     []string{"tribute"},
+			Popularity: 27,
+			Followers:  13391,
+		},
+		{
+			Id:         "36QpVbYALWlz5NtMI3LhiV",
+			Uri:        "spotify:artist:36QpVbYALWlz5NtMI3LhiV",
+			Name:       "The Beatles Greatest Hits Performed By The Frank Berman Band",
+			ImageUri:   "https://i.scdn.co/image/ab67616d00004851f903d75acdce7727b3c4aa2c",
+			Images:     spotifyImages("f903d75acdce7727b3c4aa2c", 300),
+			Genres:     []string{"tribute"},
+			Popularity: 23,
+			Followers:  9935,
+		},
+		{
+			Id:         "0zRgwHorAZPeYVdTW9F5OX",
+			Uri:        "spotify:artist:0zRgwHorAZPeYVdTW9F5OX",
+			Name:       "The Beatles Revival Band",
+			Images:     []artist.Image{},
+			Genres:     []string{"tribute"},
+			Popularity: 33,
+			Followers:  10244,
+		},
 	}
 }
 
@@ -92,7 +143,7 @@ func TestSearchArtistSendsRequestWithProperOptions(t *testing.T) {
 	testSender := sender(t)
 	repository := spotifySongRepository(testSender)
 
-	_, err := repository.SearchArtist(testContext(), searchName, limit)
+	_, err := repository.SearchArtist(testContext(), searchName, offset, limit)
 
 	assert.Nil(t, err)
 	assert.Equal(t, searchArtistHttpOptions(), testSender.GetSendArgs())
@@ -103,7 +154,7 @@ func TestSearchArtistReturnsErrorOnWrongKeyType(t *testing.T) {
 	ctx = context.WithValue(ctx, tokenKey, 42)
 	repository := spotifySongRepository(sender(t))
 
-	_, err := repository.SearchArtist(ctx, searchName, limit)
+	_, err := repository.SearchArtist(ctx, searchName, offset, limit)
 
 	assert.NotNil(t, err)
 }
@@ -113,18 +164,29 @@ func TestSearchArtistReturnsErrorOnSendError(t *testing.T) {
 	sender.SetError(errors.New("test error"))
 	repository := spotifySongRepository(sender)
 
-	_, err := repository.SearchArtist(testContext(), searchName, limit)
+	_, err := repository.SearchArtist(testContext(), searchName, offset, limit)
 
 	assert.NotNil(t, err)
 }
 
+func TestSearchArtistKeepsUpstreamAuthError(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	authErr := senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, "")
+	sender.SetError(authErr)
+	repository := spotifySongRepository(sender)
+
+	_, err := repository.SearchArtist(testContext(), searchName, offset, limit)
+
+	assert.Equal(t, authErr, err)
+}
+
 func TestSearchArtistsReturnsErrorOnInvalidBody(t *testing.T) {
 	sender := &httpsender.FakeHTTPSender{}
 	invalidBody := []byte("{some_invalid_json}")
 	sender.SetResponse(&invalidBody)
 	repository := spotifySongRepository(sender)
 
-	_, err := repository.SearchArtist(testContext(), searchName, limit)
+	_, err := repository.SearchArtist(testContext(), searchName, offset, limit)
 
 	assert.NotNil(t, err)
 }
@@ -134,15 +196,194 @@ func TestSearchArtistReturnsEmptyIfNoneFound(t *testing.T) {
 	sender.SetResponse(artistSearchEmptyResponse(t))
 	repository := spotifySongRepository(sender)
 
-	artists, _ := repository.SearchArtist(testContext(), searchName, limit)
+	artists, _ := repository.SearchArtist(testContext(), searchName, offset, limit)
 
-	assert.Equal(t, []artist.Artist{}, artists)
+	assert.Equal(t, pagination.NewPage([]artist.Artist{}, offset, 0), artists)
 }
 
 func TestSearchArtistReturnsArtists(t *testing.T) {
 	repository := spotifySongRepository(sender(t))
 
-	artists, _ := repository.SearchArtist(testContext(), searchName, limit)
+	artists, _ := repository.SearchArtist(testContext(), searchName, offset, limit)
+
+	assert.Equal(t, pagination.NewPage(searchedArtists(), offset, 59), artists)
+}
+
+func relatedArtistsResponse(t *testing.T) *[]byte {
+	t.Helper()
+
+	path := filepath.Join(testtools.GetParentDir(t), "testdata", "spotify_related_artists_response.json")
+	result := testtools.LoadTestDataOrError(t, path)
+	return &result
+}
+
+func relatedArtistsSender(t *testing.T) *httpsender.FakeHTTPSender {
+	sender := &httpsender.FakeHTTPSender{}
+	sender.SetResponse(relatedArtistsResponse(t))
+	return sender
+}
+
+func TestGetRelatedArtistsSendsRequestWithProperOptions(t *testing.T) {
+	testSender := relatedArtistsSender(t)
+	repository := spotifySongRepository(testSender)
+
+	_, err := repository.GetRelatedArtists(testContext(), "3WrFJ7ztbogyGnTHbHJFl2")
+
+	expected := httpsender.NewHTTPRequestOptions(
+		"https://api.spotify.com/v1/artists/3WrFJ7ztbogyGnTHbHJFl2/related-artists",
+		httpsender.GET,
+		200,
+	)
+	expected.SetHeaders(map[string]string{"Authorization": fmt.Sprintf("Bearer %s", authToken)})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, testSender.GetSendArgs())
+}
+
+func TestGetRelatedArtistsReturnsErrorOnMissingToken(t *testing.T) {
+	repository := spotifySongRepository(relatedArtistsSender(t))
+
+	_, err := repository.GetRelatedArtists(context.Background(), "3WrFJ7ztbogyGnTHbHJFl2")
+
+	assert.NotNil(t, err)
+}
+
+func TestGetRelatedArtistsReturnsErrorOnEmptyId(t *testing.T) {
+	repository := spotifySongRepository(relatedArtistsSender(t))
+
+	_, err := repository.GetRelatedArtists(testContext(), "")
+
+	assert.NotNil(t, err)
+}
+
+func TestGetRelatedArtistsReturnsErrorOnSendError(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	sender.SetError(errors.New("test error"))
+	repository := spotifySongRepository(sender)
+
+	_, err := repository.GetRelatedArtists(testContext(), "3WrFJ7ztbogyGnTHbHJFl2")
 
-	assert.Equal(t, artists, searchedArtists())
+	assert.NotNil(t, err)
+}
+
+func TestGetRelatedArtistsReturnsErrorOnInvalidBody(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	invalidBody := []byte("{some_invalid_json}")
+	sender.SetResponse(&invalidBody)
+	repository := spotifySongRepository(sender)
+
+	_, err := repository.GetRelatedArtists(testContext(), "3WrFJ7ztbogyGnTHbHJFl2")
+
+	assert.NotNil(t, err)
+}
+
+func TestGetRelatedArtistsReturnsArtists(t *testing.T) {
+	repository := spotifySongRepository(relatedArtistsSender(t))
+
+	artists, err := repository.GetRelatedArtists(testContext(), "3WrFJ7ztbogyGnTHbHJFl2")
+
+	expected := []artist.Artist{
+		{
+			Id:       "6FBDaR13swtiWwGhX1WQsP",
+			Uri:      "spotify:artist:6FBDaR13swtiWwGhX1WQsP",
+			Name:     "Blink-182",
+			ImageUri: "https://i.scdn.co/image/ab6761610000f1782f8e3b3bd3c2b7f1a8d6b2f6",
+			Images: []artist.Image{
+				{Url: "https://i.scdn.co/image/ab6761610000e5eb2f8e3b3bd3c2b7f1a8d6b2f6", Width: 640, Height: 640},
+				{Url: "https://i.scdn.co/image/ab6761610000f1782f8e3b3bd3c2b7f1a8d6b2f6", Width: 160, Height: 160},
+			},
+			Genres:     []string{"emo", "pop punk"},
+			Popularity: 77,
+			Followers:  1538242,
+		},
+		{
+			Id:         "1LkU3Cd8LdM2MHqdZsXJoC",
+			Uri:        "spotify:artist:1LkU3Cd8LdM2MHqdZsXJoC",
+			Name:       "Knuckle Puck",
+			Images:     []artist.Image{},
+			Genres:     []string{},
+			Popularity: 41,
+			Followers:  20431,
+		},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, artists)
+}
+
+func topTracksSender(t *testing.T) *httpsender.FakeHTTPSender {
+	t.Helper()
+
+	path := filepath.Join(testtools.GetParentDir(t), "testdata", "spotify_top_tracks_response.json")
+	response := testtools.LoadTestDataOrError(t, path)
+	sender := &httpsender.FakeHTTPSender{}
+	sender.SetResponse(&response)
+	return sender
+}
+
+func TestGetTopTracksSendsRequestWithProperOptions(t *testing.T) {
+	testSender := topTracksSender(t)
+	repository := spotifySongRepository(testSender)
+
+	_, err := repository.GetTopTracks(testContext(), "3WrFJ7ztbogyGnTHbHJFl2")
+
+	expected := httpsender.NewHTTPRequestOptions(
+		"https://api.spotify.com/v1/artists/3WrFJ7ztbogyGnTHbHJFl2/top-tracks",
+		httpsender.GET,
+		200,
+	)
+	expected.SetHeaders(map[string]string{"Authorization": fmt.Sprintf("Bearer %s", authToken)})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, testSender.GetSendArgs())
+}
+
+func TestGetTopTracksReturnsError(t *testing.T) {
+	t.Parallel()
+
+	invalidBody := []byte("{some_invalid_json}")
+	tests := map[string]struct {
+		ctx      context.Context
+		artistId string
+		setup    func(sender *httpsender.FakeHTTPSender)
+	}{
+		"missing token": {
+			ctx:      context.Background(),
+			artistId: "3WrFJ7ztbogyGnTHbHJFl2",
+			setup:    func(sender *httpsender.FakeHTTPSender) {},
+		},
+		"empty id": {
+			ctx:      testContext(),
+			artistId: "",
+			setup:    func(sender *httpsender.FakeHTTPSender) {},
+		},
+		"send error": {
+			ctx:      testContext(),
+			artistId: "3WrFJ7ztbogyGnTHbHJFl2",
+			setup:    func(sender *httpsender.FakeHTTPSender) { sender.SetError(errors.New("test error")) },
+		},
+		"invalid body": {
+			ctx:      testContext(),
+			artistId: "3WrFJ7ztbogyGnTHbHJFl2",
+			setup:    func(sender *httpsender.FakeHTTPSender) { sender.SetResponse(&invalidBody) },
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			sender := topTracksSender(t)
+			test.setup(sender)
+			repository := spotifySongRepository(sender)
+
+			_, err := repository.GetTopTracks(test.ctx, test.artistId)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestGetTopTracksReturnsTrackNames(t *testing.T) {
+	repository := spotifySongRepository(topTracksSender(t))
+
+	tracks, err := repository.GetTopTracks(testContext(), "3WrFJ7ztbogyGnTHbHJFl2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Do It Faster", "Very High"}, tracks)
 }
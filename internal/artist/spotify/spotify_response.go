@@ -7,12 +7,23 @@ import (
 )
 
 type spotifyImage struct {
-	Url string `json:"url"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type spotifyFollowers struct {
+	Total int `json:"total"`
 }
 
 type spotifyArtist struct {
-	Name   string         `json:"name"`
-	Images []spotifyImage `json:"images"`
+	Id         string           `json:"id"`
+	Uri        string           `json:"uri"`
+	Name       string           `json:"name"`
+	Images     []spotifyImage   `json:"images"`
+	Genres     []string         `json:"genres"`
+	Popularity int              `json:"popularity"`
+	Followers  spotifyFollowers `json:"followers"`
 }
 
 func (a spotifyArtist) GetSmallestImageUri() (string, error) {
@@ -23,8 +34,28 @@ func (a spotifyArtist) GetSmallestImageUri() (string, error) {
 	return a.Images[nImages-1].Url, nil
 }
 
+func (a spotifyArtist) ToArtist() artist.Artist {
+	images := []artist.Image{}
+	for _, image := range a.Images {
+		images = append(images, artist.Image{Url: image.Url, Width: image.Width, Height: image.Height})
+	}
+
+	result := artist.NewArtist(a.Name)
+	result.Id = a.Id
+	result.Uri = a.Uri
+	result.Images = images
+	result.Genres = append([]string{}, a.Genres...)
+	result.Popularity = a.Popularity
+	result.Followers = a.Followers.Total
+	if imageUri, err := a.GetSmallestImageUri(); err == nil {
+		result.SetImageUri(imageUri)
+	}
+	return result
+}
+
 type spotifyArtists struct {
 	ArtistItems []spotifyArtist `json:"items"`
+	Total       int             `json:"total"`
 }
 
 type spotifyResponse struct {
@@ -34,12 +65,35 @@ type spotifyResponse struct {
 func (s *spotifyResponse) GetArtists() []artist.Artist {
 	result := []artist.Artist{}
 	for _, currentArtist := range s.Artists.ArtistItems {
-		imageUri, err := currentArtist.GetSmallestImageUri()
-		resultArtist := artist.NewArtist(currentArtist.Name)
-		if err == nil {
-			resultArtist.SetImageUri(imageUri)
-		}
-		result = append(result, resultArtist)
+		result = append(result, currentArtist.ToArtist())
+	}
+	return result
+}
+
+type spotifyRelatedArtistsResponse struct {
+	Artists []spotifyArtist `json:"artists"`
+}
+
+func (s *spotifyRelatedArtistsResponse) GetArtists() []artist.Artist {
+	result := []artist.Artist{}
+	for _, currentArtist := range s.Artists {
+		result = append(result, currentArtist.ToArtist())
+	}
+	return result
+}
+
+type spotifyTopTrack struct {
+	Name string `json:"name"`
+}
+
+type spotifyTopTracksResponse struct {
+	Tracks []spotifyTopTrack `json:"tracks"`
+}
+
+func (s *spotifyTopTracksResponse) GetTrackNames() []string {
+	result := []string{}
+	for _, track := range s.Tracks {
+		result = append(result, track.Name)
 	}
 	return result
 }
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+type AppTokenClient interface {
+	ClientCredentials() (Token, error)
+}
+
+// Provides the token of the app, used for requests not acting on behalf of a user
+type AppTokenProvider interface {
+	AccessToken() (string, error)
+}
+
+// Concurrent-safe provider which keeps the token of the app, requesting a new one shortly before it expires
+type RefreshingAppTokenProvider struct {
+	client AppTokenClient
+	mutex  sync.Mutex
+	token  *Token
+	margin time.Duration
+	now    func() time.Time
+}
+
+func NewRefreshingAppTokenProvider(client AppTokenClient) *RefreshingAppTokenProvider {
+	return &RefreshingAppTokenProvider{client: client, margin: time.Minute, now: time.Now}
+}
+
+func (p *RefreshingAppTokenProvider) AccessToken() (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.token != nil && !p.token.ExpiresBefore(p.now().Add(p.margin)) {
+		return p.token.AccessToken, nil
+	}
+
+	// Requests wait for the new token instead of asking for one each
+	token, err := p.client.ClientCredentials()
+	if err != nil {
+		return "", err
+	}
+	p.token = &token
+	return token.AccessToken, nil
+}
+
+func (p *RefreshingAppTokenProvider) SetMargin(margin time.Duration) {
+	p.margin = margin
+}
+
+func (p *RefreshingAppTokenProvider) SetClock(now func() time.Time) {
+	p.now = now
+}
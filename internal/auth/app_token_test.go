@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appTokenSetup() (*RefreshingAppTokenProvider, *FakeAppTokenClient, *time.Time) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := &FakeAppTokenClient{}
+	client.SetResult(TokenResult{Token: Token{AccessToken: "app_access", ExpiresAt: now.Add(time.Hour)}})
+	provider := NewRefreshingAppTokenProvider(client)
+	provider.SetMargin(time.Minute)
+	provider.SetClock(func() time.Time { return now })
+	return provider, client, &now
+}
+
+func TestAppTokenProviderReturnsTokenOfClient(t *testing.T) {
+	provider, _, _ := appTokenSetup()
+
+	actual, err := provider.AccessToken()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "app_access", actual)
+}
+
+func TestAppTokenProviderReusesValidToken(t *testing.T) {
+	provider, client, _ := appTokenSetup()
+
+	provider.AccessToken()
+	provider.AccessToken()
+
+	assert.Equal(t, 1, client.GetNumCalls())
+}
+
+func TestAppTokenProviderRequestsNewTokenBeforeExpiration(t *testing.T) {
+	provider, client, now := appTokenSetup()
+	provider.AccessToken()
+	*now = now.Add(time.Hour - 30*time.Second)
+
+	provider.AccessToken()
+
+	assert.Equal(t, 2, client.GetNumCalls())
+}
+
+func TestAppTokenProviderReturnsClientError(t *testing.T) {
+	provider, client, _ := appTokenSetup()
+	client.SetResult(TokenResult{Err: errors.New("test error")})
+
+	_, err := provider.AccessToken()
+
+	assert.NotNil(t, err)
+}
+
+func TestAppTokenProviderIsConcurrentSafe(t *testing.T) {
+	provider, client, _ := appTokenSetup()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provider.AccessToken()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, client.GetNumCalls())
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"festwrap/internal/cache"
+)
+
+// Keeps the introspection of each token, so the authorization server is not asked on every request.
+// Tokens are stored hashed, so they cannot be leaked from the cache. Introspections are not kept
+// beyond the expiration of their token when it is known, so expired tokens are not taken as active
+type CachedTokenIntrospector struct {
+	introspector   TokenIntrospector
+	introspections cache.Cache[string, Introspection]
+}
+
+func NewCachedTokenIntrospector(
+	introspector TokenIntrospector,
+	introspections cache.Cache[string, Introspection],
+) CachedTokenIntrospector {
+	return CachedTokenIntrospector{introspector: introspector, introspections: introspections}
+}
+
+func (i CachedTokenIntrospector) Introspect(accessToken string) (Introspection, error) {
+	key := HashToken(accessToken)
+	if introspection, ok := i.introspections.Get(key); ok {
+		return introspection, nil
+	}
+
+	// Errors are not cached, since the token may be valid once the failure is solved
+	introspection, err := i.introspector.Introspect(accessToken)
+	if err != nil {
+		return Introspection{}, err
+	}
+	if introspection.ExpiresAt.IsZero() {
+		i.introspections.Set(key, introspection)
+	} else {
+		i.introspections.SetUntil(key, introspection, introspection.ExpiresAt)
+	}
+	return introspection, nil
+}
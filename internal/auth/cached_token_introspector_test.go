@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"festwrap/internal/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func activeIntrospection() Introspection {
+	return Introspection{Active: true, Scopes: []string{"playlist-modify-public"}}
+}
+
+func cachedIntrospectorSetup() (CachedTokenIntrospector, *FakeTokenIntrospector, *cache.MemoryCache[string, Introspection]) {
+	introspector := &FakeTokenIntrospector{}
+	introspector.SetResult(IntrospectionResult{Introspection: activeIntrospection()})
+	introspections := cache.NewMemoryCache[string, Introspection](time.Minute)
+	return NewCachedTokenIntrospector(introspector, introspections), introspector, introspections
+}
+
+func TestCachedIntrospectorReturnsIntrospection(t *testing.T) {
+	cached, _, _ := cachedIntrospectorSetup()
+
+	actual, err := cached.Introspect("some_token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, activeIntrospection(), actual)
+}
+
+func TestCachedIntrospectorReusesIntrospection(t *testing.T) {
+	cached, introspector, _ := cachedIntrospectorSetup()
+
+	cached.Introspect("some_token")
+	cached.Introspect("some_token")
+
+	assert.Equal(t, []string{"some_token"}, introspector.GetIntrospectArgs())
+}
+
+func TestCachedIntrospectorDoesNotStoreRawTokens(t *testing.T) {
+	cached, _, introspections := cachedIntrospectorSetup()
+
+	cached.Introspect("some_token")
+
+	_, found := introspections.Get("some_token")
+	assert.False(t, found)
+	assert.Equal(t, 1, introspections.Len())
+}
+
+func TestCachedIntrospectorDoesNotCacheErrors(t *testing.T) {
+	cached, introspector, introspections := cachedIntrospectorSetup()
+	introspector.SetResult(IntrospectionResult{Err: errors.New("test error")})
+
+	_, err := cached.Introspect("some_token")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, introspections.Len())
+}
+
+func TestCachedIntrospectorDoesNotKeepIntrospectionsBeyondTokenExpiry(t *testing.T) {
+	cached, introspector, introspections := cachedIntrospectorSetup()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	introspections.SetClock(func() time.Time { return now })
+	introspection := activeIntrospection()
+	introspection.ExpiresAt = now.Add(10 * time.Second)
+	introspector.SetResult(IntrospectionResult{Introspection: introspection})
+	cached.Introspect("some_token")
+	now = now.Add(10 * time.Second)
+
+	cached.Introspect("some_token")
+
+	assert.Equal(t, []string{"some_token", "some_token"}, introspector.GetIntrospectArgs())
+}
+
+func TestCachedIntrospectorKeepsIntrospectionsUntilTokenExpiry(t *testing.T) {
+	cached, introspector, introspections := cachedIntrospectorSetup()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	introspections.SetClock(func() time.Time { return now })
+	introspection := activeIntrospection()
+	introspection.ExpiresAt = now.Add(10 * time.Second)
+	introspector.SetResult(IntrospectionResult{Introspection: introspection})
+	cached.Introspect("some_token")
+	now = now.Add(9 * time.Second)
+
+	cached.Introspect("some_token")
+
+	assert.Equal(t, []string{"some_token"}, introspector.GetIntrospectArgs())
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	autherrors "festwrap/internal/auth/errors"
+)
+
+// Keeps values in cookies encrypted with AES-GCM, so clients can neither read nor modify them.
+// The cookie name is authenticated too, so a value cannot be moved into a different cookie
+type CookieStore[T any] struct {
+	name   string
+	aead   cipher.AEAD
+	maxAge time.Duration
+	secure bool
+	now    func() time.Time
+}
+
+// Browsers drop cookies once their max age is reached, but copies of them could be replayed
+// afterwards. The time they were issued at is encrypted along with the value to reject those
+type cookiePayload[T any] struct {
+	IssuedAt time.Time `json:"issuedAt"`
+	Value    T         `json:"value"`
+}
+
+// The key must have 16, 24 or 32 bytes to use AES-128, AES-192 or AES-256
+func NewCookieStore[T any](name string, key []byte, maxAge time.Duration) (CookieStore[T], error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return CookieStore[T]{}, fmt.Errorf("invalid cookie key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return CookieStore[T]{}, fmt.Errorf("could not create cookie cipher: %w", err)
+	}
+	return CookieStore[T]{name: name, aead: aead, maxAge: maxAge, secure: true, now: time.Now}, nil
+}
+
+func (s CookieStore[T]) Get(r *http.Request) (T, error) {
+	var value T
+	cookie, err := r.Cookie(s.name)
+	if err != nil {
+		return value, autherrors.NewSessionNotFoundError(fmt.Sprintf("cookie %s not found", s.name))
+	}
+
+	encrypted, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || len(encrypted) < s.aead.NonceSize() {
+		return value, autherrors.NewSessionNotFoundError(fmt.Sprintf("cookie %s is malformed", s.name))
+	}
+	nonce, ciphertext := encrypted[:s.aead.NonceSize()], encrypted[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, []byte(s.name))
+	if err != nil {
+		return value, autherrors.NewSessionNotFoundError(fmt.Sprintf("cookie %s could not be decrypted", s.name))
+	}
+
+	var payload cookiePayload[T]
+	if err = json.Unmarshal(plaintext, &payload); err != nil {
+		return value, autherrors.NewSessionNotFoundError(fmt.Sprintf("cookie %s has an invalid value", s.name))
+	}
+	if !s.now().Before(payload.IssuedAt.Add(s.maxAge)) {
+		return value, autherrors.NewSessionNotFoundError(fmt.Sprintf("cookie %s has expired", s.name))
+	}
+	return payload.Value, nil
+}
+
+func (s CookieStore[T]) Save(w http.ResponseWriter, value T) error {
+	plaintext, err := json.Marshal(cookiePayload[T]{IssuedAt: s.now(), Value: value})
+	if err != nil {
+		return fmt.Errorf("could not serialize cookie %s: %w", s.name, err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("could not generate nonce for cookie %s: %w", s.name, err)
+	}
+	encrypted := s.aead.Seal(nonce, nonce, plaintext, []byte(s.name))
+
+	cookie := s.cookie(base64.RawURLEncoding.EncodeToString(encrypted))
+	cookie.MaxAge = int(s.maxAge.Seconds())
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+func (s CookieStore[T]) Clear(w http.ResponseWriter) {
+	cookie := s.cookie("")
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+}
+
+// Cookies are only sent over HTTPS by default. This can be disabled to run the server locally
+func (s *CookieStore[T]) SetSecure(secure bool) {
+	s.secure = secure
+}
+
+func (s *CookieStore[T]) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+func (s CookieStore[T]) cookie(value string) *http.Cookie {
+	return &http.Cookie{
+		Name:     s.name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
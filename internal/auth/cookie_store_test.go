@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	autherrors "festwrap/internal/auth/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cookieName = "session"
+
+func cookieKey() []byte {
+	return []byte("0123456789abcdef0123456789abcdef")
+}
+
+func testToken() Token {
+	return Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Scopes:       []string{"playlist-modify-public"},
+	}
+}
+
+func cookieStore(t *testing.T) CookieStore[Token] {
+	store, err := NewCookieStore[Token](cookieName, cookieKey(), time.Hour)
+	if err != nil {
+		t.Fatalf("could not create cookie store: %v", err)
+	}
+	return store
+}
+
+func requestWithCookies(cookies ...*http.Cookie) *http.Request {
+	request := httptest.NewRequest("GET", "https://example.com", nil)
+	for _, cookie := range cookies {
+		request.AddCookie(cookie)
+	}
+	return request
+}
+
+func TestNewCookieStoreReturnsErrorOnInvalidKey(t *testing.T) {
+	_, err := NewCookieStore[Token](cookieName, []byte("short"), time.Hour)
+
+	assert.NotNil(t, err)
+}
+
+func TestCookieStoreReturnsSavedValue(t *testing.T) {
+	store := cookieStore(t)
+	writer := httptest.NewRecorder()
+
+	err := store.Save(writer, testToken())
+	assert.Nil(t, err)
+
+	actual, err := store.Get(requestWithCookies(writer.Result().Cookies()...))
+	assert.Nil(t, err)
+	assert.Equal(t, testToken(), actual)
+}
+
+func TestCookieStoreEncryptsValue(t *testing.T) {
+	store := cookieStore(t)
+	writer := httptest.NewRecorder()
+
+	store.Save(writer, testToken())
+
+	cookie := writer.Result().Cookies()[0]
+	assert.False(t, strings.Contains(cookie.Value, "access"))
+	assert.False(t, strings.Contains(cookie.Value, "refresh"))
+}
+
+func TestCookieStoreSetsCookieAttributes(t *testing.T) {
+	store := cookieStore(t)
+	writer := httptest.NewRecorder()
+
+	store.Save(writer, testToken())
+
+	cookie := writer.Result().Cookies()[0]
+	assert.Equal(t, cookieName, cookie.Name)
+	assert.Equal(t, "/", cookie.Path)
+	assert.Equal(t, 3600, cookie.MaxAge)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+}
+
+func TestCookieStoreCanDisableSecureCookies(t *testing.T) {
+	store := cookieStore(t)
+	store.SetSecure(false)
+	writer := httptest.NewRecorder()
+
+	store.Save(writer, testToken())
+
+	assert.False(t, writer.Result().Cookies()[0].Secure)
+}
+
+func TestCookieStoreReturnsErrorOnInvalidCookies(t *testing.T) {
+	otherStore, _ := NewCookieStore[Token]("other", cookieKey(), time.Hour)
+	otherWriter := httptest.NewRecorder()
+	otherStore.Save(otherWriter, testToken())
+	movedCookie := otherWriter.Result().Cookies()[0]
+	movedCookie.Name = cookieName
+
+	otherKeyStore, _ := NewCookieStore[Token](cookieName, []byte("fedcba9876543210fedcba9876543210"), time.Hour)
+	otherKeyWriter := httptest.NewRecorder()
+	otherKeyStore.Save(otherKeyWriter, testToken())
+
+	tests := map[string]struct {
+		cookies []*http.Cookie
+	}{
+		"missing cookie":        {cookies: nil},
+		"non base64 cookie":     {cookies: []*http.Cookie{{Name: cookieName, Value: "not base64!"}}},
+		"too short cookie":      {cookies: []*http.Cookie{{Name: cookieName, Value: "YWJj"}}},
+		"cookie of other key":   {cookies: otherKeyWriter.Result().Cookies()},
+		"cookie of other store": {cookies: []*http.Cookie{movedCookie}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := cookieStore(t)
+
+			_, err := store.Get(requestWithCookies(test.cookies...))
+
+			var notFoundErr *autherrors.SessionNotFoundError
+			assert.ErrorAs(t, err, &notFoundErr)
+		})
+	}
+}
+
+func TestCookieStoreReturnsErrorOnExpiredCookies(t *testing.T) {
+	tests := map[string]struct {
+		elapsed time.Duration
+	}{
+		"max age reached":  {elapsed: time.Hour},
+		"max age exceeded": {elapsed: 2 * time.Hour},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			store := cookieStore(t)
+			store.SetClock(func() time.Time { return now })
+			writer := httptest.NewRecorder()
+			store.Save(writer, testToken())
+			store.SetClock(func() time.Time { return now.Add(test.elapsed) })
+
+			_, err := store.Get(requestWithCookies(writer.Result().Cookies()...))
+
+			var notFoundErr *autherrors.SessionNotFoundError
+			assert.ErrorAs(t, err, &notFoundErr)
+		})
+	}
+}
+
+func TestCookieStoreReturnsValueBeforeMaxAge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := cookieStore(t)
+	store.SetClock(func() time.Time { return now })
+	writer := httptest.NewRecorder()
+	store.Save(writer, testToken())
+	store.SetClock(func() time.Time { return now.Add(59 * time.Minute) })
+
+	actual, err := store.Get(requestWithCookies(writer.Result().Cookies()...))
+
+	assert.Nil(t, err)
+	assert.Equal(t, testToken(), actual)
+}
+
+func TestCookieStoreClearExpiresCookie(t *testing.T) {
+	store := cookieStore(t)
+	writer := httptest.NewRecorder()
+
+	store.Clear(writer)
+
+	cookie := writer.Result().Cookies()[0]
+	assert.Equal(t, cookieName, cookie.Name)
+	assert.Equal(t, "", cookie.Value)
+	assert.Equal(t, -1, cookie.MaxAge)
+}
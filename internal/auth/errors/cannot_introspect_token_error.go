@@ -0,0 +1,13 @@
+package errors
+
+type CannotIntrospectTokenError struct {
+	message string
+}
+
+func NewCannotIntrospectTokenError(message string) error {
+	return &CannotIntrospectTokenError{message: message}
+}
+
+func (e *CannotIntrospectTokenError) Error() string {
+	return e.message
+}
@@ -0,0 +1,13 @@
+package errors
+
+type CannotRetrieveTokenError struct {
+	message string
+}
+
+func NewCannotRetrieveTokenError(message string) error {
+	return &CannotRetrieveTokenError{message: message}
+}
+
+func (e *CannotRetrieveTokenError) Error() string {
+	return e.message
+}
@@ -0,0 +1,13 @@
+package errors
+
+type InvalidGrantError struct {
+	message string
+}
+
+func NewInvalidGrantError(message string) error {
+	return &InvalidGrantError{message: message}
+}
+
+func (e *InvalidGrantError) Error() string {
+	return e.message
+}
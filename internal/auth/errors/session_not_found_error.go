@@ -0,0 +1,13 @@
+package errors
+
+type SessionNotFoundError struct {
+	message string
+}
+
+func NewSessionNotFoundError(message string) error {
+	return &SessionNotFoundError{message: message}
+}
+
+func (e *SessionNotFoundError) Error() string {
+	return e.message
+}
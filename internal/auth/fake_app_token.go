@@ -0,0 +1,36 @@
+package auth
+
+type FakeAppTokenClient struct {
+	numCalls int
+	result   TokenResult
+}
+
+func (c *FakeAppTokenClient) ClientCredentials() (Token, error) {
+	c.numCalls += 1
+	return c.result.Token, c.result.Err
+}
+
+func (c *FakeAppTokenClient) GetNumCalls() int {
+	return c.numCalls
+}
+
+func (c *FakeAppTokenClient) SetResult(result TokenResult) {
+	c.result = result
+}
+
+type FakeAppTokenProvider struct {
+	token string
+	err   error
+}
+
+func (p *FakeAppTokenProvider) AccessToken() (string, error) {
+	return p.token, p.err
+}
+
+func (p *FakeAppTokenProvider) SetAccessToken(token string) {
+	p.token = token
+}
+
+func (p *FakeAppTokenProvider) SetError(err error) {
+	p.err = err
+}
@@ -0,0 +1,65 @@
+package auth
+
+type ExchangeCodeArgs struct {
+	Code        string
+	Verifier    string
+	RedirectUri string
+}
+
+type TokenResult struct {
+	Token Token
+	Err   error
+}
+
+type AuthorizeUrlArgs struct {
+	State       LoginState
+	RedirectUri string
+}
+
+type FakeOAuthClient struct {
+	authorizeUrlArgs   []AuthorizeUrlArgs
+	authorizeUrl       string
+	exchangeCodeArgs   []ExchangeCodeArgs
+	exchangeCodeResult TokenResult
+	refreshTokenArgs   []string
+	refreshTokenResult TokenResult
+}
+
+func (c *FakeOAuthClient) AuthorizeUrl(state LoginState, redirectUri string) string {
+	c.authorizeUrlArgs = append(c.authorizeUrlArgs, AuthorizeUrlArgs{State: state, RedirectUri: redirectUri})
+	return c.authorizeUrl
+}
+
+func (c *FakeOAuthClient) GetAuthorizeUrlArgs() []AuthorizeUrlArgs {
+	return c.authorizeUrlArgs
+}
+
+func (c *FakeOAuthClient) SetAuthorizeUrl(url string) {
+	c.authorizeUrl = url
+}
+
+func (c *FakeOAuthClient) ExchangeCode(code string, verifier string, redirectUri string) (Token, error) {
+	c.exchangeCodeArgs = append(c.exchangeCodeArgs, ExchangeCodeArgs{Code: code, Verifier: verifier, RedirectUri: redirectUri})
+	return c.exchangeCodeResult.Token, c.exchangeCodeResult.Err
+}
+
+func (c *FakeOAuthClient) RefreshToken(refreshToken string) (Token, error) {
+	c.refreshTokenArgs = append(c.refreshTokenArgs, refreshToken)
+	return c.refreshTokenResult.Token, c.refreshTokenResult.Err
+}
+
+func (c *FakeOAuthClient) GetExchangeCodeArgs() []ExchangeCodeArgs {
+	return c.exchangeCodeArgs
+}
+
+func (c *FakeOAuthClient) SetExchangeCodeResult(result TokenResult) {
+	c.exchangeCodeResult = result
+}
+
+func (c *FakeOAuthClient) GetRefreshTokenArgs() []string {
+	return c.refreshTokenArgs
+}
+
+func (c *FakeOAuthClient) SetRefreshTokenResult(result TokenResult) {
+	c.refreshTokenResult = result
+}
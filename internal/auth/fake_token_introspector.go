@@ -0,0 +1,24 @@
+package auth
+
+type IntrospectionResult struct {
+	Introspection Introspection
+	Err           error
+}
+
+type FakeTokenIntrospector struct {
+	introspectArgs []string
+	result         IntrospectionResult
+}
+
+func (i *FakeTokenIntrospector) Introspect(accessToken string) (Introspection, error) {
+	i.introspectArgs = append(i.introspectArgs, accessToken)
+	return i.result.Introspection, i.result.Err
+}
+
+func (i *FakeTokenIntrospector) GetIntrospectArgs() []string {
+	return i.introspectArgs
+}
+
+func (i *FakeTokenIntrospector) SetResult(result IntrospectionResult) {
+	i.result = result
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"time"
+
+	autherrors "festwrap/internal/auth/errors"
+	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/serialization"
+)
+
+type introspectionResponse struct {
+	Active bool   `json:"active"`
+	Scope  string `json:"scope"`
+	// Seconds since the epoch when the token expires
+	Exp int64 `json:"exp"`
+}
+
+// Asks an authorization server supporting token introspection (RFC 7662) about the tokens. Spotify
+// does not provide it, so this is only useful with authorization servers standing in for it
+type HTTPTokenIntrospector struct {
+	introspectionUrl string
+	clientId         string
+	clientSecret     string
+	deserializer     serialization.Deserializer[introspectionResponse]
+	httpSender       httpsender.HTTPRequestSender
+}
+
+func NewHTTPTokenIntrospector(introspectionUrl string, httpSender httpsender.HTTPRequestSender) HTTPTokenIntrospector {
+	return HTTPTokenIntrospector{
+		introspectionUrl: introspectionUrl,
+		deserializer:     serialization.NewJsonDeserializer[introspectionResponse](),
+		httpSender:       httpSender,
+	}
+}
+
+func (i HTTPTokenIntrospector) Introspect(accessToken string) (Introspection, error) {
+	params := url.Values{}
+	params.Set("token", accessToken)
+	params.Set("token_type_hint", "access_token")
+	httpOptions := httpsender.NewHTTPRequestOptions(i.introspectionUrl, httpsender.POST, 200)
+	headers := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
+	if i.clientId != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", i.clientId, i.clientSecret)))
+		headers["Authorization"] = fmt.Sprintf("Basic %s", credentials)
+	}
+	httpOptions.SetHeaders(headers)
+	httpOptions.SetBody([]byte(params.Encode()))
+
+	responseBody, err := i.httpSender.Send(httpOptions)
+	if err != nil {
+		return Introspection{}, autherrors.NewCannotIntrospectTokenError(err.Error())
+	}
+
+	var response introspectionResponse
+	if err = i.deserializer.Deserialize(*responseBody, &response); err != nil {
+		return Introspection{}, autherrors.NewCannotIntrospectTokenError(fmt.Sprintf("could not deserialize introspection: %v", err))
+	}
+	introspection := Introspection{Active: response.Active, Scopes: ParseScopes(response.Scope)}
+	if response.Exp > 0 {
+		introspection.ExpiresAt = time.Unix(response.Exp, 0).UTC()
+	}
+	return introspection, nil
+}
+
+// Credentials used to authenticate against the introspection endpoint, if it requires them
+func (i *HTTPTokenIntrospector) SetClientCredentials(clientId string, clientSecret string) {
+	i.clientId = clientId
+	i.clientSecret = clientSecret
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	autherrors "festwrap/internal/auth/errors"
+	httpclient "festwrap/internal/http/client"
+	httpsender "festwrap/internal/http/sender"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Local stand-in of an authorization server, which only knows about the active token
+func fakeIntrospectionServer(t *testing.T) *httptest.Server {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "some_client" || secret != "some_secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Form.Get("token") == "active_token" {
+			w.Write([]byte(`{"active":true,"scope":"playlist-modify-public playlist-read-private","exp":1748779200}`))
+		} else if r.Form.Get("token") == "unscoped_token" {
+			w.Write([]byte(`{"active":true}`))
+		} else {
+			w.Write([]byte(`{"active":false}`))
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func introspector(t *testing.T) HTTPTokenIntrospector {
+	server := fakeIntrospectionServer(t)
+	baseClient := httpclient.NewBaseHTTPClient(server.Client())
+	sender := httpsender.NewBaseHTTPRequestSender(&baseClient)
+	introspector := NewHTTPTokenIntrospector(server.URL, &sender)
+	introspector.SetClientCredentials("some_client", "some_secret")
+	return introspector
+}
+
+func TestIntrospectReturnsScopesOfActiveToken(t *testing.T) {
+	introspector := introspector(t)
+
+	actual, err := introspector.Introspect("active_token")
+
+	assert.Nil(t, err)
+	expected := Introspection{
+		Active:    true,
+		Scopes:    []string{"playlist-modify-public", "playlist-read-private"},
+		ExpiresAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestIntrospectReturnsUnknownScopesIfNotIncluded(t *testing.T) {
+	introspector := introspector(t)
+
+	actual, err := introspector.Introspect("unscoped_token")
+
+	assert.Nil(t, err)
+	assert.True(t, actual.Active)
+	assert.Nil(t, actual.Scopes)
+}
+
+func TestIntrospectReturnsInactiveToken(t *testing.T) {
+	introspector := introspector(t)
+
+	actual, err := introspector.Introspect("other_token")
+
+	assert.Nil(t, err)
+	assert.False(t, actual.Active)
+	assert.True(t, actual.ExpiresAt.IsZero())
+}
+
+func TestIntrospectReturnsErrorOnRejectedCredentials(t *testing.T) {
+	introspector := introspector(t)
+	introspector.SetClientCredentials("some_client", "other_secret")
+
+	_, err := introspector.Introspect("active_token")
+
+	var introspectErr *autherrors.CannotIntrospectTokenError
+	assert.ErrorAs(t, err, &introspectErr)
+}
+
+func TestIntrospectReturnsErrorOnInvalidResponse(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	response := []byte("{non_json")
+	sender.SetResponse(&response)
+	introspector := NewHTTPTokenIntrospector("https://example.com/introspect", sender)
+
+	_, err := introspector.Introspect("active_token")
+
+	var introspectErr *autherrors.CannotIntrospectTokenError
+	assert.ErrorAs(t, err, &introspectErr)
+}
+
+func TestIntrospectReturnsErrorOnSendError(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	sender.SetError(errors.New("test error"))
+	introspector := NewHTTPTokenIntrospector("https://example.com/introspect", sender)
+
+	_, err := introspector.Introspect("active_token")
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Data kept between the login and the callback. The state protects the callback against forged
+// requests and the verifier proves the code is exchanged by whoever started the login (PKCE)
+type LoginState struct {
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+}
+
+func NewLoginState() (LoginState, error) {
+	state, err := randomString(16)
+	if err != nil {
+		return LoginState{}, err
+	}
+	verifier, err := randomString(32)
+	if err != nil {
+		return LoginState{}, err
+	}
+	return LoginState{State: state, Verifier: verifier}, nil
+}
+
+// Challenge sent to the authorization server, using the S256 method
+func (s LoginState) CodeChallenge() string {
+	hash := sha256.Sum256([]byte(s.Verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func randomString(numBytes int) (string, error) {
+	bytes := make([]byte, numBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoginStateGeneratesRandomValues(t *testing.T) {
+	first, err := NewLoginState()
+	assert.Nil(t, err)
+	second, err := NewLoginState()
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, first.State, second.State)
+	assert.NotEqual(t, first.Verifier, second.Verifier)
+}
+
+func TestNewLoginStateVerifierHasValidLength(t *testing.T) {
+	state, _ := NewLoginState()
+
+	assert.GreaterOrEqual(t, len(state.Verifier), 43)
+	assert.LessOrEqual(t, len(state.Verifier), 128)
+}
+
+func TestCodeChallengeUsesS256(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	state := LoginState{Verifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"}
+
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", state.CodeChallenge())
+}
@@ -0,0 +1,8 @@
+package auth
+
+// Client of the authorization server, following the authorization code flow with PKCE
+type OAuthClient interface {
+	AuthorizeUrl(state LoginState, redirectUri string) string
+	ExchangeCode(code string, verifier string, redirectUri string) (Token, error)
+	RefreshToken(refreshToken string) (Token, error)
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+// Parses the space-separated scopes of a token response (RFC 6749) or introspection (RFC 7662).
+// Both allow leaving them out, so missing scopes are returned as nil, meaning they are unknown
+func ParseScopes(scope string) []string {
+	scopes := strings.Fields(scope)
+	if len(scopes) == 0 {
+		return nil
+	}
+	return scopes
+}
+
+// Returns the required scopes which were not granted, keeping their order
+func MissingScopes(granted []string, required []string) []string {
+	missing := []string{}
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// Key to store data about a token without keeping the token itself
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingScopes(t *testing.T) {
+	tests := map[string]struct {
+		granted  []string
+		required []string
+		expected []string
+	}{
+		"all granted": {
+			granted:  []string{"playlist-modify-public", "playlist-modify-private"},
+			required: []string{"playlist-modify-private"},
+			expected: []string{},
+		},
+		"some missing": {
+			granted:  []string{"playlist-modify-public"},
+			required: []string{"playlist-modify-public", "playlist-modify-private"},
+			expected: []string{"playlist-modify-private"},
+		},
+		"none granted": {
+			granted:  nil,
+			required: []string{"playlist-modify-public", "playlist-modify-private"},
+			expected: []string{"playlist-modify-public", "playlist-modify-private"},
+		},
+		"none required": {
+			granted:  []string{"playlist-modify-public"},
+			required: nil,
+			expected: []string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, MissingScopes(test.granted, test.required))
+		})
+	}
+}
+
+func TestHashTokenDoesNotContainToken(t *testing.T) {
+	actual := HashToken("some_token")
+
+	assert.NotContains(t, actual, "some_token")
+	assert.Equal(t, HashToken("some_token"), actual)
+	assert.NotEqual(t, HashToken("other_token"), actual)
+}
+
+func TestParseScopes(t *testing.T) {
+	tests := map[string]struct {
+		scope    string
+		expected []string
+	}{
+		"several scopes": {
+			scope:    "playlist-modify-public  playlist-modify-private",
+			expected: []string{"playlist-modify-public", "playlist-modify-private"},
+		},
+		"missing scopes": {
+			scope:    "",
+			expected: nil,
+		},
+		"blank scopes": {
+			scope:    " ",
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, ParseScopes(test.scope))
+		})
+	}
+}
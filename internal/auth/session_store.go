@@ -0,0 +1,17 @@
+package auth
+
+import "net/http"
+
+// Keeps the token of the user between requests
+type SessionStore interface {
+	Get(r *http.Request) (Token, error)
+	Save(w http.ResponseWriter, token Token) error
+	Clear(w http.ResponseWriter)
+}
+
+// Keeps the login state between the login and the callback requests
+type LoginStateStore interface {
+	Get(r *http.Request) (LoginState, error)
+	Save(w http.ResponseWriter, state LoginState) error
+	Clear(w http.ResponseWriter)
+}
@@ -0,0 +1,147 @@
+package spotify
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
+	"festwrap/internal/serialization"
+)
+
+const (
+	PlaylistReadPrivateScope   = "playlist-read-private"
+	PlaylistModifyPublicScope  = "playlist-modify-public"
+	PlaylistModifyPrivateScope = "playlist-modify-private"
+	UgcImageUploadScope        = "ugc-image-upload"
+)
+
+// Permissions needed to change the playlists of the user, whether they are public or not
+var PlaylistModifyScopes = []string{PlaylistModifyPublicScope, PlaylistModifyPrivateScope}
+
+// Permissions needed to search and update the playlists of the user
+var DefaultScopes = []string{
+	PlaylistReadPrivateScope,
+	PlaylistModifyPublicScope,
+	PlaylistModifyPrivateScope,
+	UgcImageUploadScope,
+}
+
+// Authorizes users in Spotify using the authorization code flow with PKCE, which does not need a client secret.
+// The secret is only used to obtain tokens for the app itself, through the client credentials flow
+type SpotifyOAuthClient struct {
+	clientId     string
+	clientSecret string
+	accountsUrl  string
+	scopes       []string
+	deserializer serialization.Deserializer[spotifyTokenResponse]
+	httpSender   httpsender.HTTPRequestSender
+	now          func() time.Time
+}
+
+func NewSpotifyOAuthClient(clientId string, httpSender httpsender.HTTPRequestSender) SpotifyOAuthClient {
+	return SpotifyOAuthClient{
+		clientId:     clientId,
+		accountsUrl:  "https://accounts.spotify.com",
+		scopes:       DefaultScopes,
+		deserializer: serialization.NewJsonDeserializer[spotifyTokenResponse](),
+		httpSender:   httpSender,
+		now:          time.Now,
+	}
+}
+
+func (c SpotifyOAuthClient) AuthorizeUrl(state auth.LoginState, redirectUri string) string {
+	params := url.Values{}
+	params.Set("client_id", c.clientId)
+	params.Set("response_type", "code")
+	params.Set("redirect_uri", redirectUri)
+	params.Set("state", state.State)
+	params.Set("scope", strings.Join(c.scopes, " "))
+	params.Set("code_challenge_method", "S256")
+	params.Set("code_challenge", state.CodeChallenge())
+	return fmt.Sprintf("%s/authorize?%s", c.accountsUrl, params.Encode())
+}
+
+func (c SpotifyOAuthClient) ExchangeCode(code string, verifier string, redirectUri string) (auth.Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	params.Set("redirect_uri", redirectUri)
+	params.Set("client_id", c.clientId)
+	params.Set("code_verifier", verifier)
+	return c.requestToken(params, map[string]string{})
+}
+
+func (c SpotifyOAuthClient) RefreshToken(refreshToken string) (auth.Token, error) {
+	if refreshToken == "" {
+		return auth.Token{}, autherrors.NewInvalidGrantError("no refresh token provided")
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", "refresh_token")
+	params.Set("refresh_token", refreshToken)
+	params.Set("client_id", c.clientId)
+	return c.requestToken(params, map[string]string{})
+}
+
+// Returns a token of the app, which can only access public data of the catalog
+func (c SpotifyOAuthClient) ClientCredentials() (auth.Token, error) {
+	if c.clientSecret == "" {
+		return auth.Token{}, autherrors.NewCannotRetrieveTokenError("no client secret configured")
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", "client_credentials")
+	credentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", c.clientId, c.clientSecret)))
+	return c.requestToken(params, map[string]string{"Authorization": fmt.Sprintf("Basic %s", credentials)})
+}
+
+func (c SpotifyOAuthClient) requestToken(params url.Values, headers map[string]string) (auth.Token, error) {
+	httpOptions := httpsender.NewHTTPRequestOptions(fmt.Sprintf("%s/api/token", c.accountsUrl), httpsender.POST, 200)
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+	httpOptions.SetHeaders(headers)
+	httpOptions.SetBody([]byte(params.Encode()))
+
+	responseBody, err := c.httpSender.Send(httpOptions)
+	var upstreamErr *senderrors.UpstreamError
+	if errors.As(err, &upstreamErr) && upstreamErr.StatusCode() == http.StatusBadRequest {
+		// Spotify answers bad request when the code or the refresh token are not valid anymore
+		return auth.Token{}, autherrors.NewInvalidGrantError(err.Error())
+	} else if err != nil {
+		return auth.Token{}, autherrors.NewCannotRetrieveTokenError(err.Error())
+	}
+
+	var response spotifyTokenResponse
+	if err = c.deserializer.Deserialize(*responseBody, &response); err != nil {
+		return auth.Token{}, autherrors.NewCannotRetrieveTokenError(fmt.Sprintf("could not deserialize token: %v", err))
+	}
+	if response.AccessToken == "" {
+		return auth.Token{}, autherrors.NewCannotRetrieveTokenError("no access token in response")
+	}
+
+	return response.toToken(c.now()), nil
+}
+
+// Allows using a different authorization server, like a local one for testing
+func (c *SpotifyOAuthClient) SetAccountsUrl(accountsUrl string) {
+	c.accountsUrl = strings.TrimSuffix(accountsUrl, "/")
+}
+
+func (c *SpotifyOAuthClient) SetClientSecret(clientSecret string) {
+	c.clientSecret = clientSecret
+}
+
+func (c *SpotifyOAuthClient) SetScopes(scopes []string) {
+	c.scopes = scopes
+}
+
+func (c *SpotifyOAuthClient) SetClock(now func() time.Time) {
+	c.now = now
+}
@@ -0,0 +1,242 @@
+package spotify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"festwrap/internal/auth"
+	autherrors "festwrap/internal/auth/errors"
+	httpclient "festwrap/internal/http/client"
+	httpsender "festwrap/internal/http/sender"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	clientId     = "some_client"
+	redirectUri  = "https://festwrap.com/auth/callback"
+	validCode    = "some_code"
+	validRefresh = "some_refresh"
+	clientSecret = "some_secret"
+)
+
+func loginState() auth.LoginState {
+	return auth.LoginState{State: "some_state", Verifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"}
+}
+
+func now() time.Time {
+	return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// Local stand-in of the Spotify token endpoint, which only accepts the code issued for the
+// challenge of the test login state, the refresh token and the client credentials above
+func fakeTokenServer(t *testing.T) *httptest.Server {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/token" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("grant_type") != "client_credentials" && r.Form.Get("client_id") != clientId {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		validRequest := false
+		switch r.Form.Get("grant_type") {
+		case "client_credentials":
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != clientId || secret != clientSecret {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"app_access","token_type":"Bearer","expires_in":3600}`))
+			return
+		case "authorization_code":
+			verifier := auth.LoginState{Verifier: r.Form.Get("code_verifier")}
+			validRequest = r.Form.Get("code") == validCode &&
+				r.Form.Get("redirect_uri") == redirectUri &&
+				verifier.CodeChallenge() == loginState().CodeChallenge()
+		case "refresh_token":
+			validRequest = r.Form.Get("refresh_token") == validRefresh
+		}
+		if !validRequest {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(
+			`{"access_token":"new_access","token_type":"Bearer","scope":"playlist-modify-public playlist-modify-private",` +
+				`"expires_in":3600,"refresh_token":"new_refresh"}`,
+		))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func oauthClient(t *testing.T) SpotifyOAuthClient {
+	server := fakeTokenServer(t)
+	baseClient := httpclient.NewBaseHTTPClient(server.Client())
+	sender := httpsender.NewBaseHTTPRequestSender(&baseClient)
+	client := NewSpotifyOAuthClient(clientId, &sender)
+	client.SetAccountsUrl(server.URL)
+	client.SetClock(now)
+	return client
+}
+
+func expectedToken() auth.Token {
+	return auth.Token{
+		AccessToken:  "new_access",
+		RefreshToken: "new_refresh",
+		ExpiresAt:    now().Add(time.Hour),
+		Scopes:       []string{"playlist-modify-public", "playlist-modify-private"},
+	}
+}
+
+func TestAuthorizeUrlIncludesPKCEChallenge(t *testing.T) {
+	client := NewSpotifyOAuthClient(clientId, &httpsender.FakeHTTPSender{})
+	client.SetScopes([]string{"playlist-modify-public", "playlist-modify-private"})
+
+	actual, err := url.Parse(client.AuthorizeUrl(loginState(), redirectUri))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://accounts.spotify.com/authorize", strings.Split(actual.String(), "?")[0])
+	expected := url.Values{
+		"client_id":             {clientId},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirectUri},
+		"state":                 {"some_state"},
+		"scope":                 {"playlist-modify-public playlist-modify-private"},
+		"code_challenge_method": {"S256"},
+		"code_challenge":        {"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"},
+	}
+	assert.Equal(t, expected, actual.Query())
+}
+
+func TestExchangeCodeReturnsToken(t *testing.T) {
+	client := oauthClient(t)
+
+	actual, err := client.ExchangeCode(validCode, loginState().Verifier, redirectUri)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedToken(), actual)
+}
+
+func TestExchangeCodeReturnsInvalidGrantOnRejectedRequests(t *testing.T) {
+	tests := map[string]struct {
+		code        string
+		verifier    string
+		redirectUri string
+	}{
+		"invalid code":         {code: "other_code", verifier: loginState().Verifier, redirectUri: redirectUri},
+		"invalid verifier":     {code: validCode, verifier: "other_verifier", redirectUri: redirectUri},
+		"invalid redirect uri": {code: validCode, verifier: loginState().Verifier, redirectUri: "https://other.com"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := oauthClient(t)
+
+			_, err := client.ExchangeCode(test.code, test.verifier, test.redirectUri)
+
+			var grantErr *autherrors.InvalidGrantError
+			assert.ErrorAs(t, err, &grantErr)
+		})
+	}
+}
+
+func TestExchangeCodeReturnsErrorOnUnavailableServer(t *testing.T) {
+	client := oauthClient(t)
+	client.SetAccountsUrl("http://127.0.0.1:1")
+
+	_, err := client.ExchangeCode(validCode, loginState().Verifier, redirectUri)
+
+	var tokenErr *autherrors.CannotRetrieveTokenError
+	assert.ErrorAs(t, err, &tokenErr)
+}
+
+func TestExchangeCodeReturnsErrorOnInvalidResponse(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	response := []byte(`{"token_type":"Bearer"}`)
+	sender.SetResponse(&response)
+	client := NewSpotifyOAuthClient(clientId, sender)
+
+	_, err := client.ExchangeCode(validCode, loginState().Verifier, redirectUri)
+
+	var tokenErr *autherrors.CannotRetrieveTokenError
+	assert.ErrorAs(t, err, &tokenErr)
+}
+
+func TestRefreshTokenReturnsToken(t *testing.T) {
+	client := oauthClient(t)
+
+	actual, err := client.RefreshToken(validRefresh)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedToken(), actual)
+}
+
+func TestRefreshTokenReturnsInvalidGrantOnRejectedToken(t *testing.T) {
+	tests := map[string]struct {
+		refreshToken string
+	}{
+		"invalid refresh token": {refreshToken: "other_refresh"},
+		"empty refresh token":   {refreshToken: ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := oauthClient(t)
+
+			_, err := client.RefreshToken(test.refreshToken)
+
+			var grantErr *autherrors.InvalidGrantError
+			assert.ErrorAs(t, err, &grantErr)
+		})
+	}
+}
+
+func TestClientCredentialsReturnsAppToken(t *testing.T) {
+	client := oauthClient(t)
+	client.SetClientSecret(clientSecret)
+
+	actual, err := client.ClientCredentials()
+
+	assert.Nil(t, err)
+	assert.Equal(t, auth.Token{AccessToken: "app_access", ExpiresAt: now().Add(time.Hour)}, actual)
+}
+
+func TestClientCredentialsReturnsErrorOnRejectedCredentials(t *testing.T) {
+	tests := map[string]struct {
+		secret string
+	}{
+		"invalid secret": {secret: "other_secret"},
+		"missing secret": {secret: ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := oauthClient(t)
+			client.SetClientSecret(test.secret)
+
+			_, err := client.ClientCredentials()
+
+			var tokenErr *autherrors.CannotRetrieveTokenError
+			assert.ErrorAs(t, err, &tokenErr)
+		})
+	}
+}
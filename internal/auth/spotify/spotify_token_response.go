@@ -0,0 +1,24 @@
+package spotify
+
+import (
+	"time"
+
+	"festwrap/internal/auth"
+)
+
+type spotifyTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (r spotifyTokenResponse) toToken(now time.Time) auth.Token {
+	return auth.Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		ExpiresAt:    now.Add(time.Duration(r.ExpiresIn) * time.Second),
+		Scopes:       auth.ParseScopes(r.Scope),
+	}
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "time"
+
+// Spotify credentials of a user, as granted by the token endpoint
+type Token struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Scopes       []string  `json:"scopes,omitempty"`
+}
+
+func (t Token) ExpiresBefore(instant time.Time) bool {
+	return !t.ExpiresAt.After(instant)
+}
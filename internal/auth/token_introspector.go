@@ -0,0 +1,15 @@
+package auth
+
+import "time"
+
+// Details of a token given by the authorization server
+type Introspection struct {
+	Active bool
+	Scopes []string
+	// Zero if the authorization server does not tell
+	ExpiresAt time.Time
+}
+
+type TokenIntrospector interface {
+	Introspect(accessToken string) (Introspection, error)
+}
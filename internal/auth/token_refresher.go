@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"time"
+)
+
+// Refreshes tokens shortly before they expire, so they do not expire while a request is processed
+type TokenRefresher struct {
+	client OAuthClient
+	margin time.Duration
+	now    func() time.Time
+}
+
+func NewTokenRefresher(client OAuthClient) TokenRefresher {
+	return TokenRefresher{client: client, margin: time.Minute, now: time.Now}
+}
+
+func (r TokenRefresher) Refresh(token Token) (Token, error) {
+	refreshed, err := r.client.RefreshToken(token.RefreshToken)
+	if err != nil {
+		return Token{}, err
+	}
+	// Refresh tokens may not be rotated, in which case the current one is still valid
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	// Scopes may be left out when they did not change (RFC 6749 section 5.1)
+	if refreshed.Scopes == nil {
+		refreshed.Scopes = token.Scopes
+	}
+	return refreshed, nil
+}
+
+// Returns the token refreshed if it is about to expire, reporting whether it was
+func (r TokenRefresher) RefreshIfExpiring(token Token) (Token, bool, error) {
+	if !token.ExpiresBefore(r.now().Add(r.margin)) {
+		return token, false, nil
+	}
+	refreshed, err := r.Refresh(token)
+	if err != nil {
+		return Token{}, false, err
+	}
+	return refreshed, true, nil
+}
+
+// Reports whether the token can no longer be used, as opposed to being about to expire
+func (r TokenRefresher) IsExpired(token Token) bool {
+	return token.ExpiresBefore(r.now())
+}
+
+func (r *TokenRefresher) SetMargin(margin time.Duration) {
+	r.margin = margin
+}
+
+func (r *TokenRefresher) SetClock(now func() time.Time) {
+	r.now = now
+}
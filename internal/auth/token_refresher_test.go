@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func refresherSetup() (TokenRefresher, *FakeOAuthClient) {
+	client := &FakeOAuthClient{}
+	refreshed := Token{AccessToken: "new_access", RefreshToken: "new_refresh", ExpiresAt: testToken().ExpiresAt.Add(time.Hour)}
+	client.SetRefreshTokenResult(TokenResult{Token: refreshed})
+	refresher := NewTokenRefresher(client)
+	refresher.SetMargin(time.Minute)
+	return refresher, client
+}
+
+func clockAt(instant time.Time) func() time.Time {
+	return func() time.Time { return instant }
+}
+
+func TestRefreshUsesRefreshToken(t *testing.T) {
+	refresher, client := refresherSetup()
+
+	refresher.Refresh(testToken())
+
+	assert.Equal(t, []string{"refresh"}, client.GetRefreshTokenArgs())
+}
+
+func TestRefreshReturnsRefreshedToken(t *testing.T) {
+	refresher, _ := refresherSetup()
+
+	actual, err := refresher.Refresh(testToken())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "new_access", actual.AccessToken)
+	assert.Equal(t, "new_refresh", actual.RefreshToken)
+}
+
+func TestRefreshKeepsRefreshTokenIfNotRotated(t *testing.T) {
+	refresher, client := refresherSetup()
+	client.SetRefreshTokenResult(TokenResult{Token: Token{AccessToken: "new_access"}})
+
+	actual, _ := refresher.Refresh(testToken())
+
+	assert.Equal(t, "refresh", actual.RefreshToken)
+}
+
+func TestRefreshKeepsScopesIfNotIncluded(t *testing.T) {
+	refresher, client := refresherSetup()
+	client.SetRefreshTokenResult(TokenResult{Token: Token{AccessToken: "new_access"}})
+
+	actual, _ := refresher.Refresh(testToken())
+
+	assert.Equal(t, testToken().Scopes, actual.Scopes)
+}
+
+func TestRefreshReturnsGrantedScopesIfIncluded(t *testing.T) {
+	refresher, client := refresherSetup()
+	client.SetRefreshTokenResult(TokenResult{Token: Token{AccessToken: "new_access", Scopes: []string{"user-read-private"}}})
+
+	actual, _ := refresher.Refresh(testToken())
+
+	assert.Equal(t, []string{"user-read-private"}, actual.Scopes)
+}
+
+func TestRefreshReturnsClientError(t *testing.T) {
+	refresher, client := refresherSetup()
+	client.SetRefreshTokenResult(TokenResult{Err: errors.New("test error")})
+
+	_, err := refresher.Refresh(testToken())
+
+	assert.NotNil(t, err)
+}
+
+func TestRefreshIfExpiring(t *testing.T) {
+	expiresAt := testToken().ExpiresAt
+	tests := map[string]struct {
+		now               time.Time
+		expectedRefreshed bool
+		expectedAccess    string
+	}{
+		"valid token": {
+			now:               expiresAt.Add(-time.Hour),
+			expectedRefreshed: false,
+			expectedAccess:    "access",
+		},
+		"token expiring within margin": {
+			now:               expiresAt.Add(-30 * time.Second),
+			expectedRefreshed: true,
+			expectedAccess:    "new_access",
+		},
+		"expired token": {
+			now:               expiresAt.Add(time.Hour),
+			expectedRefreshed: true,
+			expectedAccess:    "new_access",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			refresher, _ := refresherSetup()
+			refresher.SetClock(clockAt(test.now))
+
+			actual, refreshed, err := refresher.RefreshIfExpiring(testToken())
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedRefreshed, refreshed)
+			assert.Equal(t, test.expectedAccess, actual.AccessToken)
+		})
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	tests := map[string]struct {
+		now      time.Time
+		expected bool
+	}{
+		"before expiration": {now: testToken().ExpiresAt.Add(-time.Second), expected: false},
+		"at expiration":     {now: testToken().ExpiresAt, expected: true},
+		"after expiration":  {now: testToken().ExpiresAt.Add(time.Second), expected: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			refresher, _ := refresherSetup()
+			refresher.SetClock(clockAt(test.now))
+
+			assert.Equal(t, test.expected, refresher.IsExpired(testToken()))
+		})
+	}
+}
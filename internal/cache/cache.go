@@ -0,0 +1,11 @@
+package cache
+
+import "time"
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	// Sets the value so it expires at the given time if that happens before its usual expiration
+	SetUntil(key K, value V, expiresAt time.Time)
+	Delete(key K)
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryCacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Concurrent-safe in-memory cache where entries expire after a fixed time since they were set.
+// If a maximum size is set, the entries closest to expire are evicted to make room for new ones
+type MemoryCache[K comparable, V any] struct {
+	mutex   sync.Mutex
+	entries map[K]memoryCacheEntry[V]
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+}
+
+func NewMemoryCache[K comparable, V any](ttl time.Duration) *MemoryCache[K, V] {
+	return &MemoryCache[K, V]{
+		entries: map[K]memoryCacheEntry[V]{},
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+func (c *MemoryCache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || c.isExpired(entry) {
+		delete(c.entries, key)
+		var empty V
+		return empty, false
+	}
+	return entry.value, true
+}
+
+func (c *MemoryCache[K, V]) Set(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.set(key, value, c.now().Add(c.ttl))
+}
+
+func (c *MemoryCache[K, V]) SetUntil(key K, value V, expiresAt time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.set(key, value, earliest(c.now().Add(c.ttl), expiresAt))
+}
+
+func (c *MemoryCache[K, V]) Delete(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *MemoryCache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// Limits the number of entries kept. Zero, the default, means no limit
+func (c *MemoryCache[K, V]) SetMaxSize(maxSize int) {
+	c.maxSize = maxSize
+}
+
+func (c *MemoryCache[K, V]) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+func (c *MemoryCache[K, V]) isExpired(entry memoryCacheEntry[V]) bool {
+	return !c.now().Before(entry.expiresAt)
+}
+
+func (c *MemoryCache[K, V]) removeExpired() {
+	for key, entry := range c.entries {
+		if c.isExpired(entry) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *MemoryCache[K, V]) set(key K, value V, expiresAt time.Time) {
+	c.removeExpired()
+	if _, ok := c.entries[key]; !ok && c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		c.evictOldest()
+	}
+	c.entries[key] = memoryCacheEntry[V]{value: value, expiresAt: expiresAt}
+}
+
+// Evicts the entry closest to expire, which is the least recently set unless its expiration was capped
+func (c *MemoryCache[K, V]) evictOldest() {
+	var oldestKey K
+	var oldest *memoryCacheEntry[V]
+	for key, entry := range c.entries {
+		if oldest == nil || entry.expiresAt.Before(oldest.expiresAt) {
+			oldestKey = key
+			oldest = &entry
+		}
+	}
+	if oldest != nil {
+		delete(c.entries, oldestKey)
+	}
+}
+
+func earliest(first time.Time, second time.Time) time.Time {
+	if second.Before(first) {
+		return second
+	}
+	return first
+}
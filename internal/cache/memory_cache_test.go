@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ttl = time.Minute
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.current
+}
+
+func (c *fakeClock) Advance(duration time.Duration) {
+	c.current = c.current.Add(duration)
+}
+
+func cacheSetup() (*MemoryCache[string, int], *fakeClock) {
+	clock := &fakeClock{current: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	cache := NewMemoryCache[string, int](ttl)
+	cache.SetClock(clock.Now)
+	return cache, clock
+}
+
+func TestGetReturnsFalseIfKeyNotFound(t *testing.T) {
+	cache, _ := cacheSetup()
+
+	_, ok := cache.Get("missing")
+
+	assert.False(t, ok)
+}
+
+func TestGetReturnsValueSet(t *testing.T) {
+	cache, _ := cacheSetup()
+	cache.Set("key", 42)
+
+	actual, ok := cache.Get("key")
+
+	assert.True(t, ok)
+	assert.Equal(t, 42, actual)
+}
+
+func TestSetOverwritesExistingValue(t *testing.T) {
+	cache, _ := cacheSetup()
+	cache.Set("key", 42)
+	cache.Set("key", 43)
+
+	actual, _ := cache.Get("key")
+
+	assert.Equal(t, 43, actual)
+}
+
+func TestGetReturnsFalseIfValueExpired(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.Set("key", 42)
+	clock.Advance(ttl)
+
+	_, ok := cache.Get("key")
+
+	assert.False(t, ok)
+}
+
+func TestSetRefreshesExpiration(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.Set("key", 42)
+	clock.Advance(ttl / 2)
+	cache.Set("key", 43)
+	clock.Advance(ttl / 2)
+
+	actual, ok := cache.Get("key")
+
+	assert.True(t, ok)
+	assert.Equal(t, 43, actual)
+}
+
+func TestSetRemovesExpiredValues(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.Set("key", 42)
+	clock.Advance(ttl)
+
+	cache.Set("other", 43)
+
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestDeleteRemovesValue(t *testing.T) {
+	cache, _ := cacheSetup()
+	cache.Set("key", 42)
+
+	cache.Delete("key")
+
+	_, ok := cache.Get("key")
+	assert.False(t, ok)
+}
+
+func TestSetUntilExpiresValueAtGivenTime(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.SetUntil("key", 42, clock.Now().Add(10*time.Second))
+	clock.Advance(10 * time.Second)
+
+	_, ok := cache.Get("key")
+
+	assert.False(t, ok)
+}
+
+func TestSetUntilKeepsTimeToLiveIfGivenTimeIsLater(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.SetUntil("key", 42, clock.Now().Add(2*ttl))
+	clock.Advance(ttl)
+
+	_, ok := cache.Get("key")
+
+	assert.False(t, ok)
+}
+
+func TestSetUntilReturnsValueBeforeGivenTime(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.SetUntil("key", 42, clock.Now().Add(10*time.Second))
+	clock.Advance(9 * time.Second)
+
+	actual, ok := cache.Get("key")
+
+	assert.True(t, ok)
+	assert.Equal(t, 42, actual)
+}
+
+func TestSetEvictsOldestValueWhenFull(t *testing.T) {
+	cache, clock := cacheSetup()
+	cache.SetMaxSize(2)
+	cache.Set("first", 1)
+	clock.Advance(time.Second)
+	cache.Set("second", 2)
+	clock.Advance(time.Second)
+
+	cache.Set("third", 3)
+
+	_, firstFound := cache.Get("first")
+	assert.False(t, firstFound)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestSetDoesNotEvictWhenOverwritingFullCache(t *testing.T) {
+	cache, _ := cacheSetup()
+	cache.SetMaxSize(2)
+	cache.Set("first", 1)
+	cache.Set("second", 2)
+
+	cache.Set("first", 3)
+
+	_, secondFound := cache.Get("second")
+	assert.True(t, secondFound)
+	assert.Equal(t, 2, cache.Len())
+}
@@ -0,0 +1,51 @@
+package cover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		scale    int
+		expected int
+	}{
+		"empty text": {
+			text:     "",
+			scale:    2,
+			expected: 0,
+		},
+		"single character": {
+			text:     "a",
+			scale:    1,
+			expected: 5,
+		},
+		"spacing between characters is scaled": {
+			text:     "ab",
+			scale:    3,
+			expected: 33,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, textWidth(test.text, test.scale))
+		})
+	}
+}
+
+func TestUnknownCharactersRenderedAsQuestionMark(t *testing.T) {
+	assert.Equal(t, glyphs['?'], glyph('ñ'))
+}
+
+func TestLowercaseCharactersRenderedAsUppercase(t *testing.T) {
+	assert.Equal(t, glyphs['A'], glyph('a'))
+}
+
+func TestTruncateTextFitsMaxWidth(t *testing.T) {
+	actual := truncateText("Hello world", 1, textWidth("Hello...", 1))
+
+	assert.Equal(t, "Hello...", actual)
+}
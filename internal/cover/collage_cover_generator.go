@@ -0,0 +1,154 @@
+package cover
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math"
+	"strings"
+
+	"festwrap/internal/cover/errors"
+)
+
+// Spotify rejects covers whose base64 encoded payload is larger than this
+const MaxEncodedCoverBytes = 256 * 1024
+
+var (
+	backgroundColor = color.RGBA{R: 30, G: 215, B: 96, A: 255}
+	bannerColor     = color.NRGBA{R: 0, G: 0, B: 0, A: 170}
+	titleColor      = color.White
+)
+
+// Arranges the images in a grid filling the whole cover, with the title on a banner at the bottom
+type CollageCoverGenerator struct {
+	size          int
+	margin        int
+	maxTitleScale int
+	maxBytes      int
+	qualities     []int
+}
+
+func NewCollageCoverGenerator() CollageCoverGenerator {
+	return CollageCoverGenerator{
+		size:          640,
+		margin:        16,
+		maxTitleScale: 6,
+		maxBytes:      MaxEncodedCoverBytes,
+		qualities:     []int{90, 75, 60, 45, 30},
+	}
+}
+
+func (g *CollageCoverGenerator) Generate(title string, images []image.Image) ([]byte, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, g.size, g.size))
+	fillRect(canvas, canvas.Bounds(), backgroundColor)
+	g.drawCollage(canvas, images)
+	g.drawTitle(canvas, strings.TrimSpace(title))
+	return g.encode(canvas)
+}
+
+// Images are split in rows, and the images of each row share its width evenly
+func (g *CollageCoverGenerator) drawCollage(canvas *image.RGBA, images []image.Image) {
+	if len(images) == 0 {
+		return
+	}
+
+	columns := int(math.Ceil(math.Sqrt(float64(len(images)))))
+	rows := int(math.Ceil(float64(len(images)) / float64(columns)))
+	for row := 0; row < rows; row++ {
+		rowImages := images[row*columns : min((row+1)*columns, len(images))]
+		for column, img := range rowImages {
+			cell := image.Rect(
+				column*g.size/len(rowImages),
+				row*g.size/rows,
+				(column+1)*g.size/len(rowImages),
+				(row+1)*g.size/rows,
+			)
+			drawCropped(canvas, cell, img)
+		}
+	}
+}
+
+func (g *CollageCoverGenerator) drawTitle(canvas *image.RGBA, title string) {
+	if title == "" {
+		return
+	}
+
+	maxWidth := g.size - 2*g.margin
+	scale := g.maxTitleScale
+	for scale > 1 && textWidth(title, scale) > maxWidth {
+		scale -= 1
+	}
+	title = truncateText(title, scale, maxWidth)
+
+	bannerHeight := textHeight(scale) + 2*g.margin
+	banner := image.Rect(0, g.size-bannerHeight, g.size, g.size)
+	draw.Draw(canvas, banner, &image.Uniform{C: bannerColor}, image.Point{}, draw.Over)
+
+	origin := image.Pt((g.size-textWidth(title, scale))/2, banner.Min.Y+g.margin)
+	drawText(canvas, title, origin, scale, titleColor)
+}
+
+// Lowers the JPEG quality until the cover fits in the allowed size
+func (g *CollageCoverGenerator) encode(canvas image.Image) ([]byte, error) {
+	for _, quality := range g.qualities {
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, canvas, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, errors.NewCannotGenerateCoverError(fmt.Sprintf("could not encode cover: %v", err))
+		}
+		if base64.StdEncoding.EncodedLen(buffer.Len()) <= g.maxBytes {
+			return buffer.Bytes(), nil
+		}
+	}
+	return nil, errors.NewCannotGenerateCoverError(fmt.Sprintf("cover does not fit in %d bytes", g.maxBytes))
+}
+
+func (g *CollageCoverGenerator) SetSize(size int) {
+	g.size = size
+}
+
+func (g *CollageCoverGenerator) SetMaxBytes(maxBytes int) {
+	g.maxBytes = maxBytes
+}
+
+func (g *CollageCoverGenerator) SetQualities(qualities []int) {
+	g.qualities = qualities
+}
+
+// Fills the cell with the center of the image, keeping its aspect ratio
+func drawCropped(canvas *image.RGBA, cell image.Rectangle, img image.Image) {
+	source := img.Bounds()
+	if source.Empty() || cell.Empty() {
+		return
+	}
+
+	cropWidth, cropHeight := source.Dx(), source.Dx()*cell.Dy()/cell.Dx()
+	if cropHeight > source.Dy() {
+		cropWidth, cropHeight = source.Dy()*cell.Dx()/cell.Dy(), source.Dy()
+	}
+	offsetX := source.Min.X + (source.Dx()-cropWidth)/2
+	offsetY := source.Min.Y + (source.Dy()-cropHeight)/2
+
+	for y := 0; y < cell.Dy(); y++ {
+		for x := 0; x < cell.Dx(); x++ {
+			sourceX := offsetX + x*cropWidth/cell.Dx()
+			sourceY := offsetY + y*cropHeight/cell.Dy()
+			canvas.Set(cell.Min.X+x, cell.Min.Y+y, img.At(sourceX, sourceY))
+		}
+	}
+}
+
+func truncateText(text string, scale int, maxWidth int) string {
+	if textWidth(text, scale) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...", scale) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "..."
+}
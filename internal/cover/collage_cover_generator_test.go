@@ -0,0 +1,112 @@
+package cover
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func solidImage(width int, height int, fillColor color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect(img, img.Bounds(), fillColor)
+	return img
+}
+
+func decodeCover(t *testing.T, cover []byte) image.Image {
+	t.Helper()
+	img, err := jpeg.Decode(bytes.NewReader(cover))
+	if err != nil {
+		t.Fatalf("Could not decode cover: %v", err)
+	}
+	return img
+}
+
+// JPEG compression changes colors slightly, so only the dominant channel is checked
+func isRed(pixel color.Color) bool {
+	r, g, b, _ := pixel.RGBA()
+	return r > 0xc000 && g < 0x4000 && b < 0x4000
+}
+
+func isBlue(pixel color.Color) bool {
+	r, g, b, _ := pixel.RGBA()
+	return b > 0xc000 && r < 0x4000 && g < 0x4000
+}
+
+func TestGenerateReturnsJpegWithCoverSize(t *testing.T) {
+	generator := NewCollageCoverGenerator()
+	generator.SetSize(300)
+
+	cover, err := generator.Generate("My playlist", []image.Image{solidImage(10, 10, color.White)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 300, 300), decodeCover(t, cover).Bounds())
+}
+
+func TestGenerateArrangesImagesInGrid(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	generator := NewCollageCoverGenerator()
+	generator.SetSize(200)
+
+	images := []image.Image{solidImage(50, 80, red), solidImage(30, 30, blue), solidImage(60, 20, red)}
+	cover, err := generator.Generate("", images)
+
+	actual := decodeCover(t, cover)
+	assert.Nil(t, err)
+	assert.True(t, isRed(actual.At(50, 50)))
+	assert.True(t, isBlue(actual.At(150, 50)))
+	// Last row has a single image filling the whole width
+	assert.True(t, isRed(actual.At(50, 150)))
+	assert.True(t, isRed(actual.At(150, 150)))
+}
+
+func TestGenerateDrawsTitleBanner(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	generator := NewCollageCoverGenerator()
+	generator.SetSize(200)
+
+	withoutTitle, err := generator.Generate("", []image.Image{solidImage(10, 10, red)})
+	assert.Nil(t, err)
+	withTitle, err := generator.Generate("Title", []image.Image{solidImage(10, 10, red)})
+	assert.Nil(t, err)
+
+	assert.True(t, isRed(decodeCover(t, withoutTitle).At(100, 195)))
+	assert.False(t, isRed(decodeCover(t, withTitle).At(100, 195)))
+	assert.True(t, isRed(decodeCover(t, withTitle).At(100, 50)))
+}
+
+func TestGenerateWorksWithoutImages(t *testing.T) {
+	generator := NewCollageCoverGenerator()
+
+	cover, err := generator.Generate("A very long playlist name which does not fit in a single line", []image.Image{})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, cover)
+}
+
+func TestGenerateReducesQualityToFitMaxBytes(t *testing.T) {
+	generator := NewCollageCoverGenerator()
+	generator.SetQualities([]int{100, 10})
+	best, err := generator.Generate("Title", []image.Image{})
+	assert.Nil(t, err)
+	generator.SetMaxBytes(base64.StdEncoding.EncodedLen(len(best)) - 1)
+
+	cover, err := generator.Generate("Title", []image.Image{})
+
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, base64.StdEncoding.EncodedLen(len(cover)), base64.StdEncoding.EncodedLen(len(best))-1)
+}
+
+func TestGenerateReturnsErrorIfCoverDoesNotFit(t *testing.T) {
+	generator := NewCollageCoverGenerator()
+	generator.SetMaxBytes(10)
+
+	_, err := generator.Generate("Title", []image.Image{})
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,8 @@
+package cover
+
+import "image"
+
+type CoverGenerator interface {
+	// Returns the JPEG encoded cover
+	Generate(title string, images []image.Image) ([]byte, error)
+}
@@ -0,0 +1,71 @@
+package cover
+
+import (
+	"context"
+	"image"
+
+	"festwrap/internal/artist"
+	"festwrap/internal/playlist"
+)
+
+type CoverService interface {
+	SetCover(ctx context.Context, playlistId string, title string, artists []string) error
+}
+
+// Generates playlist covers from the images of the playlist artists
+type ArtistCollageCoverService struct {
+	artistRepository   artist.ArtistRepository
+	playlistRepository playlist.PlaylistRepository
+	imageFetcher       ImageFetcher
+	generator          CoverGenerator
+}
+
+func NewArtistCollageCoverService(
+	artistRepository artist.ArtistRepository,
+	playlistRepository playlist.PlaylistRepository,
+	imageFetcher ImageFetcher,
+) ArtistCollageCoverService {
+	generator := NewCollageCoverGenerator()
+	return ArtistCollageCoverService{
+		artistRepository:   artistRepository,
+		playlistRepository: playlistRepository,
+		imageFetcher:       imageFetcher,
+		generator:          &generator,
+	}
+}
+
+func (s *ArtistCollageCoverService) SetCover(
+	ctx context.Context,
+	playlistId string,
+	title string,
+	artists []string,
+) error {
+	cover, err := s.generator.Generate(title, s.getArtistImages(ctx, artists))
+	if err != nil {
+		return err
+	}
+
+	return s.playlistRepository.UploadCover(ctx, playlistId, cover)
+}
+
+func (s *ArtistCollageCoverService) SetGenerator(generator CoverGenerator) {
+	s.generator = generator
+}
+
+// Artists whose image cannot be retrieved are left out of the cover
+func (s *ArtistCollageCoverService) getArtistImages(ctx context.Context, artists []string) []image.Image {
+	images := []image.Image{}
+	for _, name := range artists {
+		found, err := s.artistRepository.SearchArtist(ctx, name, 0, 1)
+		if err != nil || len(found.Items) == 0 || found.Items[0].ImageUri == "" {
+			continue
+		}
+
+		img, err := s.imageFetcher.Fetch(found.Items[0].ImageUri)
+		if err != nil {
+			continue
+		}
+		images = append(images, img)
+	}
+	return images
+}
@@ -0,0 +1,109 @@
+package cover
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"festwrap/internal/artist"
+	"festwrap/internal/pagination"
+	"festwrap/internal/playlist"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const coverPlaylistId = "somePlaylist"
+
+type fakeArtistRepository struct {
+	artists map[string]artist.Artist
+}
+
+func (r fakeArtistRepository) SearchArtist(
+	ctx context.Context,
+	name string,
+	offset int,
+	limit int,
+) (pagination.Page[artist.Artist], error) {
+	found, ok := r.artists[name]
+	if !ok {
+		return pagination.Page[artist.Artist]{}, errors.New("artist not found")
+	}
+	return pagination.NewPage([]artist.Artist{found}, offset, 1), nil
+}
+
+func (r fakeArtistRepository) GetRelatedArtists(ctx context.Context, artistId string) ([]artist.Artist, error) {
+	return []artist.Artist{}, nil
+}
+
+func (r fakeArtistRepository) GetTopTracks(ctx context.Context, artistId string) ([]string, error) {
+	return []string{}, nil
+}
+
+func coverServiceSetup() (ArtistCollageCoverService, *playlist.FakePlaylistRepository, *FakeImageFetcher) {
+	artistRepository := fakeArtistRepository{
+		artists: map[string]artist.Artist{
+			"Turnstile":  artist.NewArtistWithImageUri("Turnstile", "turnstile_uri"),
+			"Touché":     artist.NewArtistWithImageUri("Touché", "touche_uri"),
+			"No picture": artist.NewArtist("No picture"),
+		},
+	}
+	playlistRepository := playlist.NewFakePlaylistRepository()
+	fetcher := &FakeImageFetcher{}
+	fetcher.SetImage(solidImage(10, 10, color.White))
+	service := NewArtistCollageCoverService(artistRepository, &playlistRepository, fetcher)
+	return service, &playlistRepository, fetcher
+}
+
+func TestSetCoverUploadsGeneratedCover(t *testing.T) {
+	service, playlistRepository, _ := coverServiceSetup()
+
+	err := service.SetCover(context.Background(), coverPlaylistId, "My playlist", []string{"Turnstile"})
+
+	actual := playlistRepository.GetUploadCoverArgs()
+	assert.Nil(t, err)
+	assert.Equal(t, coverPlaylistId, actual.PlaylistId)
+	assert.Equal(t, image.Rect(0, 0, 640, 640), decodeCover(t, actual.JpegImage).Bounds())
+}
+
+func TestSetCoverFetchesImagesOfArtistsFound(t *testing.T) {
+	service, _, fetcher := coverServiceSetup()
+
+	artists := []string{"Turnstile", "Unknown", "No picture", "Touché"}
+	err := service.SetCover(context.Background(), coverPlaylistId, "My playlist", artists)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"turnstile_uri", "touche_uri"}, fetcher.GetFetchArgs())
+}
+
+func TestSetCoverUploadsCoverIfImagesCannotBeFetched(t *testing.T) {
+	service, playlistRepository, fetcher := coverServiceSetup()
+	fetcher.SetError(errors.New("test error"))
+
+	err := service.SetCover(context.Background(), coverPlaylistId, "My playlist", []string{"Turnstile"})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, playlistRepository.GetUploadCoverArgs().JpegImage)
+}
+
+func TestSetCoverReturnsErrorOnGenerationError(t *testing.T) {
+	service, playlistRepository, _ := coverServiceSetup()
+	generator := NewCollageCoverGenerator()
+	generator.SetMaxBytes(10)
+	service.SetGenerator(&generator)
+
+	err := service.SetCover(context.Background(), coverPlaylistId, "My playlist", []string{"Turnstile"})
+
+	assert.NotNil(t, err)
+	assert.Empty(t, playlistRepository.GetUploadCoverArgs().JpegImage)
+}
+
+func TestSetCoverReturnsErrorOnUploadError(t *testing.T) {
+	service, playlistRepository, _ := coverServiceSetup()
+	playlistRepository.SetError(errors.New("test error"))
+
+	err := service.SetCover(context.Background(), coverPlaylistId, "My playlist", []string{"Turnstile"})
+
+	assert.NotNil(t, err)
+}
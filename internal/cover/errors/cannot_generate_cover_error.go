@@ -0,0 +1,13 @@
+package errors
+
+type CannotGenerateCoverError struct {
+	message string
+}
+
+func NewCannotGenerateCoverError(message string) error {
+	return &CannotGenerateCoverError{message: message}
+}
+
+func (e *CannotGenerateCoverError) Error() string {
+	return e.message
+}
@@ -0,0 +1,26 @@
+package cover
+
+import "image"
+
+type FakeImageFetcher struct {
+	fetchArgs []string
+	image     image.Image
+	err       error
+}
+
+func (f *FakeImageFetcher) Fetch(uri string) (image.Image, error) {
+	f.fetchArgs = append(f.fetchArgs, uri)
+	return f.image, f.err
+}
+
+func (f *FakeImageFetcher) GetFetchArgs() []string {
+	return f.fetchArgs
+}
+
+func (f *FakeImageFetcher) SetImage(image image.Image) {
+	f.image = image
+}
+
+func (f *FakeImageFetcher) SetError(err error) {
+	f.err = err
+}
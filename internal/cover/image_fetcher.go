@@ -0,0 +1,35 @@
+package cover
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+
+	httpsender "festwrap/internal/http/sender"
+)
+
+type ImageFetcher interface {
+	Fetch(uri string) (image.Image, error)
+}
+
+type HTTPImageFetcher struct {
+	httpSender httpsender.HTTPRequestSender
+}
+
+func NewHTTPImageFetcher(httpSender httpsender.HTTPRequestSender) HTTPImageFetcher {
+	return HTTPImageFetcher{httpSender: httpSender}
+}
+
+func (f *HTTPImageFetcher) Fetch(uri string) (image.Image, error) {
+	response, err := f.httpSender.Send(httpsender.NewHTTPRequestOptions(uri, httpsender.GET, 200))
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(*response))
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
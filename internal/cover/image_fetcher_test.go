@@ -0,0 +1,68 @@
+package cover
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	httpsender "festwrap/internal/http/sender"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const imageUri = "https://i.scdn.co/image/some_image"
+
+func pngSender(t *testing.T) *httpsender.FakeHTTPSender {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, solidImage(4, 2, color.White)); err != nil {
+		t.Fatalf("Could not encode image: %v", err)
+	}
+	response := buffer.Bytes()
+	sender := httpsender.FakeHTTPSender{}
+	sender.SetResponse(&response)
+	return &sender
+}
+
+func TestFetchSendsRequestWithOptions(t *testing.T) {
+	sender := pngSender(t)
+	fetcher := NewHTTPImageFetcher(sender)
+
+	fetcher.Fetch(imageUri)
+
+	expected := httpsender.NewHTTPRequestOptions(imageUri, httpsender.GET, 200)
+	assert.Equal(t, expected, sender.GetSendArgs())
+}
+
+func TestFetchReturnsDecodedImage(t *testing.T) {
+	fetcher := NewHTTPImageFetcher(pngSender(t))
+
+	actual, err := fetcher.Fetch(imageUri)
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 2), actual.Bounds())
+}
+
+func TestFetchReturnsErrorOnSendError(t *testing.T) {
+	sender := httpsender.FakeHTTPSender{}
+	sender.SetError(errors.New("test error"))
+	fetcher := NewHTTPImageFetcher(&sender)
+
+	_, err := fetcher.Fetch(imageUri)
+
+	assert.NotNil(t, err)
+}
+
+func TestFetchReturnsErrorIfResponseIsNotImage(t *testing.T) {
+	sender := httpsender.FakeHTTPSender{}
+	response := []byte("not an image")
+	sender.SetResponse(&response)
+	fetcher := NewHTTPImageFetcher(&sender)
+
+	_, err := fetcher.Fetch(imageUri)
+
+	assert.NotNil(t, err)
+}
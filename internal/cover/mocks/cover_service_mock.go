@@ -0,0 +1,15 @@
+package cover_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CoverServiceMock struct {
+	mock.Mock
+}
+
+func (s *CoverServiceMock) SetCover(ctx context.Context, playlistId string, title string, artists []string) error {
+	return s.Called(ctx, playlistId, title, artists).Error(0)
+}
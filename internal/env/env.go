@@ -7,7 +7,7 @@ import (
 )
 
 type EnvValue interface {
-	~int | ~string
+	~int | ~string | ~bool
 }
 
 func GetEnvWithDefault[T EnvValue](key string, defaultValue T) (T, error) {
@@ -28,6 +28,13 @@ func GetEnvWithDefault[T EnvValue](key string, defaultValue T) (T, error) {
 		}
 	case string:
 		result = any(value).(T)
+	case bool:
+		parsed, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			err = fmt.Errorf("could not convert %s into boolean", value)
+		} else {
+			result = any(parsed).(T)
+		}
 	default:
 		err = fmt.Errorf("unsupported type %v", valueType)
 	}
@@ -23,6 +23,13 @@ func TestGetEnvReturnsDefaultValueIfDoesntExist(t *testing.T) {
 
 		assert.Equal(t, defaultValue, value)
 	})
+
+	t.Run("boolean", func(t *testing.T) {
+		t.Parallel()
+		value, _ := GetEnvWithDefault("MY_KEY", true)
+
+		assert.True(t, value)
+	})
 }
 
 func TestGetEnvReturnsExistingEnvVariable(t *testing.T) {
@@ -43,6 +50,14 @@ func TestGetEnvReturnsExistingEnvVariable(t *testing.T) {
 
 		assert.Equal(t, value, actual)
 	})
+
+	t.Run("boolean", func(t *testing.T) {
+		key := "myKey"
+		os.Setenv(key, "false")
+		actual, _ := GetEnvWithDefault(key, true)
+
+		assert.False(t, actual)
+	})
 }
 
 func TestGetEnvReturnsErrorOnInvalidEnvVar(t *testing.T) {
@@ -54,3 +69,12 @@ func TestGetEnvReturnsErrorOnInvalidEnvVar(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestGetEnvReturnsErrorOnInvalidBooleanEnvVar(t *testing.T) {
+	key := "myKey"
+	os.Setenv(key, "maybe")
+
+	_, err := GetEnvWithDefault(key, false)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,142 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	artisterrors "festwrap/internal/artist/errors"
+	autherrors "festwrap/internal/auth/errors"
+	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
+	joberrors "festwrap/internal/job/errors"
+	playlisterrors "festwrap/internal/playlist/errors"
+	setlisterrors "festwrap/internal/setlist/errors"
+	songerrors "festwrap/internal/song/errors"
+)
+
+const (
+	ArtistUnavailableCode     Code = "artist_unavailable"
+	ImageNotFoundCode         Code = "image_not_found"
+	PlaylistUnavailableCode   Code = "playlist_unavailable"
+	PlaylistUpdateFailedCode  Code = "playlist_update_failed"
+	InvalidPlaylistDetailCode Code = "invalid_playlist_details"
+	SetlistNotFoundCode       Code = "setlist_not_found"
+	SetlistUnavailableCode    Code = "setlist_unavailable"
+	SongNotFoundCode          Code = "song_not_found"
+	SongUnavailableCode       Code = "song_unavailable"
+	JobNotFoundCode           Code = "job_not_found"
+	JobQueueFullCode          Code = "job_queue_full"
+	TokenUnavailableCode      Code = "token_unavailable"
+	UpstreamErrorCode         Code = "upstream_error"
+)
+
+type errorMapping struct {
+	matches func(err error) bool
+	status  int
+	code    Code
+}
+
+func isError[T error](err error) bool {
+	var target T
+	return errors.As(err, &target)
+}
+
+// Statuses of the typed errors returned by the services. Errors from Spotify and setlist.fm
+// are reported as bad gateway, since they come from the services the API relies on
+var errorMappings = []errorMapping{
+	{isError[*artisterrors.CannotRetrieveArtistsError], http.StatusBadGateway, ArtistUnavailableCode},
+	{isError[*artisterrors.ImageNotFoundError], http.StatusNotFound, ImageNotFoundCode},
+	{isError[*playlisterrors.InvalidPlaylistDetailsError], http.StatusUnprocessableEntity, InvalidPlaylistDetailCode},
+	{isError[*playlisterrors.CannotRetrievePlaylistError], http.StatusBadGateway, PlaylistUnavailableCode},
+	{isError[*playlisterrors.CannotRetrievePlaylistTracksError], http.StatusBadGateway, PlaylistUnavailableCode},
+	{isError[*playlisterrors.CannotSearchPlaylistError], http.StatusBadGateway, PlaylistUnavailableCode},
+	{isError[*playlisterrors.CannotCreatePlaylistError], http.StatusBadGateway, PlaylistUpdateFailedCode},
+	{isError[*playlisterrors.CannotUpdatePlaylistError], http.StatusBadGateway, PlaylistUpdateFailedCode},
+	{isError[*playlisterrors.CannotDeletePlaylistError], http.StatusBadGateway, PlaylistUpdateFailedCode},
+	{isError[*playlisterrors.CannotAddSongsToPlaylistError], http.StatusBadGateway, PlaylistUpdateFailedCode},
+	{isError[*playlisterrors.CannotRemoveSongsFromPlaylistError], http.StatusBadGateway, PlaylistUpdateFailedCode},
+	{isError[*playlisterrors.CannotUploadCoverError], http.StatusBadGateway, PlaylistUpdateFailedCode},
+	{isError[*setlisterrors.SetlistNotFoundError], http.StatusNotFound, SetlistNotFoundCode},
+	{isError[*setlisterrors.CannotRetrieveSetlistError], http.StatusBadGateway, SetlistUnavailableCode},
+	{isError[*songerrors.SongNotFoundError], http.StatusNotFound, SongNotFoundCode},
+	{isError[*songerrors.CannotRetrieveSongError], http.StatusBadGateway, SongUnavailableCode},
+	{isError[*joberrors.JobNotFoundError], http.StatusNotFound, JobNotFoundCode},
+	{isError[*joberrors.JobQueueFullError], http.StatusServiceUnavailable, JobQueueFullCode},
+	{isError[*autherrors.SessionNotFoundError], http.StatusUnauthorized, MissingCredentialsCode},
+	{isError[*autherrors.InvalidGrantError], http.StatusUnauthorized, InvalidCredentialsCode},
+	{isError[*autherrors.CannotRetrieveTokenError], http.StatusBadGateway, TokenUnavailableCode},
+	{isError[*autherrors.CannotIntrospectTokenError], http.StatusBadGateway, TokenUnavailableCode},
+}
+
+// Returns the problem for the error, using its type to choose the status and code. Unknown errors
+// are internal ones. The detail is given by the caller, so internal messages are not exposed
+func FromError(err error, detail string) Problem {
+	if problem, ok := FromAuthError(err, detail); ok {
+		return problem
+	}
+	if problem, ok := fromUpstreamError(err, detail); ok {
+		return problem
+	}
+	for _, mapping := range errorMappings {
+		if mapping.matches(err) {
+			return New(mapping.status, mapping.code, detail)
+		}
+	}
+	return Internal(detail)
+}
+
+// Returns the problem for errors caused by Spotify rejecting the token of the client, which has
+// to be refreshed or granted more permissions
+func FromAuthError(err error, detail string) (Problem, bool) {
+	authErr, ok := httpsender.AsAuthError(err)
+	if !ok {
+		return Problem{}, false
+	}
+	if authErr.StatusCode() == http.StatusForbidden {
+		return New(http.StatusForbidden, ForbiddenCode, detail), true
+	}
+	return New(http.StatusUnauthorized, InvalidCredentialsCode, detail), true
+}
+
+// Returns the problem for errors answered by Spotify or setlist.fm. Client errors such as missing
+// resources or rate limits keep their status, while the rest are reported as bad gateway
+func fromUpstreamError(err error, detail string) (Problem, bool) {
+	var upstreamErr *senderrors.UpstreamError
+	if !errors.As(err, &upstreamErr) {
+		return Problem{}, false
+	}
+	status := upstreamErr.StatusCode()
+	if status < http.StatusBadRequest || status >= http.StatusInternalServerError {
+		status = http.StatusBadGateway
+	}
+	return New(status, UpstreamErrorCode, detail), true
+}
+
+// Messages shown to clients for errors reported outside problem responses, such as the ones of each
+// artist in a playlist update
+var codeMessages = map[Code]string{
+	ArtistUnavailableCode:     "could not retrieve the artist",
+	ImageNotFoundCode:         "could not find an image of the artist",
+	PlaylistUnavailableCode:   "could not retrieve the playlist",
+	PlaylistUpdateFailedCode:  "could not update the playlist",
+	InvalidPlaylistDetailCode: "invalid playlist details",
+	SetlistNotFoundCode:       "could not find a setlist for the artist",
+	SetlistUnavailableCode:    "could not retrieve the setlist",
+	SongNotFoundCode:          "could not find the song",
+	SongUnavailableCode:       "could not retrieve the song",
+	MissingCredentialsCode:    "missing credentials",
+	InvalidCredentialsCode:    "Spotify rejected the token",
+	ForbiddenCode:             "Spotify denied access",
+	TokenUnavailableCode:      "could not retrieve the token",
+	UpstreamErrorCode:         "an upstream service returned an error",
+}
+
+// Returns a fixed message for the type of error which can be shown to clients. Error messages
+// may include the requests sent to Spotify and setlist.fm along with their credentials, so they
+// should only be logged
+func Message(err error) string {
+	if message, ok := codeMessages[FromError(err, "").Code]; ok {
+		return message
+	}
+	return "unexpected error"
+}
@@ -0,0 +1,189 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	artisterrors "festwrap/internal/artist/errors"
+	autherrors "festwrap/internal/auth/errors"
+	senderrors "festwrap/internal/http/sender/errors"
+	joberrors "festwrap/internal/job/errors"
+	playlisterrors "festwrap/internal/playlist/errors"
+	setlisterrors "festwrap/internal/setlist/errors"
+	songerrors "festwrap/internal/song/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromErrorMapsTypedErrors(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+		code   Code
+	}{
+		"artists not retrieved": {
+			err:    artisterrors.NewCannotRetrieveArtistsError("test error"),
+			status: http.StatusBadGateway,
+			code:   ArtistUnavailableCode,
+		},
+		"artist image not found": {
+			err:    artisterrors.NewImageNotFoundError("test error"),
+			status: http.StatusNotFound,
+			code:   ImageNotFoundCode,
+		},
+		"invalid playlist details": {
+			err:    playlisterrors.NewInvalidPlaylistDetailsError("test error"),
+			status: http.StatusUnprocessableEntity,
+			code:   InvalidPlaylistDetailCode,
+		},
+		"playlist not retrieved": {
+			err:    playlisterrors.NewCannotRetrievePlaylistError("test error"),
+			status: http.StatusBadGateway,
+			code:   PlaylistUnavailableCode,
+		},
+		"playlist not updated": {
+			err:    playlisterrors.NewCannotAddSongsToPlaylistError("test error"),
+			status: http.StatusBadGateway,
+			code:   PlaylistUpdateFailedCode,
+		},
+		"setlist not found": {
+			err:    setlisterrors.NewSetlistNotFoundError("test error"),
+			status: http.StatusNotFound,
+			code:   SetlistNotFoundCode,
+		},
+		"setlist not retrieved": {
+			err:    setlisterrors.NewCannotRetrieveSetlistError("test error"),
+			status: http.StatusBadGateway,
+			code:   SetlistUnavailableCode,
+		},
+		"song not found": {
+			err:    songerrors.NewSongNotFoundError("test error"),
+			status: http.StatusNotFound,
+			code:   SongNotFoundCode,
+		},
+		"song not retrieved": {
+			err:    songerrors.NewCannotRetrieveSongError("test error"),
+			status: http.StatusBadGateway,
+			code:   SongUnavailableCode,
+		},
+		"job not found": {
+			err:    joberrors.NewJobNotFoundError("test error"),
+			status: http.StatusNotFound,
+			code:   JobNotFoundCode,
+		},
+		"job queue full": {
+			err:    joberrors.NewJobQueueFullError("test error"),
+			status: http.StatusServiceUnavailable,
+			code:   JobQueueFullCode,
+		},
+		"wrapped error": {
+			err:    fmt.Errorf("wrapped: %w", setlisterrors.NewSetlistNotFoundError("test error")),
+			status: http.StatusNotFound,
+			code:   SetlistNotFoundCode,
+		},
+		"session not found": {
+			err:    autherrors.NewSessionNotFoundError("test error"),
+			status: http.StatusUnauthorized,
+			code:   MissingCredentialsCode,
+		},
+		"refresh token rejected": {
+			err:    autherrors.NewInvalidGrantError("test error"),
+			status: http.StatusUnauthorized,
+			code:   InvalidCredentialsCode,
+		},
+		"token not retrieved": {
+			err:    autherrors.NewCannotRetrieveTokenError("test error"),
+			status: http.StatusBadGateway,
+			code:   TokenUnavailableCode,
+		},
+		"token not introspected": {
+			err:    autherrors.NewCannotIntrospectTokenError("test error"),
+			status: http.StatusBadGateway,
+			code:   TokenUnavailableCode,
+		},
+		"upstream unauthorized": {
+			err:    senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, ""),
+			status: http.StatusUnauthorized,
+			code:   InvalidCredentialsCode,
+		},
+		"upstream forbidden": {
+			err:    senderrors.NewUpstreamError("test error", http.StatusForbidden, http.Header{}, ""),
+			status: http.StatusForbidden,
+			code:   ForbiddenCode,
+		},
+		"upstream not found": {
+			err:    senderrors.NewUpstreamError("test error", http.StatusNotFound, http.Header{}, ""),
+			status: http.StatusNotFound,
+			code:   UpstreamErrorCode,
+		},
+		"upstream rate limit": {
+			err:    senderrors.NewUpstreamError("test error", http.StatusTooManyRequests, http.Header{}, ""),
+			status: http.StatusTooManyRequests,
+			code:   UpstreamErrorCode,
+		},
+		"upstream server error": {
+			err:    senderrors.NewUpstreamError("test error", http.StatusServiceUnavailable, http.Header{}, ""),
+			status: http.StatusBadGateway,
+			code:   UpstreamErrorCode,
+		},
+		"upstream unexpected status": {
+			err:    senderrors.NewUpstreamError("test error", http.StatusOK, http.Header{}, ""),
+			status: http.StatusBadGateway,
+			code:   UpstreamErrorCode,
+		},
+		"unknown error": {
+			err:    errors.New("test error"),
+			status: http.StatusInternalServerError,
+			code:   InternalErrorCode,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := FromError(test.err, "some detail")
+
+			assert.Equal(t, New(test.status, test.code, "some detail"), actual)
+		})
+	}
+}
+
+func TestMessageDoesNotExposeErrorMessages(t *testing.T) {
+	secretMessage := "request with options {map[x-api-key:secret]} failed"
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"typed error": {
+			err:      setlisterrors.NewSetlistNotFoundError(secretMessage),
+			expected: "could not find a setlist for the artist",
+		},
+		"wrapped typed error": {
+			err:      fmt.Errorf("could not add songs: %w", songerrors.NewCannotRetrieveSongError(secretMessage)),
+			expected: "could not retrieve the song",
+		},
+		"rejected token": {
+			err:      senderrors.NewUpstreamError(secretMessage, http.StatusUnauthorized, http.Header{}, ""),
+			expected: "Spotify rejected the token",
+		},
+		"upstream error": {
+			err:      senderrors.NewUpstreamError(secretMessage, http.StatusServiceUnavailable, http.Header{}, ""),
+			expected: "an upstream service returned an error",
+		},
+		"unknown error": {
+			err:      errors.New(secretMessage),
+			expected: "unexpected error",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, Message(test.err))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const MediaType = "application/problem+json"
+
+// Machine-readable identifier of the error, so clients do not depend on the wording of the details
+type Code string
+
+const (
+	MissingParameterCode   Code = "missing_parameter"
+	InvalidParameterCode   Code = "invalid_parameter"
+	InvalidBodyCode        Code = "invalid_body"
+	MissingCredentialsCode Code = "missing_credentials"
+	InvalidCredentialsCode Code = "invalid_credentials"
+	ForbiddenCode          Code = "forbidden"
+	InsufficientScopeCode  Code = "insufficient_scope"
+	NotAcceptableCode      Code = "not_acceptable"
+	IdempotencyKeyCode     Code = "idempotency_key_reused"
+	RequestInProgressCode  Code = "request_in_progress"
+	InternalErrorCode      Code = "internal_error"
+)
+
+// Error response following RFC 7807. The type is always about:blank, so the title is the one of the status
+type Problem struct {
+	Type          string   `json:"type"`
+	Title         string   `json:"title"`
+	Status        int      `json:"status"`
+	Detail        string   `json:"detail,omitempty"`
+	Code          Code     `json:"code"`
+	MissingScopes []string `json:"missingScopes,omitempty"`
+}
+
+func New(status int, code Code, detail string) Problem {
+	return Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Code: code}
+}
+
+func Write(w http.ResponseWriter, problem Problem) {
+	w.Header().Del("Content-Length")
+	w.Header().Set("Content-Type", MediaType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	setAuthenticateChallenge(w, problem.Status)
+	w.WriteHeader(problem.Status)
+	// Status is already sent, so there is nothing else to do if the body cannot be written
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+func MissingParameter(detail string) Problem {
+	return New(http.StatusBadRequest, MissingParameterCode, detail)
+}
+
+func InvalidParameter(detail string) Problem {
+	return New(http.StatusUnprocessableEntity, InvalidParameterCode, detail)
+}
+
+func InvalidBody(detail string) Problem {
+	return New(http.StatusBadRequest, InvalidBodyCode, detail)
+}
+
+func NotAcceptable(detail string) Problem {
+	return New(http.StatusNotAcceptable, NotAcceptableCode, detail)
+}
+
+// Returned when the token lacks scopes needed by the endpoint, listing them so clients can request them
+func InsufficientScope(missingScopes []string) Problem {
+	detail := fmt.Sprintf("token lacks the required scopes: %s", strings.Join(missingScopes, ", "))
+	problem := New(http.StatusForbidden, InsufficientScopeCode, detail)
+	problem.MissingScopes = missingScopes
+	return problem
+}
+
+func Internal(detail string) Problem {
+	return New(http.StatusInternalServerError, InternalErrorCode, detail)
+}
+
+// Clients are told how to authenticate on credential errors, unless the caller already did it
+func setAuthenticateChallenge(w http.ResponseWriter, status int) {
+	if w.Header().Get("WWW-Authenticate") != "" {
+		return
+	}
+	switch status {
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	case http.StatusForbidden:
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope"`)
+	}
+}
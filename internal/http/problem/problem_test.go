@@ -0,0 +1,86 @@
+package problem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUsesStatusTextAsTitle(t *testing.T) {
+	actual := New(http.StatusUnprocessableEntity, InvalidParameterCode, "limit must be positive")
+
+	expected := Problem{
+		Type:   "about:blank",
+		Title:  "Unprocessable Entity",
+		Status: http.StatusUnprocessableEntity,
+		Detail: "limit must be positive",
+		Code:   InvalidParameterCode,
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestWriteSendsProblemJson(t *testing.T) {
+	writer := httptest.NewRecorder()
+
+	Write(writer, MissingParameter("name was not provided"))
+
+	expected := `{"type":"about:blank","title":"Bad Request","status":400,` +
+		`"detail":"name was not provided","code":"missing_parameter"}` + "\n"
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, "application/problem+json", writer.Header().Get("Content-Type"))
+	assert.Equal(t, expected, writer.Body.String())
+}
+
+func TestWriteOmitsEmptyDetail(t *testing.T) {
+	writer := httptest.NewRecorder()
+
+	Write(writer, Internal(""))
+
+	expected := `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error"}` + "\n"
+	assert.Equal(t, expected, writer.Body.String())
+}
+
+func TestWriteSetsAuthenticateChallenge(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		expected string
+	}{
+		"unauthorized":    {status: http.StatusUnauthorized, expected: `Bearer error="invalid_token"`},
+		"forbidden":       {status: http.StatusForbidden, expected: `Bearer error="insufficient_scope"`},
+		"other than auth": {status: http.StatusBadGateway, expected: ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := httptest.NewRecorder()
+
+			Write(writer, New(test.status, InvalidCredentialsCode, ""))
+
+			assert.Equal(t, test.expected, writer.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestWriteKeepsExistingAuthenticateChallenge(t *testing.T) {
+	writer := httptest.NewRecorder()
+	writer.Header().Set("WWW-Authenticate", "Bearer")
+
+	Write(writer, New(http.StatusUnauthorized, MissingCredentialsCode, ""))
+
+	assert.Equal(t, "Bearer", writer.Header().Get("WWW-Authenticate"))
+}
+
+func TestInsufficientScopeListsMissingScopes(t *testing.T) {
+	writer := httptest.NewRecorder()
+
+	Write(writer, InsufficientScope([]string{"playlist-modify-public", "playlist-modify-private"}))
+
+	expected := `{"type":"about:blank","title":"Forbidden","status":403,` +
+		`"detail":"token lacks the required scopes: playlist-modify-public, playlist-modify-private",` +
+		`"code":"insufficient_scope","missingScopes":["playlist-modify-public","playlist-modify-private"]}` + "\n"
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+	assert.Equal(t, expected, writer.Body.String())
+}
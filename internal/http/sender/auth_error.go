@@ -0,0 +1,27 @@
+package httpsender
+
+import (
+	"errors"
+	"net/http"
+
+	senderrors "festwrap/internal/http/sender/errors"
+)
+
+// Returns the upstream error if the request was rejected because of its credentials
+func AsAuthError(err error) (*senderrors.UpstreamError, bool) {
+	var upstreamErr *senderrors.UpstreamError
+	if !errors.As(err, &upstreamErr) {
+		return nil, false
+	}
+	status := upstreamErr.StatusCode()
+	return upstreamErr, status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// Builds the error using the given constructor, unless the upstream service rejected the credentials.
+// Those are returned as they are, so handlers can tell clients to refresh their token
+func WrapError(err error, wrap func(message string) error) error {
+	if authErr, ok := AsAuthError(err); ok {
+		return authErr
+	}
+	return wrap(err.Error())
+}
@@ -0,0 +1,54 @@
+package httpsender
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	senderrors "festwrap/internal/http/sender/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func upstreamError(status int) error {
+	return senderrors.NewUpstreamError("test error", status, http.Header{}, "")
+}
+
+func TestAsAuthError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"unauthorized":         {err: upstreamError(http.StatusUnauthorized), expected: true},
+		"forbidden":            {err: upstreamError(http.StatusForbidden), expected: true},
+		"wrapped unauthorized": {err: fmt.Errorf("wrapped: %w", upstreamError(http.StatusUnauthorized)), expected: true},
+		"other status":         {err: upstreamError(http.StatusInternalServerError), expected: false},
+		"untyped error":        {err: errors.New("test error"), expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, actual := AsAuthError(test.err)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestWrapErrorKeepsAuthErrors(t *testing.T) {
+	err := upstreamError(http.StatusUnauthorized)
+
+	actual := WrapError(err, errors.New)
+
+	assert.Equal(t, err, actual)
+}
+
+func TestWrapErrorWrapsOtherErrors(t *testing.T) {
+	err := upstreamError(http.StatusInternalServerError)
+
+	actual := WrapError(err, func(message string) error { return fmt.Errorf("wrapped: %s", message) })
+
+	assert.Equal(t, errors.New("wrapped: test error"), actual)
+}
@@ -2,14 +2,17 @@ package httpsender
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	httpclient "festwrap/internal/http/client"
+	senderrors "festwrap/internal/http/sender/errors"
 )
 
+// Bytes of the body kept in upstream errors, enough to know the reason without logging big responses
+const maxErrorBodySize = 512
+
 type BaseHTTPRequestSender struct {
 	client httpclient.HTTPClient
 }
@@ -35,6 +38,10 @@ func (c *BaseHTTPRequestSender) Send(options HTTPRequestOptions) (*[]byte, error
 		return nil, fmt.Errorf("error sending HTTP request for options %v: %s", options, err.Error())
 	}
 
+	if response.Body != nil {
+		defer response.Body.Close()
+	}
+
 	if response.StatusCode != options.GetExpectedStatusCode() {
 		errorMsg := fmt.Sprintf(
 			"request with options %v failed. Expected status code %d, found %d",
@@ -42,10 +49,10 @@ func (c *BaseHTTPRequestSender) Send(options HTTPRequestOptions) (*[]byte, error
 			options.GetExpectedStatusCode(),
 			response.StatusCode,
 		)
-		return nil, errors.New(errorMsg)
+		body := readBodySnippet(response.Body)
+		return nil, senderrors.NewUpstreamError(errorMsg, response.StatusCode, response.Header, body)
 	}
 
-	defer response.Body.Close()
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error reading body for options %v: %s", options, err.Error())
@@ -59,3 +66,13 @@ func addHeadersToRequest(headers map[string]string, request *http.Request) {
 		request.Header.Add(key, name)
 	}
 }
+
+// Reads the beginning of the body of a failed response. Errors are ignored, since the body is only
+// used to give more details about the failure
+func readBodySnippet(body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+	return string(snippet)
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	httpclient "festwrap/internal/http/client"
+	senderrors "festwrap/internal/http/sender/errors"
 	"festwrap/internal/testtools"
 
 	"github.com/stretchr/testify/assert"
@@ -151,6 +153,40 @@ func TestSendRequestReturnsErrorWhenStatusNotExpected(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestSendRequestReturnsUpstreamErrorWhenStatusNotExpected(t *testing.T) {
+	client, sender, options := testSetup()
+	header := http.Header{"Www-Authenticate": []string{`Bearer error="invalid_token"`}}
+	client.SetResponse(&http.Response{
+		Status:     "401 Unauthorized",
+		StatusCode: 401,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"status":401,"message":"The access token expired"}}`)),
+	})
+
+	_, err := sender.Send(options)
+
+	var upstreamErr *senderrors.UpstreamError
+	assert.ErrorAs(t, err, &upstreamErr)
+	assert.Equal(t, 401, upstreamErr.StatusCode())
+	assert.Equal(t, header, upstreamErr.Header())
+	assert.Equal(t, `{"error":{"status":401,"message":"The access token expired"}}`, upstreamErr.Body())
+}
+
+func TestSendRequestTruncatesUpstreamErrorBody(t *testing.T) {
+	client, sender, options := testSetup()
+	client.SetResponse(&http.Response{
+		Status:     "500 Unexpected error",
+		StatusCode: 500,
+		Body:       io.NopCloser(strings.NewReader(strings.Repeat("a", 2*maxErrorBodySize))),
+	})
+
+	_, err := sender.Send(options)
+
+	var upstreamErr *senderrors.UpstreamError
+	assert.ErrorAs(t, err, &upstreamErr)
+	assert.Equal(t, strings.Repeat("a", maxErrorBodySize), upstreamErr.Body())
+}
+
 func TestSendRequestReturnsErrorOnResponseBodyError(t *testing.T) {
 	client, sender, options := testSetup()
 	client.SetResponse(errorBodyResponse())
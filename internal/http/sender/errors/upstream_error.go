@@ -0,0 +1,32 @@
+package errors
+
+import "net/http"
+
+// Returned when the upstream service answers with an unexpected status code. It keeps the
+// response details, so callers can react to the status and the body can be logged
+type UpstreamError struct {
+	message    string
+	statusCode int
+	header     http.Header
+	body       string
+}
+
+func NewUpstreamError(message string, statusCode int, header http.Header, body string) error {
+	return &UpstreamError{message: message, statusCode: statusCode, header: header, body: body}
+}
+
+func (e *UpstreamError) Error() string {
+	return e.message
+}
+
+func (e *UpstreamError) StatusCode() int {
+	return e.statusCode
+}
+
+func (e *UpstreamError) Header() http.Header {
+	return e.header
+}
+
+func (e *UpstreamError) Body() string {
+	return e.body
+}
@@ -7,8 +7,10 @@ type HTTPRequestSender interface {
 type Method string
 
 const (
-	GET  Method = "GET"
-	POST Method = "POST"
+	GET    Method = "GET"
+	POST   Method = "POST"
+	PUT    Method = "PUT"
+	DELETE Method = "DELETE"
 )
 
 type HTTPRequestOptions struct {
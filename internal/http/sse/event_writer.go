@@ -0,0 +1,52 @@
+package sse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"festwrap/internal/serialization"
+)
+
+// Writes Server-Sent Events, flushing each of them so clients receive them right away
+type EventWriter struct {
+	writer     http.ResponseWriter
+	flusher    http.Flusher
+	serializer serialization.Serializer[any]
+}
+
+func NewEventWriter(w http.ResponseWriter) (EventWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return EventWriter{}, errors.New("response writer does not support streaming")
+	}
+
+	serializer := serialization.NewJsonSerializer[any]()
+	return EventWriter{writer: w, flusher: flusher, serializer: &serializer}, nil
+}
+
+// Sends the headers for the event stream. Must be called before sending any event
+func (e EventWriter) Start() {
+	e.writer.Header().Set("Content-Type", "text/event-stream")
+	e.writer.Header().Set("Cache-Control", "no-cache")
+	e.writer.Header().Set("Connection", "keep-alive")
+	e.writer.WriteHeader(http.StatusOK)
+	e.flusher.Flush()
+}
+
+func (e EventWriter) Send(event string, data any) error {
+	body, err := e.serializer.Serialize(data)
+	if err != nil {
+		return fmt.Errorf("could not serialize event %s: %v", event, err)
+	}
+
+	if _, err = fmt.Fprintf(e.writer, "event: %s\ndata: %s\n\n", event, body); err != nil {
+		return fmt.Errorf("could not write event %s: %v", event, err)
+	}
+	e.flusher.Flush()
+	return nil
+}
+
+func (e *EventWriter) SetSerializer(serializer serialization.Serializer[any]) {
+	e.serializer = serializer
+}
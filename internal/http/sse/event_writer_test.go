@@ -0,0 +1,56 @@
+package sse
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"festwrap/internal/serialization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nonFlusherWriter struct {
+	http.ResponseWriter
+}
+
+func TestNewEventWriterReturnsErrorIfStreamingNotSupported(t *testing.T) {
+	_, err := NewEventWriter(nonFlusherWriter{httptest.NewRecorder()})
+
+	assert.NotNil(t, err)
+}
+
+func TestStartSendsEventStreamHeaders(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer, _ := NewEventWriter(recorder)
+
+	writer.Start()
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+	assert.True(t, recorder.Flushed)
+}
+
+func TestSendWritesEvent(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writer, _ := NewEventWriter(recorder)
+	writer.Start()
+
+	err := writer.Send("update", map[string]int{"value": 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "event: update\ndata: {\"value\":1}\n\n", recorder.Body.String())
+}
+
+func TestSendReturnsErrorOnSerializationError(t *testing.T) {
+	writer, _ := NewEventWriter(httptest.NewRecorder())
+	serializer := serialization.FakeSerializer[any]{}
+	serializer.SetError(errors.New("test error"))
+	writer.SetSerializer(&serializer)
+
+	err := writer.Send("update", 1)
+
+	assert.NotNil(t, err)
+}
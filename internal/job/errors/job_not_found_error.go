@@ -0,0 +1,13 @@
+package errors
+
+type JobNotFoundError struct {
+	message string
+}
+
+func NewJobNotFoundError(message string) error {
+	return &JobNotFoundError{message: message}
+}
+
+func (e *JobNotFoundError) Error() string {
+	return e.message
+}
@@ -0,0 +1,13 @@
+package errors
+
+type JobQueueFullError struct {
+	message string
+}
+
+func NewJobQueueFullError(message string) error {
+	return &JobQueueFullError{message: message}
+}
+
+func (e *JobQueueFullError) Error() string {
+	return e.message
+}
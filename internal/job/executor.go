@@ -0,0 +1,5 @@
+package job
+
+type Executor interface {
+	Submit(task func()) error
+}
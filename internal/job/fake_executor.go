@@ -0,0 +1,24 @@
+package job
+
+// Runs the submitted tasks synchronously
+type FakeExecutor struct {
+	submitted int
+	err       error
+}
+
+func (e *FakeExecutor) Submit(task func()) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.submitted += 1
+	task()
+	return nil
+}
+
+func (e *FakeExecutor) GetSubmitted() int {
+	return e.submitted
+}
+
+func (e *FakeExecutor) SetError(err error) {
+	e.err = err
+}
@@ -0,0 +1,107 @@
+package job
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"festwrap/internal/http/problem"
+	"festwrap/internal/playlist"
+)
+
+type Status string
+
+const (
+	PendingStatus            Status = "pending"
+	RunningStatus            Status = "running"
+	CompletedStatus          Status = "completed"
+	PartiallyCompletedStatus Status = "partiallyCompleted"
+	FailedStatus             Status = "failed"
+)
+
+type ArtistProgress struct {
+	Name      string                      `json:"name"`
+	RelatedTo string                      `json:"relatedTo,omitempty"`
+	Status    Status                      `json:"status"`
+	Error     string                      `json:"error,omitempty"`
+	Setlist   *playlist.SetlistProvenance `json:"setlist,omitempty"`
+}
+
+type Job struct {
+	Id         string           `json:"id"`
+	PlaylistId string           `json:"playlistId"`
+	Status     Status           `json:"status"`
+	Artists    []ArtistProgress `json:"artists"`
+	RolledBack bool             `json:"rolledBack,omitempty"`
+}
+
+func NewJob(id string, playlistId string, artists []string) Job {
+	progress := make([]ArtistProgress, len(artists))
+	for i, artist := range artists {
+		progress[i] = ArtistProgress{Name: artist, Status: PendingStatus}
+	}
+	return Job{Id: id, PlaylistId: playlistId, Status: PendingStatus, Artists: progress}
+}
+
+// Generates a random identifier which is hard to guess by other users
+func NewJobId() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func (j *Job) Start() {
+	j.Status = RunningStatus
+}
+
+// Errors are stored as a message describing their type, since jobs are returned to clients and
+// error messages may include the credentials of the requests sent
+func (j *Job) SetArtistResult(index int, err error) {
+	if err != nil {
+		j.Artists[index].Status = FailedStatus
+		j.Artists[index].Error = problem.Message(err)
+	} else {
+		j.Artists[index].Status = CompletedStatus
+	}
+}
+
+func (j *Job) SetArtistSetlist(index int, setlist playlist.SetlistProvenance) {
+	j.Artists[index].Setlist = &setlist
+}
+
+// Sets the final status of the job depending on the result of each artist
+func (j *Job) Finish() {
+	failures := 0
+	for _, artist := range j.Artists {
+		if artist.Status != CompletedStatus {
+			failures += 1
+		}
+	}
+
+	if failures == 0 {
+		j.Status = CompletedStatus
+	} else if failures < len(j.Artists) {
+		j.Status = PartiallyCompletedStatus
+	} else {
+		j.Status = FailedStatus
+	}
+}
+
+// Marks the job as failed when it cannot go on, along with the artists not processed yet
+func (j *Job) Fail(message string) {
+	for i, artist := range j.Artists {
+		if artist.Status != CompletedStatus && artist.Status != FailedStatus {
+			j.Artists[i].Status = FailedStatus
+			j.Artists[i].Error = message
+		}
+	}
+	j.Status = FailedStatus
+}
+
+func (j Job) Copy() Job {
+	result := j
+	result.Artists = make([]ArtistProgress, len(j.Artists))
+	copy(result.Artists, j.Artists)
+	return result
+}
@@ -0,0 +1,6 @@
+package job
+
+type JobStore interface {
+	Save(job Job) error
+	Get(id string) (Job, error)
+}
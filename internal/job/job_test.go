@@ -0,0 +1,104 @@
+package job
+
+import (
+	"errors"
+	"testing"
+
+	setlisterrors "festwrap/internal/setlist/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func defaultJob() Job {
+	return NewJob("jobId", "playlistId", []string{"Boysetsfire", "Thrice"})
+}
+
+func TestNewJobIsPendingForAllArtists(t *testing.T) {
+	job := defaultJob()
+
+	expected := []ArtistProgress{
+		{Name: "Boysetsfire", Status: PendingStatus},
+		{Name: "Thrice", Status: PendingStatus},
+	}
+	assert.Equal(t, PendingStatus, job.Status)
+	assert.Equal(t, expected, job.Artists)
+}
+
+func TestNewJobIdReturnsDifferentIds(t *testing.T) {
+	first, err := NewJobId()
+	assert.Nil(t, err)
+
+	second, err := NewJobId()
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestSetArtistResultStoresErrorsWithoutTheirMessages(t *testing.T) {
+	job := defaultJob()
+
+	job.SetArtistResult(0, nil)
+	job.SetArtistResult(1, setlisterrors.NewSetlistNotFoundError("request with header x-api-key:secret failed"))
+
+	expected := []ArtistProgress{
+		{Name: "Boysetsfire", Status: CompletedStatus},
+		{Name: "Thrice", Status: FailedStatus, Error: "could not find a setlist for the artist"},
+	}
+	assert.Equal(t, expected, job.Artists)
+}
+
+func TestFinishSetsStatusDependingOnArtistResults(t *testing.T) {
+	tests := map[string]struct {
+		errors   []error
+		expected Status
+	}{
+		"no failures": {
+			errors:   []error{nil, nil},
+			expected: CompletedStatus,
+		},
+		"some failures": {
+			errors:   []error{errors.New("test error"), nil},
+			expected: PartiallyCompletedStatus,
+		},
+		"all failures": {
+			errors:   []error{errors.New("test error"), errors.New("test error")},
+			expected: FailedStatus,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			job := defaultJob()
+			for i, err := range test.errors {
+				job.SetArtistResult(i, err)
+			}
+
+			job.Finish()
+
+			assert.Equal(t, test.expected, job.Status)
+		})
+	}
+}
+
+func TestFailMarksUnfinishedArtistsAsFailed(t *testing.T) {
+	job := defaultJob()
+	job.SetArtistResult(0, nil)
+
+	job.Fail("test error")
+
+	expected := []ArtistProgress{
+		{Name: "Boysetsfire", Status: CompletedStatus},
+		{Name: "Thrice", Status: FailedStatus, Error: "test error"},
+	}
+	assert.Equal(t, FailedStatus, job.Status)
+	assert.Equal(t, expected, job.Artists)
+}
+
+func TestCopyDoesNotShareArtistProgress(t *testing.T) {
+	job := defaultJob()
+
+	copied := job.Copy()
+	job.SetArtistResult(0, nil)
+
+	assert.Equal(t, PendingStatus, copied.Artists[0].Status)
+}
@@ -0,0 +1,41 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"festwrap/internal/cache"
+	"festwrap/internal/job/errors"
+)
+
+// Keeps jobs in memory until a given time has passed since their last update
+type MemoryJobStore struct {
+	jobs *cache.MemoryCache[string, Job]
+}
+
+func NewMemoryJobStore(ttl time.Duration) MemoryJobStore {
+	return MemoryJobStore{jobs: cache.NewMemoryCache[string, Job](ttl)}
+}
+
+func (s MemoryJobStore) Save(job Job) error {
+	// Jobs are copied so callers can keep updating them without data races
+	s.jobs.Set(job.Id, job.Copy())
+	return nil
+}
+
+func (s MemoryJobStore) Get(id string) (Job, error) {
+	job, ok := s.jobs.Get(id)
+	if !ok {
+		return Job{}, errors.NewJobNotFoundError(fmt.Sprintf("could not find job %s", id))
+	}
+	return job.Copy(), nil
+}
+
+// Limits the number of jobs kept, dropping the least recently updated ones first
+func (s MemoryJobStore) SetMaxSize(maxSize int) {
+	s.jobs.SetMaxSize(maxSize)
+}
+
+func (s MemoryJobStore) SetClock(now func() time.Time) {
+	s.jobs.SetClock(now)
+}
@@ -0,0 +1,68 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReturnsErrorIfJobNotFound(t *testing.T) {
+	store := NewMemoryJobStore(time.Minute)
+
+	_, err := store.Get("missing")
+
+	assert.NotNil(t, err)
+}
+
+func TestGetReturnsSavedJob(t *testing.T) {
+	store := NewMemoryJobStore(time.Minute)
+	err := store.Save(defaultJob())
+	assert.Nil(t, err)
+
+	actual, err := store.Get(defaultJob().Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, defaultJob(), actual)
+}
+
+func TestSavedJobIsNotModifiedByCaller(t *testing.T) {
+	store := NewMemoryJobStore(time.Minute)
+	job := defaultJob()
+	store.Save(job)
+
+	job.SetArtistResult(0, nil)
+
+	actual, _ := store.Get(job.Id)
+	assert.Equal(t, defaultJob(), actual)
+}
+
+func TestGetReturnsErrorIfJobExpired(t *testing.T) {
+	current := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryJobStore(time.Minute)
+	store.SetClock(func() time.Time { return current })
+	store.Save(defaultJob())
+	current = current.Add(time.Minute)
+
+	_, err := store.Get(defaultJob().Id)
+
+	assert.NotNil(t, err)
+}
+
+func TestSaveDropsLeastRecentlyUpdatedJobIfFull(t *testing.T) {
+	current := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryJobStore(time.Minute)
+	store.SetClock(func() time.Time { return current })
+	store.SetMaxSize(1)
+	store.Save(defaultJob())
+	current = current.Add(time.Second)
+	newJob := NewJob("newJobId", "playlistId", []string{"Thrice"})
+
+	store.Save(newJob)
+
+	_, err := store.Get(defaultJob().Id)
+	actual, newErr := store.Get(newJob.Id)
+	assert.NotNil(t, err)
+	assert.Nil(t, newErr)
+	assert.Equal(t, newJob, actual)
+}
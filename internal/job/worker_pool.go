@@ -0,0 +1,73 @@
+package job
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"festwrap/internal/job/errors"
+	"festwrap/internal/logging"
+)
+
+// Runs the submitted tasks in background using a fixed number of workers
+type WorkerPool struct {
+	tasks   chan func()
+	mutex   sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+	logger  logging.Logger
+}
+
+func NewWorkerPool(workers int, queueSize int, logger logging.Logger) *WorkerPool {
+	pool := &WorkerPool{tasks: make(chan func(), queueSize), logger: logger}
+	for i := 0; i < workers; i++ {
+		pool.wg.Add(1)
+		go pool.work()
+	}
+	return pool
+}
+
+func (p *WorkerPool) Submit(task func()) error {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.stopped {
+		return errors.NewJobQueueFullError("worker pool has been stopped")
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return errors.NewJobQueueFullError("too many pending jobs")
+	}
+}
+
+// Stops accepting tasks and waits until the pending ones are finished
+func (p *WorkerPool) Stop() {
+	p.mutex.Lock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.tasks)
+	}
+	p.mutex.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		p.runTask(task)
+	}
+}
+
+func (p *WorkerPool) runTask(task func()) {
+	// A failing task should not bring down the worker
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error(fmt.Sprintf("background task panicked: %v\n%s", r, debug.Stack()))
+		}
+	}()
+	task()
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"festwrap/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolRunsSubmittedTasks(t *testing.T) {
+	pool := NewWorkerPool(2, 10, logging.NoopLogger{})
+	var executed atomic.Int32
+
+	for i := 0; i < 5; i++ {
+		err := pool.Submit(func() { executed.Add(1) })
+		assert.Nil(t, err)
+	}
+	pool.Stop()
+
+	assert.Equal(t, int32(5), executed.Load())
+}
+
+func TestWorkerPoolReturnsErrorWhenQueueIsFull(t *testing.T) {
+	pool := NewWorkerPool(1, 1, logging.NoopLogger{})
+	defer pool.Stop()
+	started := make(chan bool)
+	release := make(chan bool)
+	pool.Submit(func() {
+		started <- true
+		<-release
+	})
+	<-started
+	pool.Submit(func() {})
+
+	err := pool.Submit(func() {})
+	close(release)
+
+	assert.NotNil(t, err)
+}
+
+func TestWorkerPoolReturnsErrorWhenStopped(t *testing.T) {
+	pool := NewWorkerPool(1, 1, logging.NoopLogger{})
+	pool.Stop()
+
+	err := pool.Submit(func() {})
+
+	assert.NotNil(t, err)
+}
+
+func TestWorkerPoolKeepsRunningAfterTaskPanics(t *testing.T) {
+	pool := NewWorkerPool(1, 2, logging.NoopLogger{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	pool.Submit(func() { panic("test panic") })
+	pool.Submit(func() { wg.Done() })
+	wg.Wait()
+	pool.Stop()
+}
@@ -0,0 +1,22 @@
+package pagination
+
+// Subset of the results of a query, starting at Offset, along with the total number of results
+type Page[T any] struct {
+	Items  []T
+	Offset int
+	Total  int
+}
+
+func NewPage[T any](items []T, offset int, total int) Page[T] {
+	return Page[T]{Items: items, Offset: offset, Total: total}
+}
+
+// Reports whether there are results after the ones in the page
+func (p Page[T]) HasNext() bool {
+	return p.Offset+len(p.Items) < p.Total
+}
+
+// Reports whether there are results before the ones in the page
+func (p Page[T]) HasPrevious() bool {
+	return p.Offset > 0
+}
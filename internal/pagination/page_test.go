@@ -0,0 +1,51 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageHasNextAndPrevious(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		page     Page[int]
+		next     bool
+		previous bool
+	}{
+		"single page": {
+			page:     NewPage([]int{1, 2}, 0, 2),
+			next:     false,
+			previous: false,
+		},
+		"first page": {
+			page:     NewPage([]int{1, 2}, 0, 5),
+			next:     true,
+			previous: false,
+		},
+		"middle page": {
+			page:     NewPage([]int{3, 4}, 2, 5),
+			next:     true,
+			previous: true,
+		},
+		"last page": {
+			page:     NewPage([]int{5}, 4, 5),
+			next:     false,
+			previous: true,
+		},
+		"offset beyond total": {
+			page:     NewPage([]int{}, 10, 5),
+			next:     false,
+			previous: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.next, test.page.HasNext())
+			assert.Equal(t, test.previous, test.page.HasPrevious())
+		})
+	}
+}
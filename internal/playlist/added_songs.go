@@ -0,0 +1,11 @@
+package playlist
+
+import "festwrap/internal/song"
+
+// Outcome of adding songs to a playlist, which can be done in several batches
+type AddedSongs struct {
+	// Snapshot of the playlist after each of the batches was added
+	SnapshotIds []string
+	Added       []song.Song
+	NotAdded    []song.Song
+}
@@ -9,8 +9,9 @@ import (
 )
 
 type FetchSongResult struct {
-	Song *song.Song
-	Err  error
+	Title string
+	Song  *song.Song
+	Err   error
 }
 
 type ConcurrentPlaylistService struct {
@@ -37,36 +38,139 @@ func (s *ConcurrentPlaylistService) CreatePlaylist(ctx context.Context, playlist
 	return s.playlistRepository.CreatePlaylist(ctx, playlist)
 }
 
-func (s *ConcurrentPlaylistService) AddSetlist(ctx context.Context, playlistId string, artist string) error {
-	setlist, err := s.setlistRepository.GetSetlist(artist, s.minSongs)
+func (s *ConcurrentPlaylistService) DeletePlaylist(ctx context.Context, playlistId string) error {
+	return s.playlistRepository.DeletePlaylist(ctx, playlistId)
+}
+
+func (s *ConcurrentPlaylistService) GetPlaylist(
+	ctx context.Context,
+	playlistId string,
+	offset int,
+	limit int,
+) (PlaylistWithTracks, error) {
+	return s.playlistRepository.GetPlaylist(ctx, playlistId, offset, limit)
+}
+
+func (s *ConcurrentPlaylistService) UpdatePlaylistDetails(
+	ctx context.Context,
+	playlistId string,
+	details PlaylistDetails,
+) error {
+	return s.playlistRepository.UpdatePlaylistDetails(ctx, playlistId, details)
+}
+
+func (s *ConcurrentPlaylistService) AddSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist PlaylistArtist,
+) (SetlistProvenance, error) {
+	matched, provenance, err := s.getSetlistSongs(ctx, playlistId, artist)
 	if err != nil {
-		return err
+		return SetlistProvenance{}, err
 	}
 
-	ch := make(chan FetchSongResult)
-	for _, song := range setlist.GetSongs() {
-		go s.fetchSong(ctx, artist, song, ch)
+	err = s.addSongs(ctx, playlistId, artist.Name, matched, provenance.Songs)
+	if err != nil {
+		return SetlistProvenance{}, err
 	}
 
-	songs := []song.Song{}
-	for i := 0; i < len(setlist.GetSongs()); i++ {
-		result := <-ch
-		if result.Err == nil {
-			songs = append(songs, *result.Song)
+	return provenance, nil
+}
+
+func (s *ConcurrentPlaylistService) ReplaceSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist PlaylistArtist,
+) (SetlistProvenance, error) {
+	matched, provenance, err := s.getSetlistSongs(ctx, playlistId, artist)
+	if err != nil {
+		return SetlistProvenance{}, err
+	}
+
+	err = s.playlistRepository.ReplaceSongs(ctx, playlistId, toSongs(matched))
+	if err != nil {
+		return SetlistProvenance{}, err
+	}
+
+	provenance.Songs.Added = matched
+	NotifyProgress(ctx, ProgressEvent{Type: SongsAddedEvent, Artist: artist.Name, NumSongs: len(matched)})
+	return provenance, nil
+}
+
+func (s *ConcurrentPlaylistService) SyncSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist PlaylistArtist,
+) (SetlistProvenance, error) {
+	matched, provenance, err := s.getSetlistSongs(ctx, playlistId, artist)
+	if err != nil {
+		return SetlistProvenance{}, err
+	}
+
+	tracks, err := s.playlistRepository.GetPlaylistTracks(ctx, playlistId)
+	if err != nil {
+		return SetlistProvenance{}, err
+	}
+
+	artistSongs := []song.Song{}
+	for _, track := range tracks.GetArtistTracks(artist.Name) {
+		artistSongs = append(artistSongs, track.ToSong())
+	}
+
+	songsToRemove := songsDifference(artistSongs, toSongs(matched))
+	if len(songsToRemove) > 0 {
+		err = s.playlistRepository.RemoveSongs(ctx, playlistId, tracks.SnapshotId, songsToRemove)
+		if err != nil {
+			return SetlistProvenance{}, err
 		}
+		NotifyProgress(ctx, ProgressEvent{Type: SongsRemovedEvent, Artist: artist.Name, NumSongs: len(songsToRemove)})
 	}
 
-	if len(songs) == 0 {
-		message := fmt.Sprintf("No songs to add to playlist %s", playlistId)
-		return errors.NewCannotAddSongsToPlaylistError(message)
+	artistUris := songUris(artistSongs)
+	songsToAdd := []SetlistSong{}
+	for _, matchedSong := range matched {
+		if artistUris[matchedSong.Uri] {
+			provenance.Songs.Skipped = append(provenance.Songs.Skipped, matchedSong)
+		} else {
+			songsToAdd = append(songsToAdd, matchedSong)
+		}
 	}
+	if len(songsToAdd) > 0 {
+		err = s.addSongs(ctx, playlistId, artist.Name, songsToAdd, provenance.Songs)
+		if err != nil {
+			return SetlistProvenance{}, err
+		}
+	}
+
+	return provenance, nil
+}
 
-	err = s.playlistRepository.AddSongs(ctx, playlistId, songs)
+func (s *ConcurrentPlaylistService) RemoveArtist(
+	ctx context.Context,
+	playlistId string,
+	artist string,
+) ([]PlaylistTrack, error) {
+	tracks, err := s.playlistRepository.GetPlaylistTracks(ctx, playlistId)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	artistTracks := tracks.GetArtistTracks(artist)
+	if len(artistTracks) == 0 {
+		return artistTracks, nil
+	}
+
+	songs := make([]song.Song, len(artistTracks))
+	for i, track := range artistTracks {
+		songs[i] = track.ToSong()
+	}
+
+	err = s.playlistRepository.RemoveSongs(ctx, playlistId, tracks.SnapshotId, songs)
+	if err != nil {
+		return nil, err
+	}
+
+	return artistTracks, nil
 }
 
 func (s *ConcurrentPlaylistService) SetMinSongs(minSongs int) {
@@ -75,10 +179,133 @@ func (s *ConcurrentPlaylistService) SetMinSongs(minSongs int) {
 
 func (s *ConcurrentPlaylistService) fetchSong(
 	ctx context.Context,
-	artist string,
+	artist PlaylistArtist,
 	song setlist.Song,
 	ch chan<- FetchSongResult,
 ) {
-	songDetails, err := s.songRepository.GetSong(ctx, artist, song.GetTitle())
-	ch <- FetchSongResult{Song: songDetails, Err: err}
+	songDetails, err := s.searchSong(ctx, artist, song.GetTitle())
+	ch <- FetchSongResult{Title: song.GetTitle(), Song: songDetails, Err: err}
+}
+
+func (s *ConcurrentPlaylistService) searchSong(
+	ctx context.Context,
+	artist PlaylistArtist,
+	title string,
+) (*song.Song, error) {
+	if artist.SpotifyId != "" {
+		return s.songRepository.GetSongByArtistId(ctx, artist.SpotifyId, artist.Name, title)
+	}
+	return s.songRepository.GetSong(ctx, artist.Name, title)
+}
+
+func (s *ConcurrentPlaylistService) getSetlist(ctx context.Context, artist PlaylistArtist) (*setlist.Setlist, error) {
+	setlistArtist := setlist.SetlistArtist{
+		Name:          artist.Name,
+		MusicBrainzId: artist.MusicBrainzId,
+		SpotifyId:     artist.SpotifyId,
+	}
+	return s.setlistRepository.GetSetlist(ctx, setlistArtist, s.minSongs)
+}
+
+// Returns the setlist songs matched in Spotify, along with the provenance of the setlist, which
+// already reports the songs not found
+func (s *ConcurrentPlaylistService) getSetlistSongs(
+	ctx context.Context,
+	playlistId string,
+	playlistArtist PlaylistArtist,
+) ([]SetlistSong, SetlistProvenance, error) {
+	artist := playlistArtist.Name
+	setlist, err := s.getSetlist(ctx, playlistArtist)
+	if err != nil {
+		NotifyProgress(ctx, ProgressEvent{Type: SetlistNotFoundEvent, Artist: artist, Err: err})
+		return nil, SetlistProvenance{}, err
+	}
+	NotifyProgress(ctx, ProgressEvent{Type: SetlistFoundEvent, Artist: artist, NumSongs: len(setlist.GetSongs())})
+
+	ch := make(chan FetchSongResult)
+	for _, song := range setlist.GetSongs() {
+		go s.fetchSong(ctx, playlistArtist, song, ch)
+	}
+
+	// Listeners are only notified from this goroutine, so they do not need to be concurrent-safe
+	matched := []SetlistSong{}
+	report := SetlistSongs{}
+	for i := 0; i < len(setlist.GetSongs()); i++ {
+		result := <-ch
+		if result.Err == nil {
+			matched = append(matched, SetlistSong{Title: result.Title, Uri: result.Song.GetUri()})
+			event := ProgressEvent{Type: SongMatchedEvent, Artist: artist, Title: result.Title, Uri: result.Song.GetUri()}
+			NotifyProgress(ctx, event)
+		} else {
+			report.NotFound = append(report.NotFound, SetlistSong{Title: result.Title})
+			event := ProgressEvent{Type: SongNotMatchedEvent, Artist: artist, Title: result.Title, Err: result.Err}
+			NotifyProgress(ctx, event)
+		}
+	}
+
+	if len(matched) == 0 {
+		message := fmt.Sprintf("No songs to add to playlist %s", playlistId)
+		return nil, SetlistProvenance{}, errors.NewCannotAddSongsToPlaylistError(message)
+	}
+
+	provenance := NewSetlistProvenance(artist, *setlist)
+	provenance.Songs = &report
+	return matched, provenance, nil
+}
+
+// Adds the songs to the playlist, reporting which of them were added. Songs are added in batches,
+// so it only fails if none of them could be added
+func (s *ConcurrentPlaylistService) addSongs(
+	ctx context.Context,
+	playlistId string,
+	artist string,
+	songs []SetlistSong,
+	report *SetlistSongs,
+) error {
+	added, err := s.playlistRepository.AddSongs(ctx, playlistId, toSongs(songs))
+	if len(added.Added) > 0 {
+		NotifyProgress(ctx, ProgressEvent{Type: SongsAddedEvent, Artist: artist, NumSongs: len(added.Added)})
+	}
+	if err == nil {
+		report.Added = append(report.Added, songs...)
+		return nil
+	}
+
+	for _, notAdded := range added.NotAdded {
+		event := ProgressEvent{Type: SongNotAddedEvent, Artist: artist, Uri: notAdded.GetUri(), Err: err}
+		NotifyProgress(ctx, event)
+	}
+	if len(added.Added) == 0 {
+		return err
+	}
+
+	addedUris := songUris(added.Added)
+	for _, setlistSong := range songs {
+		if addedUris[setlistSong.Uri] {
+			report.Added = append(report.Added, setlistSong)
+		} else {
+			report.NotAdded = append(report.NotAdded, setlistSong)
+		}
+	}
+	return nil
+}
+
+// Returns the songs in the first list which are not present in the second one
+func songsDifference(songs []song.Song, other []song.Song) []song.Song {
+	otherUris := songUris(other)
+	result := []song.Song{}
+	for _, currentSong := range songs {
+		if !otherUris[currentSong.GetUri()] {
+			result = append(result, currentSong)
+		}
+	}
+	return result
+}
+
+func songUris(songs []song.Song) map[string]bool {
+	uris := make(map[string]bool, len(songs))
+	for _, currentSong := range songs {
+		uris[currentSong.GetUri()] = true
+	}
+	return uris
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"festwrap/internal/setlist"
 	"festwrap/internal/song"
@@ -28,6 +29,10 @@ func defaultArtist() string {
 	return "myArtist"
 }
 
+func defaultPlaylistArtist() PlaylistArtist {
+	return PlaylistArtist{Name: defaultArtist()}
+}
+
 func defaultSongs() []interface{} {
 	return []interface{}{
 		song.NewSong("some_uri"),
@@ -57,6 +62,34 @@ func defaultSetlist() setlist.Setlist {
 	return setlist.NewSetlist(defaultArtist(), songs)
 }
 
+func concertSetlist() setlist.Setlist {
+	concert := defaultSetlist()
+	concert.SetDate(time.Date(2024, time.August, 10, 0, 0, 0, 0, time.UTC))
+	concert.SetVenue("Parque Ondarreta, Getxo")
+	concert.SetTour("Summer Tour")
+	concert.SetSource(setlist.SetlistFMSource)
+	return concert
+}
+
+func concertProvenance() SetlistProvenance {
+	return SetlistProvenance{
+		Artist: defaultArtist(),
+		Date:   "2024-08-10",
+		Venue:  "Parque Ondarreta, Getxo",
+		Tour:   "Summer Tour",
+		Source: setlist.SetlistFMSource,
+	}
+}
+
+// Songs are fetched concurrently, so we cannot know which title gets each uri
+func setlistSongUris(songs []SetlistSong) []string {
+	uris := make([]string, len(songs))
+	for i, setlistSong := range songs {
+		uris[i] = setlistSong.Uri
+	}
+	return uris
+}
+
 func emptySetlist() setlist.Setlist {
 	return setlist.NewSetlist(defaultArtist(), []setlist.Song{})
 }
@@ -143,18 +176,134 @@ func TestCreatePlaylistReturnsErrorIfRepositoryErrors(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestDeletePlaylistRepositoryCalledWithArgs(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	err := service.DeletePlaylist(defaultContext(), defaultPlaylistId())
+
+	actual := playlistRepository.GetDeletePlaylistArgs()
+	expected := DeletePlaylistArgs{Context: defaultContext(), PlaylistId: defaultPlaylistId()}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestDeletePlaylistReturnsErrorIfRepositoryErrors(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetError(errors.New("test error"))
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	err := service.DeletePlaylist(defaultContext(), defaultPlaylistId())
+
+	assert.NotNil(t, err)
+}
+
+func TestGetPlaylistRepositoryCalledWithArgs(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.GetPlaylist(defaultContext(), defaultPlaylistId(), 10, 20)
+
+	actual := playlistRepository.GetGetPlaylistArgs()
+	expected := GetPlaylistArgs{Context: defaultContext(), PlaylistId: defaultPlaylistId(), Offset: 10, Limit: 20}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetPlaylistReturnsRepositoryPlaylist(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	expected := PlaylistWithTracks{Id: defaultPlaylistId(), Name: "My playlist", SnapshotId: "snapshot"}
+	playlistRepository.SetPlaylist(expected)
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.GetPlaylist(defaultContext(), defaultPlaylistId(), 0, 20)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestUpdatePlaylistDetailsRepositoryCalledWithArgs(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+	description := "some description"
+	details := PlaylistDetails{Description: &description}
+
+	err := service.UpdatePlaylistDetails(defaultContext(), defaultPlaylistId(), details)
+
+	actual := playlistRepository.GetUpdatePlaylistDetailsArgs()
+	expected := UpdatePlaylistDetailsArgs{Context: defaultContext(), PlaylistId: defaultPlaylistId(), Details: details}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestSetlistMethodsReturnSetlistProvenance(t *testing.T) {
+	tests := map[string]struct {
+		update func(service *ConcurrentPlaylistService) (SetlistProvenance, error)
+	}{
+		"add": {
+			update: func(service *ConcurrentPlaylistService) (SetlistProvenance, error) {
+				return service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+			},
+		},
+		"replace": {
+			update: func(service *ConcurrentPlaylistService) (SetlistProvenance, error) {
+				return service.ReplaceSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+			},
+		},
+		"sync": {
+			update: func(service *ConcurrentPlaylistService) (SetlistProvenance, error) {
+				return service.SyncSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			playlistRepository, setlistRepository, songRepository := testSetup()
+			setlistRepository.SetReturnValue(concertSetlist())
+			service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+			actual, err := test.update(&service)
+
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, []string{"some_uri", "another_uri"}, setlistSongUris(actual.Songs.Added))
+			actual.Songs = nil
+			assert.Equal(t, concertProvenance(), actual)
+		})
+	}
+}
+
+func TestAddSetlistReturnsProvenanceWithoutUnknownDate(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.Nil(t, err)
+	actual.Songs = nil
+	assert.Equal(t, SetlistProvenance{Artist: defaultArtist()}, actual)
+}
+
 func TestAddSetlistSetlistRepositoryCalledWithArgs(t *testing.T) {
-	artist := defaultArtist()
 	minSongs := 6
 	playlistRepository, setlistRepository, songRepository := testSetup()
+	artist := PlaylistArtist{
+		Name:          defaultArtist(),
+		SpotifyId:     "artistId",
+		MusicBrainzId: "b5e3a6d1-65be-4b4a-a59e-2d5e4fbd9b6c",
+	}
 
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 	service.SetMinSongs(minSongs)
 
-	err := service.AddSetlist(defaultContext(), defaultPlaylistId(), artist)
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), artist)
 
 	actual := setlistRepository.GetGetSetlistArgs()
-	expected := setlist.GetSetlistArgs{Artist: artist, MinSongs: minSongs}
+	expected := setlist.GetSetlistArgs{
+		Context:  defaultContext(),
+		Artist:   setlist.SetlistArtist{Name: artist.Name, SpotifyId: artist.SpotifyId, MusicBrainzId: artist.MusicBrainzId},
+		MinSongs: minSongs,
+	}
 	assert.Nil(t, err)
 	assert.Equal(t, expected, actual)
 }
@@ -165,7 +314,7 @@ func TestAddSetlistReturnsErrorOnSetlistRepositoryError(t *testing.T) {
 	setlistRepository.SetError(returnError)
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 
-	err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultArtist())
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
 
 	assert.NotNil(t, err)
 }
@@ -174,7 +323,7 @@ func TestAddSetlistSongRepositoryCalledWithSetlistSongs(t *testing.T) {
 	playlistRepository, setlistRepository, songRepository := testSetup()
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 
-	err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultArtist())
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
 
 	actual := songRepository.GetGetSongArgs()
 	expected := defaultGetSongArgs()
@@ -184,12 +333,30 @@ func TestAddSetlistSongRepositoryCalledWithSetlistSongs(t *testing.T) {
 	}
 }
 
+func TestAddSetlistSongRepositoryCalledWithArtistId(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+	artist := PlaylistArtist{Name: defaultArtist(), SpotifyId: "artistId"}
+
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), artist)
+
+	actual := songRepository.GetGetSongArgs()
+	expected := []song.GetSongArgs{
+		{Context: defaultContext(), ArtistId: "artistId", Artist: defaultArtist(), Title: "My song"},
+		{Context: defaultContext(), ArtistId: "artistId", Artist: defaultArtist(), Title: "My other song"},
+	}
+	assert.Nil(t, err)
+	if !testtools.HaveSameElements(expected, actual) {
+		t.Errorf("Expected called songs %v, found %v", expected, actual)
+	}
+}
+
 func TestAddSetlistAddsSongsFetched(t *testing.T) {
 	playlistRepository, setlistRepository, songRepository := testSetup()
 	songRepository.SetSongs(defaultSongs())
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 
-	err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultArtist())
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
 
 	actual := playlistRepository.GetAddSongArgs()
 	expected := defaultAddSongsArgs()
@@ -202,7 +369,7 @@ func TestAddSetlistAddsOnlySongsFetchedWithoutError(t *testing.T) {
 	songRepository.SetSongs(songsWithErrors())
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 
-	err := service.AddSetlist(defaultContext(), "myPlaylist", defaultArtist())
+	_, err := service.AddSetlist(defaultContext(), "myPlaylist", defaultPlaylistArtist())
 
 	actual := playlistRepository.GetAddSongArgs()
 	expected := addSongsArgsWithErrors()
@@ -215,7 +382,7 @@ func TestAddSetlistSetlistRaisesErrorIfSetlistEmpty(t *testing.T) {
 	songRepository.SetSongs(errorSongs())
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 
-	err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultArtist())
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
 
 	assert.NotNil(t, err)
 }
@@ -225,7 +392,280 @@ func TestAddSetlistSetlistRaisesErrorIfNoSongsFound(t *testing.T) {
 	setlistRepository.SetReturnValue(emptySetlist())
 	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
 
-	err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultArtist())
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.NotNil(t, err)
+}
+
+func TestReplaceSetlistReplacesSongsFetched(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.ReplaceSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	actual := playlistRepository.GetReplaceSongsArgs()
+	expected := ReplaceSongsArgs(defaultAddSongsArgs())
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestReplaceSetlistReturnsErrorOnSetlistRepositoryError(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	setlistRepository.SetError(errors.New("test error"))
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.ReplaceSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.NotNil(t, err)
+}
+
+func syncPlaylistTracks() PlaylistTracks {
+	return PlaylistTracks{
+		SnapshotId: "snapshot",
+		Tracks: []PlaylistTrack{
+			{Uri: "some_uri", Name: "My song", Artists: []string{"MYARTIST"}},
+			{Uri: "outdated_uri", Name: "My outdated song", Artists: []string{"Someone", defaultArtist()}},
+			{Uri: "other_artist_uri", Name: "Other song", Artists: []string{"Other artist"}},
+		},
+	}
+}
+
+func TestSyncSetlistRemovesArtistSongsNotInSetlist(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.SyncSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	actual := playlistRepository.GetRemoveSongsArgs()
+	expected := RemoveSongsArgs{
+		Context:    defaultContext(),
+		PlaylistId: defaultPlaylistId(),
+		SnapshotId: "snapshot",
+		Songs:      []song.Song{song.NewSong("outdated_uri")},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestSyncSetlistAddsSetlistSongsNotInPlaylist(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.SyncSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	actual := playlistRepository.GetAddSongArgs()
+	expected := AddSongsArgs{
+		Context:    defaultContext(),
+		PlaylistId: defaultPlaylistId(),
+		Songs:      []song.Song{song.NewSong("another_uri")},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestSyncSetlistDoesNotUpdatePlaylistIfAlreadySynced(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(PlaylistTracks{
+		Tracks: []PlaylistTrack{
+			{Uri: "some_uri", Artists: []string{defaultArtist()}},
+			{Uri: "another_uri", Artists: []string{defaultArtist()}},
+		},
+	})
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.SyncSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.Nil(t, err)
+	assert.Equal(t, AddSongsArgs{}, playlistRepository.GetAddSongArgs())
+	assert.Equal(t, RemoveSongsArgs{}, playlistRepository.GetRemoveSongsArgs())
+}
+
+func TestSyncSetlistReturnsErrorOnPlaylistRepositoryError(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetError(errors.New("test error"))
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.SyncSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.NotNil(t, err)
+}
+
+func TestRemoveArtistRemovesArtistTracks(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.RemoveArtist(defaultContext(), defaultPlaylistId(), defaultArtist())
+
+	actual := playlistRepository.GetRemoveSongsArgs()
+	expected := RemoveSongsArgs{
+		Context:    defaultContext(),
+		PlaylistId: defaultPlaylistId(),
+		SnapshotId: "snapshot",
+		Songs:      []song.Song{song.NewSong("some_uri"), song.NewSong("outdated_uri")},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestRemoveArtistReturnsRemovedTracks(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.RemoveArtist(defaultContext(), defaultPlaylistId(), defaultArtist())
+
+	expected := syncPlaylistTracks().Tracks[:2]
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestRemoveArtistDoesNotRemoveIfArtistNotInPlaylist(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.RemoveArtist(defaultContext(), defaultPlaylistId(), "Unknown artist")
+
+	assert.Nil(t, err)
+	assert.Empty(t, actual)
+	assert.Equal(t, RemoveSongsArgs{}, playlistRepository.GetRemoveSongsArgs())
+}
+
+func TestRemoveArtistReturnsErrorOnPlaylistRepositoryError(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetError(errors.New("test error"))
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.RemoveArtist(defaultContext(), defaultPlaylistId(), defaultArtist())
+
+	assert.NotNil(t, err)
+}
+
+func progressRecorderContext(events *[]ProgressEvent) context.Context {
+	return WithProgressListener(defaultContext(), func(event ProgressEvent) {
+		*events = append(*events, event)
+	})
+}
+
+func TestAddSetlistNotifiesProgress(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	songRepository.SetSongs(songsWithErrors())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+	events := []ProgressEvent{}
+
+	_, err := service.AddSetlist(progressRecorderContext(&events), defaultPlaylistId(), defaultPlaylistArtist())
+
+	// Songs are fetched concurrently, so we cannot know which title gets each result
+	songEventTypes := []ProgressEventType{events[1].Type, events[2].Type}
+	expectedSongEventTypes := []ProgressEventType{SongNotMatchedEvent, SongMatchedEvent}
+	assert.Nil(t, err)
+	assert.Len(t, events, 4)
+	assert.Equal(t, ProgressEvent{Type: SetlistFoundEvent, Artist: defaultArtist(), NumSongs: 2}, events[0])
+	assert.True(t, testtools.HaveSameElements(expectedSongEventTypes, songEventTypes))
+	assert.Equal(t, ProgressEvent{Type: SongsAddedEvent, Artist: defaultArtist(), NumSongs: 1}, events[3])
+}
+
+func TestAddSetlistNotifiesSetlistNotFound(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	setlistErr := errors.New("test error")
+	setlistRepository.SetError(setlistErr)
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+	events := []ProgressEvent{}
+
+	service.AddSetlist(progressRecorderContext(&events), defaultPlaylistId(), defaultPlaylistArtist())
+
+	expected := []ProgressEvent{{Type: SetlistNotFoundEvent, Artist: defaultArtist(), Err: setlistErr}}
+	assert.Equal(t, expected, events)
+}
+
+func TestSyncSetlistNotifiesRemovedSongs(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+	events := []ProgressEvent{}
+
+	service.SyncSetlist(progressRecorderContext(&events), defaultPlaylistId(), defaultPlaylistArtist())
+
+	expected := []ProgressEvent{
+		{Type: SongsRemovedEvent, Artist: defaultArtist(), NumSongs: 1},
+		{Type: SongsAddedEvent, Artist: defaultArtist(), NumSongs: 1},
+	}
+	assert.Equal(t, expected, events[len(events)-2:])
+}
+
+func TestAddSetlistNotifiesSongsNotAdded(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	addErr := errors.New("test error")
+	playlistRepository.SetError(addErr)
+	playlistRepository.SetAddedSongs(AddedSongs{
+		SnapshotIds: []string{"snapshot"},
+		Added:       []song.Song{song.NewSong("some_uri")},
+		NotAdded:    []song.Song{song.NewSong("another_uri")},
+	})
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+	events := []ProgressEvent{}
+
+	_, err := service.AddSetlist(progressRecorderContext(&events), defaultPlaylistId(), defaultPlaylistArtist())
+
+	expected := []ProgressEvent{
+		{Type: SongsAddedEvent, Artist: defaultArtist(), NumSongs: 1},
+		{Type: SongNotAddedEvent, Artist: defaultArtist(), Uri: "another_uri", Err: addErr},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, events[len(events)-2:])
+}
+
+func TestAddSetlistReportsSongsNotAdded(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetError(errors.New("test error"))
+	playlistRepository.SetAddedSongs(AddedSongs{
+		SnapshotIds: []string{"snapshot"},
+		Added:       []song.Song{song.NewSong("some_uri")},
+		NotAdded:    []song.Song{song.NewSong("another_uri")},
+	})
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"some_uri"}, setlistSongUris(actual.Songs.Added))
+	assert.Equal(t, []string{"another_uri"}, setlistSongUris(actual.Songs.NotAdded))
+}
+
+func TestAddSetlistReturnsErrorIfNoSongsAdded(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetError(errors.New("test error"))
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	_, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
 
 	assert.NotNil(t, err)
 }
+
+func TestAddSetlistReportsSongsNotFound(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	songRepository.SetSongs(songsWithErrors())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.AddSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"another_uri"}, setlistSongUris(actual.Songs.Added))
+	assert.Len(t, actual.Songs.NotFound, 1)
+	assert.Contains(t, []string{"My song", "My other song"}, actual.Songs.NotFound[0].Title)
+}
+
+func TestSyncSetlistReportsSongsAlreadyInPlaylist(t *testing.T) {
+	playlistRepository, setlistRepository, songRepository := testSetup()
+	playlistRepository.SetPlaylistTracks(syncPlaylistTracks())
+	service := NewConcurrentPlaylistService(&playlistRepository, &setlistRepository, &songRepository)
+
+	actual, err := service.SyncSetlist(defaultContext(), defaultPlaylistId(), defaultPlaylistArtist())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"another_uri"}, setlistSongUris(actual.Songs.Added))
+	assert.Equal(t, []string{"some_uri"}, setlistSongUris(actual.Songs.Skipped))
+}
@@ -0,0 +1,87 @@
+package playlist
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Spotify rejects playlist descriptions longer than this
+const MaxDescriptionLength = 300
+
+const DefaultDescriptionTemplate = `Setlists of ` +
+	`{{range $i, $setlist := .Setlists}}{{if $i}}; {{end}}{{$setlist.Artist}}` +
+	`{{with $setlist.Venue}} at {{.}}{{end}}{{with $setlist.Date}} on {{.}}{{end}}{{with $setlist.Tour}} ({{.}}){{end}}` +
+	`{{if eq $setlist.Source "topTracks"}} (top tracks){{end}}` +
+	`{{end}}`
+
+type DescriptionData struct {
+	Name     string
+	Setlists []SetlistProvenance
+}
+
+// Generates playlist descriptions listing where the setlist of each artist comes from
+type DescriptionGenerator struct {
+	template  *template.Template
+	maxLength int
+}
+
+func NewDescriptionGenerator() DescriptionGenerator {
+	return DescriptionGenerator{
+		template:  template.Must(template.New("description").Parse(DefaultDescriptionTemplate)),
+		maxLength: MaxDescriptionLength,
+	}
+}
+
+func (g *DescriptionGenerator) Generate(data DescriptionData) (string, error) {
+	var buffer bytes.Buffer
+	if err := g.template.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return truncateDescription(sanitizeDescription(buffer.String()), g.maxLength), nil
+}
+
+// Template is parsed with text/template, where fields are accessible as {{.Name}} and {{.Setlists}}
+func (g *DescriptionGenerator) SetTemplate(text string) error {
+	parsed, err := template.New("description").Parse(text)
+	if err != nil {
+		return err
+	}
+	g.template = parsed
+	return nil
+}
+
+func (g *DescriptionGenerator) SetMaxLength(maxLength int) {
+	g.maxLength = maxLength
+}
+
+// Spotify rejects descriptions with angle brackets and line breaks, so they are removed
+func sanitizeDescription(description string) string {
+	cleaned := strings.Map(func(char rune) rune {
+		switch {
+		case char == '<' || char == '>':
+			return -1
+		case unicode.IsSpace(char):
+			return ' '
+		case unicode.IsControl(char):
+			return -1
+		default:
+			return char
+		}
+	}, description)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+func truncateDescription(description string, maxLength int) string {
+	runes := []rune(description)
+	if len(runes) <= maxLength {
+		return description
+	}
+
+	ellipsis := "..."
+	if maxLength <= len(ellipsis) {
+		return string(runes[:maxLength])
+	}
+	return strings.TrimSpace(string(runes[:maxLength-len(ellipsis)])) + ellipsis
+}
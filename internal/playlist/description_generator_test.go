@@ -0,0 +1,108 @@
+package playlist
+
+import (
+	"strings"
+	"testing"
+
+	"festwrap/internal/setlist"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func descriptionData() DescriptionData {
+	return DescriptionData{
+		Name: "My festival",
+		Setlists: []SetlistProvenance{
+			{Artist: "The Menzingers", Date: "2024-01-25", Venue: "Gruenspan, Hamburg", Tour: "Some Of It Was True Tour"},
+			{Artist: "Comeback Kid"},
+		},
+	}
+}
+
+func TestGenerateDescriptionWithDefaultTemplate(t *testing.T) {
+	generator := NewDescriptionGenerator()
+
+	actual, err := generator.Generate(descriptionData())
+
+	expected := "Setlists of The Menzingers at Gruenspan, Hamburg on 2024-01-25 (Some Of It Was True Tour); Comeback Kid"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestGenerateDescriptionFlagsTopTracks(t *testing.T) {
+	generator := NewDescriptionGenerator()
+	data := DescriptionData{Setlists: []SetlistProvenance{{Artist: "Militarie Gun", Source: setlist.TopTracksSource}}}
+
+	actual, err := generator.Generate(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Setlists of Militarie Gun (top tracks)", actual)
+}
+
+func TestGenerateDescriptionWithCustomTemplate(t *testing.T) {
+	generator := NewDescriptionGenerator()
+	err := generator.SetTemplate("{{.Name}}: {{range .Setlists}}{{.Artist}} {{end}}")
+	assert.Nil(t, err)
+
+	actual, err := generator.Generate(descriptionData())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "My festival: The Menzingers Comeback Kid", actual)
+}
+
+func TestSetTemplateReturnsErrorOnInvalidTemplate(t *testing.T) {
+	generator := NewDescriptionGenerator()
+
+	err := generator.SetTemplate("{{.Name")
+
+	assert.NotNil(t, err)
+}
+
+func TestGenerateDescriptionRemovesCharactersRejectedBySpotify(t *testing.T) {
+	generator := NewDescriptionGenerator()
+	generator.SetTemplate("<b>{{.Name}}</b>\n\tby\r\n  Festwrap")
+
+	actual, err := generator.Generate(descriptionData())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "bMy festival/b by Festwrap", actual)
+}
+
+func TestGenerateDescriptionTruncatesLongDescriptions(t *testing.T) {
+	tests := map[string]struct {
+		maxLength int
+		expected  string
+	}{
+		"adds ellipsis": {
+			maxLength: 15,
+			expected:  "Setlists of...",
+		},
+		"ellipsis does not fit": {
+			maxLength: 2,
+			expected:  "Se",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			generator := NewDescriptionGenerator()
+			generator.SetMaxLength(test.maxLength)
+
+			actual, err := generator.Generate(descriptionData())
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGenerateDescriptionRespectsSpotifyLimit(t *testing.T) {
+	generator := NewDescriptionGenerator()
+	data := descriptionData()
+	data.Setlists[1].Artist = strings.Repeat("ñ", 2*MaxDescriptionLength)
+
+	actual, err := generator.Generate(data)
+
+	assert.Nil(t, err)
+	assert.Len(t, []rune(actual), MaxDescriptionLength)
+}
@@ -0,0 +1,13 @@
+package errors
+
+type CannotDeletePlaylistError struct {
+	message string
+}
+
+func NewCannotDeletePlaylistError(message string) error {
+	return &CannotDeletePlaylistError{message: message}
+}
+
+func (e *CannotDeletePlaylistError) Error() string {
+	return e.message
+}
@@ -0,0 +1,13 @@
+package errors
+
+type CannotRemoveSongsFromPlaylistError struct {
+	message string
+}
+
+func NewCannotRemoveSongsFromPlaylistError(message string) error {
+	return &CannotRemoveSongsFromPlaylistError{message: message}
+}
+
+func (e *CannotRemoveSongsFromPlaylistError) Error() string {
+	return e.message
+}
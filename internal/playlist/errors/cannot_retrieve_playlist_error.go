@@ -0,0 +1,13 @@
+package errors
+
+type CannotRetrievePlaylistError struct {
+	message string
+}
+
+func NewCannotRetrievePlaylistError(message string) error {
+	return &CannotRetrievePlaylistError{message: message}
+}
+
+func (e *CannotRetrievePlaylistError) Error() string {
+	return e.message
+}
@@ -0,0 +1,13 @@
+package errors
+
+type CannotRetrievePlaylistTracksError struct {
+	message string
+}
+
+func NewCannotRetrievePlaylistTracksError(message string) error {
+	return &CannotRetrievePlaylistTracksError{message: message}
+}
+
+func (e *CannotRetrievePlaylistTracksError) Error() string {
+	return e.message
+}
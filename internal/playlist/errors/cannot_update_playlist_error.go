@@ -0,0 +1,13 @@
+package errors
+
+type CannotUpdatePlaylistError struct {
+	message string
+}
+
+func NewCannotUpdatePlaylistError(message string) error {
+	return &CannotUpdatePlaylistError{message: message}
+}
+
+func (e *CannotUpdatePlaylistError) Error() string {
+	return e.message
+}
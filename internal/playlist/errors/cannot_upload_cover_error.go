@@ -0,0 +1,13 @@
+package errors
+
+type CannotUploadCoverError struct {
+	message string
+}
+
+func NewCannotUploadCoverError(message string) error {
+	return &CannotUploadCoverError{message: message}
+}
+
+func (e *CannotUploadCoverError) Error() string {
+	return e.message
+}
@@ -0,0 +1,13 @@
+package errors
+
+type InvalidPlaylistDetailsError struct {
+	message string
+}
+
+func NewInvalidPlaylistDetailsError(message string) error {
+	return &InvalidPlaylistDetailsError{message: message}
+}
+
+func (e *InvalidPlaylistDetailsError) Error() string {
+	return e.message
+}
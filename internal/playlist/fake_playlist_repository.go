@@ -2,6 +2,7 @@ package playlist
 
 import (
 	"context"
+	"festwrap/internal/pagination"
 	"festwrap/internal/song"
 )
 
@@ -11,28 +12,81 @@ type AddSongsArgs struct {
 	Songs      []song.Song
 }
 
+type ReplaceSongsArgs struct {
+	Context    context.Context
+	PlaylistId string
+	Songs      []song.Song
+}
+
+type RemoveSongsArgs struct {
+	Context    context.Context
+	PlaylistId string
+	SnapshotId string
+	Songs      []song.Song
+}
+
 type CreatePlaylistArgs struct {
 	Context  context.Context
 	Playlist Playlist
 }
 
+type DeletePlaylistArgs struct {
+	Context    context.Context
+	PlaylistId string
+}
+
 type SearchPlaylistArgs struct {
 	Context      context.Context
 	PlaylistName string
+	Offset       int
 	Limit        int
 }
 
+type GetPlaylistTracksArgs struct {
+	Context    context.Context
+	PlaylistId string
+}
+
+type GetPlaylistArgs struct {
+	Context    context.Context
+	PlaylistId string
+	Offset     int
+	Limit      int
+}
+
+type UpdatePlaylistDetailsArgs struct {
+	Context    context.Context
+	PlaylistId string
+	Details    PlaylistDetails
+}
+
+type UploadCoverArgs struct {
+	Context    context.Context
+	PlaylistId string
+	JpegImage  []byte
+}
+
 type FakePlaylistRepository struct {
-	addSongArgs        AddSongsArgs
-	createPlaylistArgs CreatePlaylistArgs
-	searchPlaylistArgs SearchPlaylistArgs
-	searchedPlaylists  []Playlist
-	createdPlaylistId  string
-	err                error
+	addSongArgs           AddSongsArgs
+	replaceSongsArgs      ReplaceSongsArgs
+	removeSongsArgs       RemoveSongsArgs
+	createPlaylistArgs    CreatePlaylistArgs
+	deletePlaylistArgs    DeletePlaylistArgs
+	searchUserArgs        SearchPlaylistArgs
+	getPlaylistTracksArgs GetPlaylistTracksArgs
+	getPlaylistArgs       GetPlaylistArgs
+	uploadCoverArgs       UploadCoverArgs
+	updateDetailsArgs     UpdatePlaylistDetailsArgs
+	searchedPlaylists     []Playlist
+	playlistTracks        PlaylistTracks
+	playlist              PlaylistWithTracks
+	addedSongs            *AddedSongs
+	createdPlaylistId     string
+	err                   error
 }
 
 func NewFakePlaylistRepository() FakePlaylistRepository {
-	return FakePlaylistRepository{searchedPlaylists: []Playlist{}}
+	return FakePlaylistRepository{searchedPlaylists: []Playlist{}, playlistTracks: PlaylistTracks{Tracks: []PlaylistTrack{}}}
 }
 
 func (s *FakePlaylistRepository) CreatePlaylist(ctx context.Context, playlist Playlist) (string, error) {
@@ -40,15 +94,64 @@ func (s *FakePlaylistRepository) CreatePlaylist(ctx context.Context, playlist Pl
 	return s.createdPlaylistId, s.err
 }
 
-func (s *FakePlaylistRepository) SearchPlaylist(
-	ctx context.Context, playlistName string, limit int,
-) ([]Playlist, error) {
-	s.searchPlaylistArgs = SearchPlaylistArgs{Context: ctx, PlaylistName: playlistName, Limit: limit}
-	return s.searchedPlaylists, s.err
+func (s *FakePlaylistRepository) DeletePlaylist(ctx context.Context, playlistId string) error {
+	s.deletePlaylistArgs = DeletePlaylistArgs{Context: ctx, PlaylistId: playlistId}
+	return s.err
+}
+
+func (s *FakePlaylistRepository) SearchUserPlaylists(
+	ctx context.Context, playlistName string, offset int, limit int,
+) (pagination.Page[Playlist], error) {
+	s.searchUserArgs = SearchPlaylistArgs{Context: ctx, PlaylistName: playlistName, Offset: offset, Limit: limit}
+	return pagination.NewPage(s.searchedPlaylists, offset, len(s.searchedPlaylists)), s.err
 }
 
-func (s *FakePlaylistRepository) AddSongs(ctx context.Context, playlistId string, songs []song.Song) error {
+func (s *FakePlaylistRepository) AddSongs(
+	ctx context.Context, playlistId string, songs []song.Song,
+) (AddedSongs, error) {
 	s.addSongArgs = AddSongsArgs{Context: ctx, PlaylistId: playlistId, Songs: songs}
+	if s.addedSongs != nil {
+		return *s.addedSongs, s.err
+	}
+	if s.err != nil {
+		return AddedSongs{SnapshotIds: []string{}, Added: []song.Song{}, NotAdded: songs}, s.err
+	}
+	return AddedSongs{SnapshotIds: []string{"snapshot"}, Added: songs, NotAdded: []song.Song{}}, nil
+}
+
+func (s *FakePlaylistRepository) ReplaceSongs(ctx context.Context, playlistId string, songs []song.Song) error {
+	s.replaceSongsArgs = ReplaceSongsArgs{Context: ctx, PlaylistId: playlistId, Songs: songs}
+	return s.err
+}
+
+func (s *FakePlaylistRepository) RemoveSongs(
+	ctx context.Context, playlistId string, snapshotId string, songs []song.Song,
+) error {
+	s.removeSongsArgs = RemoveSongsArgs{Context: ctx, PlaylistId: playlistId, SnapshotId: snapshotId, Songs: songs}
+	return s.err
+}
+
+func (s *FakePlaylistRepository) GetPlaylistTracks(ctx context.Context, playlistId string) (PlaylistTracks, error) {
+	s.getPlaylistTracksArgs = GetPlaylistTracksArgs{Context: ctx, PlaylistId: playlistId}
+	return s.playlistTracks, s.err
+}
+
+func (s *FakePlaylistRepository) GetPlaylist(
+	ctx context.Context, playlistId string, offset int, limit int,
+) (PlaylistWithTracks, error) {
+	s.getPlaylistArgs = GetPlaylistArgs{Context: ctx, PlaylistId: playlistId, Offset: offset, Limit: limit}
+	return s.playlist, s.err
+}
+
+func (s *FakePlaylistRepository) UpdatePlaylistDetails(
+	ctx context.Context, playlistId string, details PlaylistDetails,
+) error {
+	s.updateDetailsArgs = UpdatePlaylistDetailsArgs{Context: ctx, PlaylistId: playlistId, Details: details}
+	return s.err
+}
+
+func (s *FakePlaylistRepository) UploadCover(ctx context.Context, playlistId string, jpegImage []byte) error {
+	s.uploadCoverArgs = UploadCoverArgs{Context: ctx, PlaylistId: playlistId, JpegImage: jpegImage}
 	return s.err
 }
 
@@ -60,18 +163,59 @@ func (s *FakePlaylistRepository) GetAddSongArgs() AddSongsArgs {
 	return s.addSongArgs
 }
 
+func (s *FakePlaylistRepository) GetReplaceSongsArgs() ReplaceSongsArgs {
+	return s.replaceSongsArgs
+}
+
+func (s *FakePlaylistRepository) GetRemoveSongsArgs() RemoveSongsArgs {
+	return s.removeSongsArgs
+}
+
 func (s *FakePlaylistRepository) GetCreatePlaylistArgs() CreatePlaylistArgs {
 	return s.createPlaylistArgs
 }
 
-func (s *FakePlaylistRepository) GetSearchPlaylistArgs() SearchPlaylistArgs {
-	return s.searchPlaylistArgs
+func (s *FakePlaylistRepository) GetDeletePlaylistArgs() DeletePlaylistArgs {
+	return s.deletePlaylistArgs
+}
+
+func (s *FakePlaylistRepository) GetSearchUserPlaylistsArgs() SearchPlaylistArgs {
+	return s.searchUserArgs
+}
+
+func (s *FakePlaylistRepository) GetGetPlaylistTracksArgs() GetPlaylistTracksArgs {
+	return s.getPlaylistTracksArgs
+}
+
+func (s *FakePlaylistRepository) GetGetPlaylistArgs() GetPlaylistArgs {
+	return s.getPlaylistArgs
+}
+
+func (s *FakePlaylistRepository) GetUpdatePlaylistDetailsArgs() UpdatePlaylistDetailsArgs {
+	return s.updateDetailsArgs
+}
+
+func (s *FakePlaylistRepository) GetUploadCoverArgs() UploadCoverArgs {
+	return s.uploadCoverArgs
 }
 
 func (s *FakePlaylistRepository) SetSearchedPlaylists(playlists []Playlist) {
 	s.searchedPlaylists = playlists
 }
 
+func (s *FakePlaylistRepository) SetPlaylist(playlist PlaylistWithTracks) {
+	s.playlist = playlist
+}
+
+func (s *FakePlaylistRepository) SetPlaylistTracks(tracks PlaylistTracks) {
+	s.playlistTracks = tracks
+}
+
+// Overrides the result of adding songs, which by default depends on the configured error
+func (s *FakePlaylistRepository) SetAddedSongs(added AddedSongs) {
+	s.addedSongs = &added
+}
+
 func (s *FakePlaylistRepository) SetCreatedPlaylistId(id string) {
 	s.createdPlaylistId = id
 }
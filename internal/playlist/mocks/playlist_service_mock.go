@@ -20,6 +20,60 @@ func (s *PlaylistServiceMock) CreatePlaylist(ctx context.Context, playlistInput
 	return args.String(0), args.Error(1)
 }
 
-func (s *PlaylistServiceMock) AddSetlist(ctx context.Context, playlistId string, artist string) error {
-	return s.Called(ctx, playlistId, artist).Error(0)
+func (s *PlaylistServiceMock) DeletePlaylist(ctx context.Context, playlistId string) error {
+	return s.Called(ctx, playlistId).Error(0)
+}
+
+func (s *PlaylistServiceMock) GetPlaylist(
+	ctx context.Context,
+	playlistId string,
+	offset int,
+	limit int,
+) (playlist.PlaylistWithTracks, error) {
+	args := s.Called(ctx, playlistId, offset, limit)
+	return args.Get(0).(playlist.PlaylistWithTracks), args.Error(1)
+}
+
+func (s *PlaylistServiceMock) UpdatePlaylistDetails(
+	ctx context.Context,
+	playlistId string,
+	details playlist.PlaylistDetails,
+) error {
+	return s.Called(ctx, playlistId, details).Error(0)
+}
+
+func (s *PlaylistServiceMock) AddSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist playlist.PlaylistArtist,
+) (playlist.SetlistProvenance, error) {
+	args := s.Called(ctx, playlistId, artist)
+	return args.Get(0).(playlist.SetlistProvenance), args.Error(1)
+}
+
+func (s *PlaylistServiceMock) ReplaceSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist playlist.PlaylistArtist,
+) (playlist.SetlistProvenance, error) {
+	args := s.Called(ctx, playlistId, artist)
+	return args.Get(0).(playlist.SetlistProvenance), args.Error(1)
+}
+
+func (s *PlaylistServiceMock) SyncSetlist(
+	ctx context.Context,
+	playlistId string,
+	artist playlist.PlaylistArtist,
+) (playlist.SetlistProvenance, error) {
+	args := s.Called(ctx, playlistId, artist)
+	return args.Get(0).(playlist.SetlistProvenance), args.Error(1)
+}
+
+func (s *PlaylistServiceMock) RemoveArtist(
+	ctx context.Context,
+	playlistId string,
+	artist string,
+) ([]playlist.PlaylistTrack, error) {
+	args := s.Called(ctx, playlistId, artist)
+	return args.Get(0).([]playlist.PlaylistTrack), args.Error(1)
 }
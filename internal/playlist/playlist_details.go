@@ -0,0 +1,68 @@
+package playlist
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"festwrap/internal/playlist/errors"
+)
+
+// Spotify does not document it, but longer names are rejected by its clients
+const MaxNameLength = 100
+
+// Playlist fields to be modified. Fields left nil are not changed
+type PlaylistDetails struct {
+	Name          *string `json:"name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	IsPublic      *bool   `json:"isPublic,omitempty"`
+	Collaborative *bool   `json:"collaborative,omitempty"`
+}
+
+// Returns the details with the surrounding spaces of the name removed, so they are not sent to Spotify
+func (d PlaylistDetails) Trimmed() PlaylistDetails {
+	if d.Name != nil {
+		name := strings.TrimSpace(*d.Name)
+		d.Name = &name
+	}
+	return d
+}
+
+// Checks the details follow the rules Spotify applies to playlists, so invalid updates are not sent
+func (d PlaylistDetails) Validate() error {
+	if d.Name == nil && d.Description == nil && d.IsPublic == nil && d.Collaborative == nil {
+		return errors.NewInvalidPlaylistDetailsError("at least one field must be provided")
+	}
+
+	if d.Name != nil {
+		name := strings.TrimSpace(*d.Name)
+		if name == "" {
+			return errors.NewInvalidPlaylistDetailsError("name cannot be empty")
+		}
+		if utf8.RuneCountInString(name) > MaxNameLength {
+			return errors.NewInvalidPlaylistDetailsError(fmt.Sprintf("name cannot exceed %d characters", MaxNameLength))
+		}
+	}
+
+	if d.Description != nil {
+		if utf8.RuneCountInString(*d.Description) > MaxDescriptionLength {
+			message := fmt.Sprintf("description cannot exceed %d characters", MaxDescriptionLength)
+			return errors.NewInvalidPlaylistDetailsError(message)
+		}
+		if strings.ContainsFunc(*d.Description, isRejectedDescriptionChar) {
+			return errors.NewInvalidPlaylistDetailsError("description cannot contain angle brackets or line breaks")
+		}
+	}
+
+	// Spotify only allows collaborative playlists if they are not public
+	if d.Collaborative != nil && *d.Collaborative && (d.IsPublic == nil || *d.IsPublic) {
+		return errors.NewInvalidPlaylistDetailsError("collaborative playlists must set isPublic to false")
+	}
+
+	return nil
+}
+
+func isRejectedDescriptionChar(char rune) bool {
+	return char == '<' || char == '>' || unicode.IsControl(char)
+}
@@ -0,0 +1,95 @@
+package playlist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func TestPlaylistDetailsValidate(t *testing.T) {
+	tests := map[string]struct {
+		details PlaylistDetails
+		valid   bool
+	}{
+		"only name": {
+			details: PlaylistDetails{Name: stringPointer("Hellfest 2025")},
+			valid:   true,
+		},
+		"only visibility": {
+			details: PlaylistDetails{IsPublic: boolPointer(true)},
+			valid:   true,
+		},
+		"private collaborative": {
+			details: PlaylistDetails{IsPublic: boolPointer(false), Collaborative: boolPointer(true)},
+			valid:   true,
+		},
+		"non collaborative": {
+			details: PlaylistDetails{Collaborative: boolPointer(false)},
+			valid:   true,
+		},
+		"description with max length": {
+			details: PlaylistDetails{Description: stringPointer(strings.Repeat("á", MaxDescriptionLength))},
+			valid:   true,
+		},
+		"no fields": {
+			details: PlaylistDetails{},
+			valid:   false,
+		},
+		"blank name": {
+			details: PlaylistDetails{Name: stringPointer("  ")},
+			valid:   false,
+		},
+		"name too long": {
+			details: PlaylistDetails{Name: stringPointer(strings.Repeat("a", MaxNameLength+1))},
+			valid:   false,
+		},
+		"description too long": {
+			details: PlaylistDetails{Description: stringPointer(strings.Repeat("a", MaxDescriptionLength+1))},
+			valid:   false,
+		},
+		"description with angle brackets": {
+			details: PlaylistDetails{Description: stringPointer("<b>Hellfest</b>")},
+			valid:   false,
+		},
+		"description with line breaks": {
+			details: PlaylistDetails{Description: stringPointer("Hellfest\n2025")},
+			valid:   false,
+		},
+		"collaborative without visibility": {
+			details: PlaylistDetails{Collaborative: boolPointer(true)},
+			valid:   false,
+		},
+		"public collaborative": {
+			details: PlaylistDetails{IsPublic: boolPointer(true), Collaborative: boolPointer(true)},
+			valid:   false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.details.Validate()
+
+			assert.Equal(t, test.valid, err == nil)
+		})
+	}
+}
+
+func TestPlaylistDetailsTrimmedRemovesSpacesAroundName(t *testing.T) {
+	details := PlaylistDetails{Name: stringPointer("  Hellfest 2025 "), Description: stringPointer(" Metal ")}
+
+	actual := details.Trimmed()
+
+	expected := PlaylistDetails{Name: stringPointer("Hellfest 2025"), Description: stringPointer(" Metal ")}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "  Hellfest 2025 ", *details.Name)
+}
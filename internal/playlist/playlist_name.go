@@ -0,0 +1,12 @@
+package playlist
+
+import (
+	"strings"
+
+	"festwrap/internal/text"
+)
+
+// Reports whether the playlist name contains the query, ignoring case and accents
+func NameMatches(name string, query string) bool {
+	return strings.Contains(text.Normalize(name), text.Normalize(query))
+}
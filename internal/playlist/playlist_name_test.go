@@ -0,0 +1,68 @@
+package playlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameMatches(t *testing.T) {
+	tests := map[string]struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		"same name": {
+			name:     "My festival",
+			query:    "My festival",
+			expected: true,
+		},
+		"partial name": {
+			name:     "My festival",
+			query:    "festival",
+			expected: true,
+		},
+		"different case": {
+			name:     "My Festival",
+			query:    "my FESTIVAL",
+			expected: true,
+		},
+		"accents in name": {
+			name:     "Resurrección Fest",
+			query:    "resurreccion",
+			expected: true,
+		},
+		"accents in query": {
+			name:     "Resurreccion Fest",
+			query:    "RESURRECCIÓN",
+			expected: true,
+		},
+		"decomposed accents in query": {
+			name:     "Resurrección Fest",
+			query:    "resurreccio\u0301n",
+			expected: true,
+		},
+		"accents outside latin-1": {
+			name:     "Şebnem Ferah",
+			query:    "sebnem",
+			expected: true,
+		},
+		"surrounding spaces in query": {
+			name:     "Hellfest",
+			query:    " hellfest ",
+			expected: true,
+		},
+		"different name": {
+			name:     "Hellfest",
+			query:    "Wacken",
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, NameMatches(test.name, test.query))
+		})
+	}
+}
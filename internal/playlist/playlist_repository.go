@@ -2,11 +2,19 @@ package playlist
 
 import (
 	"context"
+	"festwrap/internal/pagination"
 	"festwrap/internal/song"
 )
 
 type PlaylistRepository interface {
 	CreatePlaylist(ctx context.Context, playlist Playlist) (string, error)
-	SearchPlaylist(ctx context.Context, name string, limit int) ([]Playlist, error)
-	AddSongs(ctx context.Context, playlistId string, songs []song.Song) error
+	DeletePlaylist(ctx context.Context, playlistId string) error
+	UpdatePlaylistDetails(ctx context.Context, playlistId string, details PlaylistDetails) error
+	SearchUserPlaylists(ctx context.Context, name string, offset int, limit int) (pagination.Page[Playlist], error)
+	AddSongs(ctx context.Context, playlistId string, songs []song.Song) (AddedSongs, error)
+	ReplaceSongs(ctx context.Context, playlistId string, songs []song.Song) error
+	RemoveSongs(ctx context.Context, playlistId string, snapshotId string, songs []song.Song) error
+	GetPlaylistTracks(ctx context.Context, playlistId string) (PlaylistTracks, error)
+	GetPlaylist(ctx context.Context, playlistId string, offset int, limit int) (PlaylistWithTracks, error)
+	UploadCover(ctx context.Context, playlistId string, jpegImage []byte) error
 }
@@ -4,5 +4,11 @@ import "context"
 
 type PlaylistService interface {
 	CreatePlaylist(ctx context.Context, playlist Playlist) (string, error)
-	AddSetlist(ctx context.Context, playlistId string, artist string) error
+	DeletePlaylist(ctx context.Context, playlistId string) error
+	GetPlaylist(ctx context.Context, playlistId string, offset int, limit int) (PlaylistWithTracks, error)
+	UpdatePlaylistDetails(ctx context.Context, playlistId string, details PlaylistDetails) error
+	AddSetlist(ctx context.Context, playlistId string, artist PlaylistArtist) (SetlistProvenance, error)
+	ReplaceSetlist(ctx context.Context, playlistId string, artist PlaylistArtist) (SetlistProvenance, error)
+	SyncSetlist(ctx context.Context, playlistId string, artist PlaylistArtist) (SetlistProvenance, error)
+	RemoveArtist(ctx context.Context, playlistId string, artist string) ([]PlaylistTrack, error)
 }
@@ -0,0 +1,42 @@
+package playlist
+
+import (
+	"strings"
+
+	"festwrap/internal/song"
+)
+
+type PlaylistTrack struct {
+	Uri        string   `json:"uri"`
+	Name       string   `json:"name"`
+	Artists    []string `json:"artists"`
+	DurationMs int      `json:"durationMs,omitempty"`
+}
+
+func (t PlaylistTrack) HasArtist(artist string) bool {
+	for _, trackArtist := range t.Artists {
+		if strings.EqualFold(trackArtist, artist) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t PlaylistTrack) ToSong() song.Song {
+	return song.NewSong(t.Uri)
+}
+
+type PlaylistTracks struct {
+	SnapshotId string
+	Tracks     []PlaylistTrack
+}
+
+func (t PlaylistTracks) GetArtistTracks(artist string) []PlaylistTrack {
+	artistTracks := []PlaylistTrack{}
+	for _, track := range t.Tracks {
+		if track.HasArtist(artist) {
+			artistTracks = append(artistTracks, track)
+		}
+	}
+	return artistTracks
+}
@@ -1,16 +1,55 @@
 package playlist
 
 import (
+	"fmt"
 	"net/http"
 )
 
+type UpdateMode string
+
+const (
+	// Adds the setlist songs at the end of the playlist
+	AppendMode UpdateMode = "append"
+	// Overwrites the playlist content with the setlist songs
+	ReplaceMode UpdateMode = "replace"
+	// Adds or removes the songs of the setlist artist, keeping the rest of the tracks
+	SyncMode UpdateMode = "sync"
+)
+
+func NewUpdateMode(value string) (UpdateMode, error) {
+	switch mode := UpdateMode(value); mode {
+	case "":
+		return AppendMode, nil
+	case AppendMode, ReplaceMode, SyncMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown update mode %s", value)
+	}
+}
+
 type PlaylistArtist struct {
 	Name string
+	// Optional Spotify id, used to discard songs of other artists with similar names
+	SpotifyId string
+	// Optional MusicBrainz id, used to search the setlist instead of the name
+	MusicBrainzId string
+	// Name of the requested artist this one was added for, empty for requested artists
+	RelatedTo string
 }
 
 type PlaylistUpdate struct {
 	PlaylistId string
 	Artists    []PlaylistArtist
+	Mode       UpdateMode
+	// Playlist to create before updating it, nil when updating an existing one
+	NewPlaylist *Playlist
+	// Whether the playlist was created for this update, so it can be removed if nothing is added to it
+	Created             bool
+	Name                string
+	GenerateCover       bool
+	GenerateDescription bool
+	// Number of artists related to the requested ones to add to the playlist
+	RelatedArtists int
 }
 
 type PlaylistUpdateBuilder interface {
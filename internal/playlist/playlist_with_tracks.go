@@ -0,0 +1,24 @@
+package playlist
+
+type PlaylistOwner struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type PlaylistTracksPage struct {
+	Items  []PlaylistTrack `json:"items"`
+	Offset int             `json:"offset"`
+	Limit  int             `json:"limit"`
+	Total  int             `json:"total"`
+}
+
+// Playlist metadata along with a page of its tracks
+type PlaylistWithTracks struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	IsPublic    bool               `json:"isPublic"`
+	Owner       PlaylistOwner      `json:"owner"`
+	SnapshotId  string             `json:"snapshotId"`
+	Tracks      PlaylistTracksPage `json:"tracks"`
+}
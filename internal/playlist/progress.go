@@ -0,0 +1,43 @@
+package playlist
+
+import "context"
+
+type ProgressEventType string
+
+const (
+	SetlistFoundEvent    ProgressEventType = "setlistFound"
+	SetlistNotFoundEvent ProgressEventType = "setlistNotFound"
+	SongMatchedEvent     ProgressEventType = "songMatched"
+	SongNotMatchedEvent  ProgressEventType = "songNotMatched"
+	SongsAddedEvent      ProgressEventType = "songsAdded"
+	SongNotAddedEvent    ProgressEventType = "songNotAdded"
+	SongsRemovedEvent    ProgressEventType = "songsRemoved"
+)
+
+type ProgressEvent struct {
+	Type     ProgressEventType `json:"-"`
+	Artist   string            `json:"artist"`
+	Title    string            `json:"title,omitempty"`
+	Uri      string            `json:"uri,omitempty"`
+	NumSongs int               `json:"numSongs,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	// Error which caused the event. Its message may include the credentials of the requests sent,
+	// so listeners sending the event to clients should fill the error with a safe description
+	Err error `json:"-"`
+}
+
+type ProgressListener func(event ProgressEvent)
+
+type progressListenerKey struct{}
+
+// Returns a context where the given listener is notified about the progress of the playlist updates
+func WithProgressListener(ctx context.Context, listener ProgressListener) context.Context {
+	return context.WithValue(ctx, progressListenerKey{}, listener)
+}
+
+func NotifyProgress(ctx context.Context, event ProgressEvent) {
+	listener, ok := ctx.Value(progressListenerKey{}).(ProgressListener)
+	if ok && listener != nil {
+		listener(event)
+	}
+}
@@ -0,0 +1,126 @@
+package playlist
+
+import (
+	"context"
+	"fmt"
+
+	"festwrap/internal/artist"
+	"festwrap/internal/text"
+)
+
+type LineupExpander interface {
+	Expand(ctx context.Context, lineup []PlaylistArtist, n int) ([]PlaylistArtist, error)
+}
+
+// Extends lineups with the artists Spotify considers similar to the ones in them
+type RelatedArtistsExpander struct {
+	artistRepository artist.ArtistRepository
+}
+
+func NewRelatedArtistsExpander(artistRepository artist.ArtistRepository) RelatedArtistsExpander {
+	return RelatedArtistsExpander{artistRepository: artistRepository}
+}
+
+// Returns the lineup followed by up to n related artists. Related artists are picked in turns
+// for each artist of the lineup, skipping the ones already included. Artists whose related artists
+// cannot be retrieved are ignored, so it only fails if none of them can be retrieved
+func (e *RelatedArtistsExpander) Expand(
+	ctx context.Context,
+	lineup []PlaylistArtist,
+	n int,
+) ([]PlaylistArtist, error) {
+	result := append([]PlaylistArtist{}, lineup...)
+	if n <= 0 || len(lineup) == 0 {
+		return result, nil
+	}
+
+	candidates := make([][]artist.Artist, len(lineup))
+	var lastErr error
+	failures := 0
+	for i, lineupArtist := range lineup {
+		related, err := e.getRelatedArtists(ctx, lineupArtist)
+		if err != nil {
+			lastErr = err
+			failures += 1
+			continue
+		}
+		candidates[i] = related
+	}
+	if failures == len(lineup) {
+		return result, fmt.Errorf("could not retrieve related artists: %w", lastErr)
+	}
+
+	included := newIncludedArtists(lineup)
+	next := make([]int, len(lineup))
+	for added := 0; added < n; {
+		found := false
+		for i := 0; i < len(lineup) && added < n; i++ {
+			candidate, ok := nextCandidate(candidates[i], &next[i], included)
+			if !ok {
+				continue
+			}
+			found = true
+			added += 1
+			relatedArtist := PlaylistArtist{Name: candidate.Name, SpotifyId: candidate.Id, RelatedTo: lineup[i].Name}
+			included.add(relatedArtist)
+			result = append(result, relatedArtist)
+		}
+		if !found {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Related artists are searched by Spotify id, which is looked up by name if not provided
+func (e *RelatedArtistsExpander) getRelatedArtists(
+	ctx context.Context,
+	lineupArtist PlaylistArtist,
+) ([]artist.Artist, error) {
+	if lineupArtist.SpotifyId != "" {
+		return e.artistRepository.GetRelatedArtists(ctx, lineupArtist.SpotifyId)
+	}
+
+	artistId, err := artist.FindArtistId(ctx, e.artistRepository, lineupArtist.Name)
+	if err != nil {
+		return nil, err
+	}
+	return e.artistRepository.GetRelatedArtists(ctx, artistId)
+}
+
+// Advances the position until an artist not included yet is found
+func nextCandidate(candidates []artist.Artist, position *int, included includedArtists) (artist.Artist, bool) {
+	for *position < len(candidates) {
+		candidate := candidates[*position]
+		*position += 1
+		if !included.contains(candidate.Id, candidate.Name) {
+			return candidate, true
+		}
+	}
+	return artist.Artist{}, false
+}
+
+// Artists are considered the same if they share Spotify id or name, since lineups may not include ids
+type includedArtists struct {
+	ids   map[string]bool
+	names map[string]bool
+}
+
+func newIncludedArtists(lineup []PlaylistArtist) includedArtists {
+	included := includedArtists{ids: map[string]bool{}, names: map[string]bool{}}
+	for _, lineupArtist := range lineup {
+		included.add(lineupArtist)
+	}
+	return included
+}
+
+func (i includedArtists) add(playlistArtist PlaylistArtist) {
+	if playlistArtist.SpotifyId != "" {
+		i.ids[playlistArtist.SpotifyId] = true
+	}
+	i.names[text.Normalize(playlistArtist.Name)] = true
+}
+
+func (i includedArtists) contains(id string, name string) bool {
+	return (id != "" && i.ids[id]) || i.names[text.Normalize(name)]
+}
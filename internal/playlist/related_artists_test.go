@@ -0,0 +1,125 @@
+package playlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"festwrap/internal/artist"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func relatedArtist(id string, name string) artist.Artist {
+	result := artist.NewArtist(name)
+	result.Id = id
+	return result
+}
+
+func relatedArtistsSetup() *artist.FakeArtistRepository {
+	repository := &artist.FakeArtistRepository{}
+	repository.SetSearchReturnValue([]artist.Artist{relatedArtist("touche", "Touché Amoré")})
+	repository.SetRelatedArtists("pianos", []artist.Artist{
+		relatedArtist("lahd", "La Dispute"),
+		relatedArtist("touche", "Touché Amoré"),
+		relatedArtist("defeater", "Defeater"),
+	})
+	repository.SetRelatedArtists("touche", []artist.Artist{
+		relatedArtist("pianos", "Pianos Become the Teeth"),
+		relatedArtist("lahd", "La Dispute"),
+		relatedArtist("ttng", "This Town Needs Guns"),
+	})
+	return repository
+}
+
+func relatedArtistsLineup() []PlaylistArtist {
+	return []PlaylistArtist{
+		{Name: "Pianos Become the Teeth", SpotifyId: "pianos"},
+		{Name: "Touche Amore"},
+	}
+}
+
+func TestExpandAddsRelatedArtistsInTurns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		n        int
+		expected []PlaylistArtist
+	}{
+		"no related artists": {
+			n:        0,
+			expected: relatedArtistsLineup(),
+		},
+		"fewer than available": {
+			n: 2,
+			expected: append(
+				relatedArtistsLineup(),
+				PlaylistArtist{Name: "La Dispute", SpotifyId: "lahd", RelatedTo: "Pianos Become the Teeth"},
+				PlaylistArtist{Name: "This Town Needs Guns", SpotifyId: "ttng", RelatedTo: "Touche Amore"},
+			),
+		},
+		"more than available": {
+			n: 10,
+			expected: append(
+				relatedArtistsLineup(),
+				PlaylistArtist{Name: "La Dispute", SpotifyId: "lahd", RelatedTo: "Pianos Become the Teeth"},
+				PlaylistArtist{Name: "This Town Needs Guns", SpotifyId: "ttng", RelatedTo: "Touche Amore"},
+				PlaylistArtist{Name: "Defeater", SpotifyId: "defeater", RelatedTo: "Pianos Become the Teeth"},
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			expander := NewRelatedArtistsExpander(relatedArtistsSetup())
+
+			actual, err := expander.Expand(context.Background(), relatedArtistsLineup(), test.n)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestExpandLooksUpIdsOfArtistsWithoutIt(t *testing.T) {
+	repository := relatedArtistsSetup()
+	expander := NewRelatedArtistsExpander(repository)
+
+	_, err := expander.Expand(context.Background(), relatedArtistsLineup(), 1)
+
+	expected := []artist.GetRelatedArtistsArgs{
+		{Context: context.Background(), ArtistId: "pianos"},
+		{Context: context.Background(), ArtistId: "touche"},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, repository.GetGetRelatedArtistsArgs())
+	assert.Equal(t, "Touche Amore", repository.GetSearchArtistArgs().Name)
+}
+
+func TestExpandSkipsArtistsWhoseIdIsNotFound(t *testing.T) {
+	repository := relatedArtistsSetup()
+	repository.SetSearchReturnValue([]artist.Artist{relatedArtist("other", "Some other band")})
+	expander := NewRelatedArtistsExpander(repository)
+
+	actual, err := expander.Expand(context.Background(), relatedArtistsLineup(), 2)
+
+	expected := append(
+		relatedArtistsLineup(),
+		PlaylistArtist{Name: "La Dispute", SpotifyId: "lahd", RelatedTo: "Pianos Become the Teeth"},
+		PlaylistArtist{Name: "Defeater", SpotifyId: "defeater", RelatedTo: "Pianos Become the Teeth"},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestExpandReturnsErrorIfNoRelatedArtistsRetrieved(t *testing.T) {
+	repository := relatedArtistsSetup()
+	repository.SetRelatedArtistsError(errors.New("test error"))
+	expander := NewRelatedArtistsExpander(repository)
+
+	actual, err := expander.Expand(context.Background(), relatedArtistsLineup(), 2)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, relatedArtistsLineup(), actual)
+}
@@ -0,0 +1,30 @@
+package playlist
+
+import "festwrap/internal/setlist"
+
+const provenanceDateLayout = "2006-01-02"
+
+// Describes the concert the songs added for an artist come from
+type SetlistProvenance struct {
+	Artist string `json:"artist"`
+	Date   string `json:"date,omitempty"`
+	Venue  string `json:"venue,omitempty"`
+	Tour   string `json:"tour,omitempty"`
+	// Whether songs come from a concert or from the artist top tracks
+	Source setlist.Source `json:"source,omitempty"`
+	// Outcome of adding each of the setlist songs to the playlist
+	Songs *SetlistSongs `json:"songs,omitempty"`
+}
+
+func NewSetlistProvenance(artist string, setlist setlist.Setlist) SetlistProvenance {
+	provenance := SetlistProvenance{
+		Artist: artist,
+		Venue:  setlist.GetVenue(),
+		Tour:   setlist.GetTour(),
+		Source: setlist.GetSource(),
+	}
+	if !setlist.GetDate().IsZero() {
+		provenance.Date = setlist.GetDate().Format(provenanceDateLayout)
+	}
+	return provenance
+}
@@ -0,0 +1,28 @@
+package playlist
+
+import "festwrap/internal/song"
+
+// Song of a setlist, along with the Spotify track matched for it, if any
+type SetlistSong struct {
+	Title string `json:"title"`
+	Uri   string `json:"uri,omitempty"`
+}
+
+// Songs of a setlist grouped by what happened to them during the update
+type SetlistSongs struct {
+	Added []SetlistSong `json:"added,omitempty"`
+	// Already in the playlist, so they were not added again
+	Skipped []SetlistSong `json:"skipped,omitempty"`
+	// Matched in Spotify but could not be added to the playlist
+	NotAdded []SetlistSong `json:"notAdded,omitempty"`
+	// Not matched with any Spotify track
+	NotFound []SetlistSong `json:"notFound,omitempty"`
+}
+
+func toSongs(setlistSongs []SetlistSong) []song.Song {
+	songs := make([]song.Song, len(setlistSongs))
+	for i, setlistSong := range setlistSongs {
+		songs[i] = song.NewSong(setlistSong.Uri)
+	}
+	return songs
+}
@@ -1,7 +1,10 @@
 package spotify
 
+import "festwrap/internal/playlist"
+
 type SpotifyPlaylistOwnerMetadata struct {
-	Id string `json:"id"`
+	Id          string `json:"id"`
+	DisplayName string `json:"display_name"`
 }
 
 type SpotifySearchPlaylist struct {
@@ -9,13 +12,46 @@ type SpotifySearchPlaylist struct {
 	Description   string                       `json:"description"`
 	Name          string                       `json:"name"`
 	Public        bool                         `json:"public"`
+	Collaborative bool                         `json:"collaborative"`
 	OwnerMetadata SpotifyPlaylistOwnerMetadata `json:"owner"`
 }
 
-type SpotifySearchPlaylists struct {
+type SpotifyUserPlaylistsResponse struct {
 	Items []SpotifySearchPlaylist `json:"items"`
+	Total int                     `json:"total"`
+}
+
+// Users can edit the playlists they own and the collaborative ones they follow
+func (p SpotifySearchPlaylist) IsEditableBy(userId string) bool {
+	return p.OwnerMetadata.Id == userId || p.Collaborative
+}
+
+func (p SpotifySearchPlaylist) ToPlaylist() playlist.Playlist {
+	return playlist.Playlist{
+		Id:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		IsPublic:    p.Public,
+	}
+}
+
+type SpotifyPlaylistMetadataResponse struct {
+	Id            string                       `json:"id"`
+	Name          string                       `json:"name"`
+	Description   string                       `json:"description"`
+	Public        bool                         `json:"public"`
+	SnapshotId    string                       `json:"snapshot_id"`
+	OwnerMetadata SpotifyPlaylistOwnerMetadata `json:"owner"`
 }
 
-type SpotifySearchPlaylistResponse struct {
-	Playlists SpotifySearchPlaylists `json:"playlists"`
+func (r SpotifyPlaylistMetadataResponse) ToPlaylistWithTracks(tracks playlist.PlaylistTracksPage) playlist.PlaylistWithTracks {
+	return playlist.PlaylistWithTracks{
+		Id:          r.Id,
+		Name:        r.Name,
+		Description: r.Description,
+		IsPublic:    r.Public,
+		Owner:       playlist.PlaylistOwner{Id: r.OwnerMetadata.Id, Name: r.OwnerMetadata.DisplayName},
+		SnapshotId:  r.SnapshotId,
+		Tracks:      tracks,
+	}
 }
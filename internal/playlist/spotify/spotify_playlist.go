@@ -5,3 +5,10 @@ type SpotifyPlaylist struct {
 	Description string `json:"description"`
 	IsPublic    bool   `json:"is_public"`
 }
+
+type SpotifyPlaylistDetails struct {
+	Name          *string `json:"name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	IsPublic      *bool   `json:"public,omitempty"`
+	Collaborative *bool   `json:"collaborative,omitempty"`
+}
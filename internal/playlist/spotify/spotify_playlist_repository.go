@@ -2,65 +2,302 @@ package spotify
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/url"
 
 	types "festwrap/internal"
 	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/pagination"
 	"festwrap/internal/playlist"
 	"festwrap/internal/playlist/errors"
 	"festwrap/internal/serialization"
 	"festwrap/internal/song"
 )
 
+const (
+	playlistTracksFields         = "total,items(track(uri,name,artists(name)))"
+	playlistDetailedTracksFields = "total,items(track(uri,name,duration_ms,artists(name)))"
+	playlistMetadataFields       = "id,name,description,public,snapshot_id,owner(id,display_name)"
+)
+
 type SpotifyPlaylistRepository struct {
-	songsSerializer            serialization.Serializer[SpotifySongs]
-	playlistCreateSerializer   serialization.Serializer[SpotifyPlaylist]
-	playlistSearchDeserializer serialization.Deserializer[SpotifySearchPlaylistResponse]
-	playlistCreateDeserializer serialization.Deserializer[SpotifyCreatePlaylistResponse]
-	userIdKey                  types.ContextKey
-	tokenKey                   types.ContextKey
-	host                       string
-	httpSender                 httpsender.HTTPRequestSender
+	songsSerializer                 serialization.Serializer[SpotifySongs]
+	songsRemoveSerializer           serialization.Serializer[SpotifyRemoveSongs]
+	playlistCreateSerializer        serialization.Serializer[SpotifyPlaylist]
+	playlistDetailsSerializer       serialization.Serializer[SpotifyPlaylistDetails]
+	userPlaylistsDeserializer       serialization.Deserializer[SpotifyUserPlaylistsResponse]
+	playlistCreateDeserializer      serialization.Deserializer[SpotifyCreatePlaylistResponse]
+	playlistTracksDeserializer      serialization.Deserializer[SpotifyPlaylistTracksResponse]
+	playlistFirstTracksDeserializer serialization.Deserializer[SpotifyPlaylistWithTracksResponse]
+	playlistMetadataDeserializer    serialization.Deserializer[SpotifyPlaylistMetadataResponse]
+	snapshotDeserializer            serialization.Deserializer[SpotifySnapshotResponse]
+	tracksPageLimit                 int
+	userPlaylistsPageLimit          int
+	songsBatchSize                  int
+	maxCoverBytes                   int
+	userIdKey                       types.ContextKey
+	tokenKey                        types.ContextKey
+	host                            string
+	httpSender                      httpsender.HTTPRequestSender
 }
 
 func NewSpotifyPlaylistRepository(httpSender httpsender.HTTPRequestSender) SpotifyPlaylistRepository {
 	songSerializer := serialization.NewJsonSerializer[SpotifySongs]()
+	songsRemoveSerializer := serialization.NewJsonSerializer[SpotifyRemoveSongs]()
 	playlistCreateSerializer := serialization.NewJsonSerializer[SpotifyPlaylist]()
-	playlistSearchDeserializer := serialization.NewJsonDeserializer[SpotifySearchPlaylistResponse]()
+	playlistDetailsSerializer := serialization.NewJsonSerializer[SpotifyPlaylistDetails]()
+	userPlaylistsDeserializer := serialization.NewJsonDeserializer[SpotifyUserPlaylistsResponse]()
 	playlistCreateDeserializer := serialization.NewJsonDeserializer[SpotifyCreatePlaylistResponse]()
+	playlistTracksDeserializer := serialization.NewJsonDeserializer[SpotifyPlaylistTracksResponse]()
+	playlistFirstTracksDeserializer := serialization.NewJsonDeserializer[SpotifyPlaylistWithTracksResponse]()
+	playlistMetadataDeserializer := serialization.NewJsonDeserializer[SpotifyPlaylistMetadataResponse]()
+	snapshotDeserializer := serialization.NewJsonDeserializer[SpotifySnapshotResponse]()
 	return SpotifyPlaylistRepository{
-		tokenKey:                   "token",
-		userIdKey:                  "user_id",
-		host:                       "api.spotify.com",
-		httpSender:                 httpSender,
-		songsSerializer:            &songSerializer,
-		playlistCreateSerializer:   &playlistCreateSerializer,
-		playlistSearchDeserializer: &playlistSearchDeserializer,
-		playlistCreateDeserializer: playlistCreateDeserializer,
+		tokenKey:                        "token",
+		userIdKey:                       "user_id",
+		host:                            "api.spotify.com",
+		httpSender:                      httpSender,
+		songsSerializer:                 &songSerializer,
+		songsRemoveSerializer:           &songsRemoveSerializer,
+		playlistCreateSerializer:        &playlistCreateSerializer,
+		playlistDetailsSerializer:       &playlistDetailsSerializer,
+		userPlaylistsDeserializer:       userPlaylistsDeserializer,
+		playlistCreateDeserializer:      playlistCreateDeserializer,
+		playlistTracksDeserializer:      playlistTracksDeserializer,
+		playlistFirstTracksDeserializer: playlistFirstTracksDeserializer,
+		playlistMetadataDeserializer:    playlistMetadataDeserializer,
+		snapshotDeserializer:            snapshotDeserializer,
+		tracksPageLimit:                 100,
+		userPlaylistsPageLimit:          50,
+		songsBatchSize:                  100,
+		maxCoverBytes:                   256 * 1024,
 	}
 }
 
-func (r *SpotifyPlaylistRepository) AddSongs(ctx context.Context, playlistId string, songs []song.Song) error {
+// Songs are added in ordered batches, since Spotify limits the number of songs per request.
+// If a batch fails the rest are not sent, and the result reports which songs were added and which not
+func (r *SpotifyPlaylistRepository) AddSongs(
+	ctx context.Context,
+	playlistId string,
+	songs []song.Song,
+) (playlist.AddedSongs, error) {
+	result := playlist.AddedSongs{SnapshotIds: []string{}, Added: []song.Song{}, NotAdded: songs}
 	if len(songs) == 0 {
-		return errors.NewCannotAddSongsToPlaylistError("no songs provided")
+		return result, errors.NewCannotAddSongsToPlaylistError("no songs provided")
 	}
 
 	token, ok := ctx.Value(r.tokenKey).(string)
 	if !ok {
-		return errors.NewCannotAddSongsToPlaylistError("Could not retrieve token from context")
+		return result, errors.NewCannotAddSongsToPlaylistError("Could not retrieve token from context")
+	}
+
+	for start := 0; start < len(songs); start += r.songsBatchSize {
+		end := min(start+r.songsBatchSize, len(songs))
+		snapshotId, err := r.addSongsBatch(playlistId, songs[start:end], token)
+		if err != nil {
+			return result, httpsender.WrapError(err, func(message string) error {
+				errorMsg := fmt.Sprintf(
+					"could not add %d out of %d songs to playlist %s: %s", len(songs)-start, len(songs), playlistId, message,
+				)
+				return errors.NewCannotAddSongsToPlaylistError(errorMsg)
+			})
+		}
+
+		result.SnapshotIds = append(result.SnapshotIds, snapshotId)
+		result.Added = songs[:end]
+		result.NotAdded = songs[end:]
 	}
 
+	return result, nil
+}
+
+// Returns the snapshot of the playlist after adding the songs
+func (r *SpotifyPlaylistRepository) addSongsBatch(playlistId string, songs []song.Song, token string) (string, error) {
 	body, err := r.songsSerializer.Serialize(NewSpotifySongs(songs))
+	if err != nil {
+		return "", fmt.Errorf("could not serialize songs: %v", err.Error())
+	}
+
+	response, err := r.httpSender.Send(r.addSongsHttpOptions(playlistId, body, token))
+	if err != nil {
+		return "", err
+	}
+
+	var snapshot SpotifySnapshotResponse
+	if err = r.snapshotDeserializer.Deserialize(*response, &snapshot); err != nil {
+		return "", fmt.Errorf("could not read playlist snapshot: %v", err.Error())
+	}
+	return snapshot.SnapshotId, nil
+}
+
+// Spotify limits the number of songs per request, so the first batch replaces the playlist
+// songs and the rest are appended afterwards
+func (r *SpotifyPlaylistRepository) ReplaceSongs(ctx context.Context, playlistId string, songs []song.Song) error {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return errors.NewCannotAddSongsToPlaylistError("Could not retrieve token from context")
+	}
+
+	end := min(r.songsBatchSize, len(songs))
+	body, err := r.songsSerializer.Serialize(NewSpotifySongs(songs[:end]))
 	if err != nil {
 		errorMsg := fmt.Sprintf("could not serialize songs: %v", err.Error())
 		return errors.NewCannotAddSongsToPlaylistError(errorMsg)
 	}
 
-	httpOptions := r.addSongsHttpOptions(playlistId, body, token)
+	httpOptions := r.replaceSongsHttpOptions(playlistId, body, token)
 	_, err = r.httpSender.Send(httpOptions)
 	if err != nil {
-		return errors.NewCannotAddSongsToPlaylistError(err.Error())
+		return httpsender.WrapError(err, errors.NewCannotAddSongsToPlaylistError)
+	}
+
+	for start := end; start < len(songs); start += r.songsBatchSize {
+		batchEnd := min(start+r.songsBatchSize, len(songs))
+		if _, err = r.addSongsBatch(playlistId, songs[start:batchEnd], token); err != nil {
+			return httpsender.WrapError(err, errors.NewCannotAddSongsToPlaylistError)
+		}
+	}
+
+	return nil
+}
+
+func (r *SpotifyPlaylistRepository) RemoveSongs(
+	ctx context.Context,
+	playlistId string,
+	snapshotId string,
+	songs []song.Song,
+) error {
+	if len(songs) == 0 {
+		return errors.NewCannotRemoveSongsFromPlaylistError("no songs provided")
+	}
+
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return errors.NewCannotRemoveSongsFromPlaylistError("Could not retrieve token from context")
+	}
+
+	// Every batch refers to the same snapshot, so Spotify applies them to the playlist as it was read
+	for start := 0; start < len(songs); start += r.songsBatchSize {
+		end := min(start+r.songsBatchSize, len(songs))
+		body, err := r.songsRemoveSerializer.Serialize(NewSpotifyRemoveSongs(songs[start:end], snapshotId))
+		if err != nil {
+			errorMsg := fmt.Sprintf("could not serialize songs: %v", err.Error())
+			return errors.NewCannotRemoveSongsFromPlaylistError(errorMsg)
+		}
+
+		httpOptions := r.removeSongsHttpOptions(playlistId, body, token)
+		_, err = r.httpSender.Send(httpOptions)
+		if err != nil {
+			return httpsender.WrapError(err, errors.NewCannotRemoveSongsFromPlaylistError)
+		}
+	}
+
+	return nil
+}
+
+func (r *SpotifyPlaylistRepository) GetPlaylistTracks(
+	ctx context.Context,
+	playlistId string,
+) (playlist.PlaylistTracks, error) {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return playlist.PlaylistTracks{}, errors.NewCannotRetrievePlaylistTracksError("Could not retrieve token from context")
+	}
+
+	// The first page is retrieved along with the playlist so we get the snapshot the tracks belong to
+	response, err := r.httpSender.Send(r.getPlaylistWithTracksHttpOptions(playlistId, token))
+	if err != nil {
+		return playlist.PlaylistTracks{}, httpsender.WrapError(err, errors.NewCannotRetrievePlaylistTracksError)
+	}
+
+	var playlistWithTracks SpotifyPlaylistWithTracksResponse
+	err = r.playlistFirstTracksDeserializer.Deserialize(*response, &playlistWithTracks)
+	if err != nil {
+		return playlist.PlaylistTracks{}, errors.NewCannotRetrievePlaylistTracksError(err.Error())
+	}
+
+	page := playlistWithTracks.Tracks
+	tracks := page.GetTracks()
+	offset := len(page.Items)
+	for len(page.Items) > 0 && offset < page.Total {
+		httpOptions := r.getPlaylistTracksHttpOptions(playlistId, offset, token)
+		response, err := r.httpSender.Send(httpOptions)
+		if err != nil {
+			return playlist.PlaylistTracks{}, httpsender.WrapError(err, errors.NewCannotRetrievePlaylistTracksError)
+		}
+
+		page = SpotifyPlaylistTracksResponse{}
+		err = r.playlistTracksDeserializer.Deserialize(*response, &page)
+		if err != nil {
+			return playlist.PlaylistTracks{}, errors.NewCannotRetrievePlaylistTracksError(err.Error())
+		}
+
+		tracks = append(tracks, page.GetTracks()...)
+		offset += len(page.Items)
+	}
+
+	return playlist.PlaylistTracks{SnapshotId: playlistWithTracks.SnapshotId, Tracks: tracks}, nil
+}
+
+// Retrieves the playlist metadata and the page of tracks starting at offset
+func (r *SpotifyPlaylistRepository) GetPlaylist(
+	ctx context.Context,
+	playlistId string,
+	offset int,
+	limit int,
+) (playlist.PlaylistWithTracks, error) {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return playlist.PlaylistWithTracks{}, errors.NewCannotRetrievePlaylistError("Could not retrieve token from context")
+	}
+
+	response, err := r.httpSender.Send(r.getPlaylistMetadataHttpOptions(playlistId, token))
+	if err != nil {
+		return playlist.PlaylistWithTracks{}, httpsender.WrapError(err, errors.NewCannotRetrievePlaylistError)
+	}
+
+	var metadata SpotifyPlaylistMetadataResponse
+	err = r.playlistMetadataDeserializer.Deserialize(*response, &metadata)
+	if err != nil {
+		return playlist.PlaylistWithTracks{}, errors.NewCannotRetrievePlaylistError(err.Error())
+	}
+
+	httpOptions := r.playlistTracksPageHttpOptions(playlistId, playlistDetailedTracksFields, offset, limit, token)
+	response, err = r.httpSender.Send(httpOptions)
+	if err != nil {
+		return playlist.PlaylistWithTracks{}, httpsender.WrapError(err, errors.NewCannotRetrievePlaylistError)
+	}
+
+	var page SpotifyPlaylistTracksResponse
+	err = r.playlistTracksDeserializer.Deserialize(*response, &page)
+	if err != nil {
+		return playlist.PlaylistWithTracks{}, errors.NewCannotRetrievePlaylistError(err.Error())
+	}
+
+	tracks := playlist.PlaylistTracksPage{Items: page.GetTracks(), Offset: offset, Limit: limit, Total: page.Total}
+	return metadata.ToPlaylistWithTracks(tracks), nil
+}
+
+func (r *SpotifyPlaylistRepository) UploadCover(ctx context.Context, playlistId string, jpegImage []byte) error {
+	if len(jpegImage) == 0 {
+		return errors.NewCannotUploadCoverError("no image provided")
+	}
+
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return errors.NewCannotUploadCoverError("Could not retrieve token from context")
+	}
+
+	body := []byte(base64.StdEncoding.EncodeToString(jpegImage))
+	if len(body) > r.maxCoverBytes {
+		errorMsg := fmt.Sprintf("encoded cover has %d bytes, but at most %d are allowed", len(body), r.maxCoverBytes)
+		return errors.NewCannotUploadCoverError(errorMsg)
+	}
+
+	_, err := r.httpSender.Send(r.uploadCoverHttpOptions(playlistId, body, token))
+	if err != nil {
+		return httpsender.WrapError(err, errors.NewCannotUploadCoverError)
 	}
 
 	return nil
@@ -92,7 +329,7 @@ func (r *SpotifyPlaylistRepository) CreatePlaylist(ctx context.Context, playlist
 	httpOptions := r.createPlaylistOptions(userId, body, token)
 	response, err := r.httpSender.Send(httpOptions)
 	if err != nil {
-		return "", errors.NewCannotCreatePlaylistError(err.Error())
+		return "", httpsender.WrapError(err, errors.NewCannotCreatePlaylistError)
 	}
 
 	var parsedResponse SpotifyCreatePlaylistResponse
@@ -104,47 +341,102 @@ func (r *SpotifyPlaylistRepository) CreatePlaylist(ctx context.Context, playlist
 	return parsedResponse.Id, nil
 }
 
-func (r *SpotifyPlaylistRepository) SearchPlaylist(ctx context.Context, name string, limit int) ([]playlist.Playlist, error) {
-	emptyResponse := []playlist.Playlist{}
+func (r *SpotifyPlaylistRepository) UpdatePlaylistDetails(
+	ctx context.Context,
+	playlistId string,
+	details playlist.PlaylistDetails,
+) error {
 	token, ok := ctx.Value(r.tokenKey).(string)
 	if !ok {
-		return emptyResponse, errors.NewCannotSearchPlaylistError("Could not retrieve token from context")
+		return errors.NewCannotUpdatePlaylistError("Could not retrieve token from context")
 	}
 
-	userId, ok := ctx.Value(r.userIdKey).(string)
-	if !ok {
-		return emptyResponse, errors.NewCannotSearchPlaylistError("Could not retrieve user id from context")
+	body, err := r.playlistDetailsSerializer.Serialize(
+		SpotifyPlaylistDetails{
+			Name:          details.Name,
+			Description:   details.Description,
+			IsPublic:      details.IsPublic,
+			Collaborative: details.Collaborative,
+		},
+	)
+	if err != nil {
+		errorMsg := fmt.Sprintf("could not serialize playlist details: %v", err.Error())
+		return errors.NewCannotUpdatePlaylistError(errorMsg)
 	}
 
-	httpOptions := r.searchPlaylistOptions(name, limit, token)
-	response, err := r.httpSender.Send(httpOptions)
+	_, err = r.httpSender.Send(r.updatePlaylistDetailsOptions(playlistId, body, token))
 	if err != nil {
-		return emptyResponse, errors.NewCannotSearchPlaylistError(err.Error())
+		return httpsender.WrapError(err, errors.NewCannotUpdatePlaylistError)
+	}
+
+	return nil
+}
+
+// Spotify does not allow deleting playlists, so they are unfollowed to remove them from the user library
+func (r *SpotifyPlaylistRepository) DeletePlaylist(ctx context.Context, playlistId string) error {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return errors.NewCannotDeletePlaylistError("Could not retrieve token from context")
 	}
 
-	var searchedPlaylist SpotifySearchPlaylistResponse
-	err = r.playlistSearchDeserializer.Deserialize(*response, &searchedPlaylist)
+	_, err := r.httpSender.Send(r.deletePlaylistOptions(playlistId, token))
 	if err != nil {
-		return nil, errors.NewCannotSearchPlaylistError(err.Error())
+		return httpsender.WrapError(err, errors.NewCannotDeletePlaylistError)
 	}
 
-	return filterPlaylistByUser(searchedPlaylist.Playlists.Items, userId), nil
+	return nil
 }
 
-func filterPlaylistByUser(playlists []SpotifySearchPlaylist, userId string) []playlist.Playlist {
-	var userPlaylists []playlist.Playlist
-	for _, currentPlaylist := range playlists {
-		if currentPlaylist.OwnerMetadata.Id == userId {
-			playlistObj := playlist.Playlist{
-				Id:          currentPlaylist.Id,
-				Name:        currentPlaylist.Name,
-				Description: currentPlaylist.Description,
-				IsPublic:    currentPlaylist.Public,
+// Returns the page of the playlists in the user library that the user can edit and whose name
+// matches, ignoring case and accents. Spotify cannot filter them, so the library is read until one
+// match after the page is found. In that case the total only counts the matches found so far,
+// which is enough to know there is a next page
+func (r *SpotifyPlaylistRepository) SearchUserPlaylists(
+	ctx context.Context,
+	name string,
+	offset int,
+	limit int,
+) (pagination.Page[playlist.Playlist], error) {
+	emptyPage := pagination.Page[playlist.Playlist]{}
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return emptyPage, errors.NewCannotSearchPlaylistError("Could not retrieve token from context")
+	}
+
+	userId, ok := ctx.Value(r.userIdKey).(string)
+	if !ok {
+		return emptyPage, errors.NewCannotSearchPlaylistError("Could not retrieve user id from context")
+	}
+
+	userPlaylists := []playlist.Playlist{}
+	libraryOffset := 0
+	for len(userPlaylists) <= offset+limit {
+		response, err := r.httpSender.Send(r.userPlaylistsOptions(libraryOffset, token))
+		if err != nil {
+			return emptyPage, httpsender.WrapError(err, errors.NewCannotSearchPlaylistError)
+		}
+
+		var page SpotifyUserPlaylistsResponse
+		err = r.userPlaylistsDeserializer.Deserialize(*response, &page)
+		if err != nil {
+			return emptyPage, errors.NewCannotSearchPlaylistError(err.Error())
+		}
+
+		for _, currentPlaylist := range page.Items {
+			if currentPlaylist.IsEditableBy(userId) && playlist.NameMatches(currentPlaylist.Name, name) {
+				userPlaylists = append(userPlaylists, currentPlaylist.ToPlaylist())
 			}
-			userPlaylists = append(userPlaylists, playlistObj)
+		}
+
+		libraryOffset += len(page.Items)
+		if len(page.Items) == 0 || libraryOffset >= page.Total {
+			break
 		}
 	}
-	return userPlaylists
+
+	start := min(offset, len(userPlaylists))
+	end := min(offset+limit, len(userPlaylists))
+	return pagination.NewPage(userPlaylists[start:end], offset, len(userPlaylists)), nil
 }
 
 func (r *SpotifyPlaylistRepository) SetUserIdKey(key types.ContextKey) {
@@ -169,6 +461,80 @@ func (r *SpotifyPlaylistRepository) addSongsHttpOptions(
 	return httpOptions
 }
 
+func (r *SpotifyPlaylistRepository) replaceSongsHttpOptions(
+	playlistId string, body []byte, token string,
+) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://%s/v1/playlists/%s/tracks", r.host, playlistId)
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.PUT, 200)
+	httpOptions.SetBody(body)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) removeSongsHttpOptions(
+	playlistId string, body []byte, token string,
+) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://%s/v1/playlists/%s/tracks", r.host, playlistId)
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.DELETE, 200)
+	httpOptions.SetBody(body)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) getPlaylistWithTracksHttpOptions(
+	playlistId string, token string,
+) httpsender.HTTPRequestOptions {
+	queryParams := url.Values{}
+	queryParams.Set("fields", fmt.Sprintf("snapshot_id,tracks(%s)", playlistTracksFields))
+	url := fmt.Sprintf("https://%s/v1/playlists/%s?%s", r.host, playlistId, queryParams.Encode())
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) getPlaylistTracksHttpOptions(
+	playlistId string, offset int, token string,
+) httpsender.HTTPRequestOptions {
+	return r.playlistTracksPageHttpOptions(playlistId, playlistTracksFields, offset, r.tracksPageLimit, token)
+}
+
+func (r *SpotifyPlaylistRepository) getPlaylistMetadataHttpOptions(
+	playlistId string, token string,
+) httpsender.HTTPRequestOptions {
+	queryParams := url.Values{}
+	queryParams.Set("fields", playlistMetadataFields)
+	url := fmt.Sprintf("https://%s/v1/playlists/%s?%s", r.host, playlistId, queryParams.Encode())
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) playlistTracksPageHttpOptions(
+	playlistId string, fields string, offset int, limit int, token string,
+) httpsender.HTTPRequestOptions {
+	queryParams := url.Values{}
+	queryParams.Set("fields", fields)
+	queryParams.Set("limit", fmt.Sprintf("%d", limit))
+	queryParams.Set("offset", fmt.Sprintf("%d", offset))
+	url := fmt.Sprintf("https://%s/v1/playlists/%s/tracks?%s", r.host, playlistId, queryParams.Encode())
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) uploadCoverHttpOptions(
+	playlistId string, body []byte, token string,
+) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://%s/v1/playlists/%s/images", r.host, playlistId)
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.PUT, 202)
+	httpOptions.SetHeaders(map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", token),
+		"Content-Type":  "image/jpeg",
+	})
+	httpOptions.SetBody(body)
+	return httpOptions
+}
+
 func (r *SpotifyPlaylistRepository) createPlaylistOptions(
 	userId string, body []byte, token string,
 ) httpsender.HTTPRequestOptions {
@@ -179,14 +545,30 @@ func (r *SpotifyPlaylistRepository) createPlaylistOptions(
 	return httpOptions
 }
 
-func (r *SpotifyPlaylistRepository) searchPlaylistOptions(
-	playlistName string, limit int, token string,
+func (r *SpotifyPlaylistRepository) updatePlaylistDetailsOptions(
+	playlistId string, body []byte, token string,
+) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://%s/v1/playlists/%s", r.host, playlistId)
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.PUT, 200)
+	httpOptions.SetBody(body)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) deletePlaylistOptions(
+	playlistId string, token string,
 ) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://%s/v1/playlists/%s/followers", r.host, playlistId)
+	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.DELETE, 200)
+	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
+	return httpOptions
+}
+
+func (r *SpotifyPlaylistRepository) userPlaylistsOptions(offset int, token string) httpsender.HTTPRequestOptions {
 	queryParams := url.Values{}
-	queryParams.Set("q", playlistName)
-	queryParams.Set("type", "playlist")
-	queryParams.Set("limit", fmt.Sprintf("%d", limit))
-	url := fmt.Sprintf("https://%s/v1/search?%s", r.host, queryParams.Encode())
+	queryParams.Set("limit", fmt.Sprintf("%d", r.userPlaylistsPageLimit))
+	queryParams.Set("offset", fmt.Sprintf("%d", offset))
+	url := fmt.Sprintf("https://%s/v1/me/playlists?%s", r.host, queryParams.Encode())
 	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
 	httpOptions.SetHeaders(r.GetSpotifyBaseHeaders(token))
 	return httpOptions
@@ -199,6 +581,22 @@ func (r *SpotifyPlaylistRepository) GetSpotifyBaseHeaders(token string) map[stri
 	}
 }
 
+func (r *SpotifyPlaylistRepository) SetSongsBatchSize(size int) {
+	r.songsBatchSize = size
+}
+
+func (r *SpotifyPlaylistRepository) SetMaxCoverBytes(maxBytes int) {
+	r.maxCoverBytes = maxBytes
+}
+
+func (r *SpotifyPlaylistRepository) SetTracksPageLimit(limit int) {
+	r.tracksPageLimit = limit
+}
+
+func (r *SpotifyPlaylistRepository) SetUserPlaylistsPageLimit(limit int) {
+	r.userPlaylistsPageLimit = limit
+}
+
 func (r *SpotifyPlaylistRepository) SetPlaylistCreateSerializer(serializer serialization.Serializer[SpotifyPlaylist]) {
 	r.playlistCreateSerializer = serializer
 }
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	types "festwrap/internal"
 	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
+	httpsendermocks "festwrap/internal/http/sender/mocks"
+	"festwrap/internal/pagination"
 	"festwrap/internal/playlist"
 	"festwrap/internal/serialization"
 	"festwrap/internal/song"
@@ -33,6 +37,12 @@ func emptyResponseSender() *httpsender.FakeHTTPSender {
 	return &sender
 }
 
+func snapshotResponseSender() *httpsender.FakeHTTPSender {
+	sender := httpsender.FakeHTTPSender{}
+	sender.SetResponse(snapshotResponse("snapshot"))
+	return &sender
+}
+
 func errorSender() *httpsender.FakeHTTPSender {
 	sender := httpsender.FakeHTTPSender{}
 	sender.SetError(errors.New("test send error"))
@@ -53,36 +63,141 @@ func createPlaylistSender() *httpsender.FakeHTTPSender {
 	return &sender
 }
 
-func searchPlaylistSender() *httpsender.FakeHTTPSender {
-	sender := httpsender.FakeHTTPSender{}
-	response := searchedPlaylistsResponseBody()
-	sender.SetResponse(&response)
+func playlistTracksPageResponse(page int) *[]byte {
+	pages := [][]byte{
+		[]byte(`
+			{
+				"snapshot_id": "snapshot",
+				"tracks": {
+					"total": 3,
+					"items": [
+						{"track": {"uri": "uri1", "name": "first song", "artists": [{"name": "first artist"}]}},
+						{"track": null}
+					]
+				}
+			}
+		`),
+		[]byte(`
+			{
+				"total": 3,
+				"items": [
+					{
+						"track": {
+							"uri": "uri2",
+							"name": "second song",
+							"artists": [{"name": "first artist"}, {"name": "second artist"}]
+						}
+					}
+				]
+			}
+		`),
+	}
+	return &pages[page]
+}
+
+func playlistTracksSender() *httpsendermocks.HTTPSenderMock {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getPlaylistWithTracksHttpOptions()).Return(playlistTracksPageResponse(0), nil)
+	sender.On("Send", getPlaylistTracksHttpOptions(2)).Return(playlistTracksPageResponse(1), nil)
 	return &sender
 }
 
-func searchedPlaylistsResponseBody() []byte {
-	return []byte(`
-		{
-			"playlists": {
+func expectedPlaylistTracks() playlist.PlaylistTracks {
+	return playlist.PlaylistTracks{
+		SnapshotId: "snapshot",
+		Tracks: []playlist.PlaylistTrack{
+			{Uri: "uri1", Name: "first song", Artists: []string{"first artist"}},
+			{Uri: "uri2", Name: "second song", Artists: []string{"first artist", "second artist"}},
+		},
+	}
+}
+
+func userPlaylistsPageResponse(page int) *[]byte {
+	pages := [][]byte{
+		[]byte(`
+			{
+				"total": 4,
+				"items": [
+					{"id":"id1","name":"My Festival","public":true,"owner":{"id":"qrRwLBFxQL9fknW8NzBn4JprRNgS"}},
+					{"id":"id2","name":"Festival hits","public":true,"owner":{"id":"another_owner_id"}}
+				]
+			}
+		`),
+		[]byte(`
+			{
+				"total": 4,
 				"items": [
 					{
-						"id":"id1",
-						"name":"first playlist",
-						"description":"First description",
-						"public":true,
-						"owner":{"id":"qrRwLBFxQL9fknW8NzBn4JprRNgS"}
-					},
-					{
-						"id":"id2",
-						"name":"second playlist",
-						"description":"Second description",
+						"id":"id3",
+						"name":"Festivál friends",
+						"description":"Shared",
 						"public":false,
+						"collaborative":true,
 						"owner":{"id":"another_owner_id"}
-					}
+					},
+					{"id":"id4","name":"Gym","public":false,"owner":{"id":"qrRwLBFxQL9fknW8NzBn4JprRNgS"}}
 				]
 			}
+		`),
+	}
+	return &pages[page]
+}
+
+func userPlaylistsSender() *httpsendermocks.HTTPSenderMock {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", userPlaylistsHttpOptions(0)).Return(userPlaylistsPageResponse(0), nil)
+	sender.On("Send", userPlaylistsHttpOptions(2)).Return(userPlaylistsPageResponse(1), nil)
+	return &sender
+}
+
+func expectedUserPlaylists() []playlist.Playlist {
+	return []playlist.Playlist{
+		{Id: "id1", Name: "My Festival", IsPublic: true},
+		{Id: "id3", Name: "Festivál friends", Description: "Shared", IsPublic: false},
+	}
+}
+
+func getPlaylistSender() *httpsendermocks.HTTPSenderMock {
+	metadata := []byte(`
+		{
+			"id": "testId",
+			"name": "My playlist",
+			"description": "Some description",
+			"public": true,
+			"snapshot_id": "snapshot",
+			"owner": {"id": "qrRwLBFxQL9fknW8NzBn4JprRNgS", "display_name": "Some user"}
+		}
+	`)
+	tracks := []byte(`
+		{
+			"total": 3,
+			"items": [
+				{"track": {"uri": "uri2", "name": "second song", "duration_ms": 1000, "artists": [{"name": "first artist"}]}},
+				{"track": null}
+			]
 		}
 	`)
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getPlaylistMetadataHttpOptions()).Return(&metadata, nil)
+	sender.On("Send", getPlaylistPageHttpOptions()).Return(&tracks, nil)
+	return &sender
+}
+
+func expectedPlaylistWithTracks() playlist.PlaylistWithTracks {
+	return playlist.PlaylistWithTracks{
+		Id:          addSongsPlaylistId,
+		Name:        "My playlist",
+		Description: "Some description",
+		IsPublic:    true,
+		Owner:       playlist.PlaylistOwner{Id: userId, Name: "Some user"},
+		SnapshotId:  "snapshot",
+		Tracks: playlist.PlaylistTracksPage{
+			Items:  []playlist.PlaylistTrack{{Uri: "uri2", Name: "second song", Artists: []string{"first artist"}, DurationMs: 1000}},
+			Offset: 1,
+			Limit:  2,
+			Total:  3,
+		},
+	}
 }
 
 func songsToAdd() []song.Song {
@@ -93,17 +208,6 @@ func playlistToCreate() playlist.Playlist {
 	return playlist.Playlist{Id: createPlaylistId, Name: "my-playlist", Description: "some playlist", IsPublic: false}
 }
 
-func searchedPlaylists() []playlist.Playlist {
-	return []playlist.Playlist{
-		{
-			Id:          "id1",
-			Name:        "first playlist",
-			Description: "First description",
-			IsPublic:    true,
-		},
-	}
-}
-
 func testContext() context.Context {
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, types.ContextKey(tokenKey), token)
@@ -112,10 +216,92 @@ func testContext() context.Context {
 }
 
 func addSongsHttpOptions() httpsender.HTTPRequestOptions {
+	return addSongsBatchHttpOptions(`{"uris":["uri1","uri2"]}`)
+}
+
+func addSongsBatchHttpOptions(body string) httpsender.HTTPRequestOptions {
 	url := fmt.Sprintf("https://api.spotify.com/v1/playlists/%s/tracks", addSongsPlaylistId)
 	options := httpsender.NewHTTPRequestOptions(url, httpsender.POST, 201)
 	options.SetHeaders(authHeaders())
-	options.SetBody([]byte(`{"uris":["uri1","uri2"]}`))
+	options.SetBody([]byte(body))
+	return options
+}
+
+func snapshotResponse(snapshotId string) *[]byte {
+	response := fmt.Appendf(nil, `{"snapshot_id":"%s"}`, snapshotId)
+	return &response
+}
+
+func batchSongsToAdd() []song.Song {
+	return []song.Song{song.NewSong("uri1"), song.NewSong("uri2"), song.NewSong("uri3"), song.NewSong("uri4")}
+}
+
+func replaceSongsHttpOptions() httpsender.HTTPRequestOptions {
+	return replaceSongsBatchHttpOptions(`{"uris":["uri1","uri2"]}`)
+}
+
+func replaceSongsBatchHttpOptions(body string) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.spotify.com/v1/playlists/%s/tracks", addSongsPlaylistId)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.PUT, 200)
+	options.SetHeaders(authHeaders())
+	options.SetBody([]byte(body))
+	return options
+}
+
+func removeSongsHttpOptions() httpsender.HTTPRequestOptions {
+	return removeSongsBatchHttpOptions(`{"tracks":[{"uri":"uri1"},{"uri":"uri2"}],"snapshot_id":"snapshot"}`)
+}
+
+func removeSongsBatchHttpOptions(body string) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.spotify.com/v1/playlists/%s/tracks", addSongsPlaylistId)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.DELETE, 200)
+	options.SetHeaders(authHeaders())
+	options.SetBody([]byte(body))
+	return options
+}
+
+func getPlaylistWithTracksHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf(
+		"https://api.spotify.com/v1/playlists/%s?fields=%s",
+		addSongsPlaylistId,
+		"snapshot_id%2Ctracks%28total%2Citems%28track%28uri%2Cname%2Cartists%28name%29%29%29%29",
+	)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(authHeaders())
+	return options
+}
+
+func getPlaylistTracksHttpOptions(offset int) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf(
+		"https://api.spotify.com/v1/playlists/%s/tracks?fields=%s&limit=2&offset=%d",
+		addSongsPlaylistId,
+		"total%2Citems%28track%28uri%2Cname%2Cartists%28name%29%29%29",
+		offset,
+	)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(authHeaders())
+	return options
+}
+
+func getPlaylistMetadataHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf(
+		"https://api.spotify.com/v1/playlists/%s?fields=%s",
+		addSongsPlaylistId,
+		"id%2Cname%2Cdescription%2Cpublic%2Csnapshot_id%2Cowner%28id%2Cdisplay_name%29",
+	)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(authHeaders())
+	return options
+}
+
+func getPlaylistPageHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf(
+		"https://api.spotify.com/v1/playlists/%s/tracks?fields=%s&limit=2&offset=1",
+		addSongsPlaylistId,
+		"total%2Citems%28track%28uri%2Cname%2Cduration_ms%2Cartists%28name%29%29%29",
+	)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(authHeaders())
 	return options
 }
 
@@ -128,12 +314,42 @@ func createPlaylistHttpOptions() httpsender.HTTPRequestOptions {
 	return options
 }
 
-func searchPlaylistHttpOptions() httpsender.HTTPRequestOptions {
-	url := fmt.Sprintf(
-		"https://api.spotify.com/v1/search?limit=%d&q=%s&type=playlist",
-		searchPlaylistLimit,
-		searchPlaylistName,
-	)
+func updatePlaylistDetailsHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.spotify.com/v1/playlists/%s", addSongsPlaylistId)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.PUT, 200)
+	options.SetHeaders(authHeaders())
+	options.SetBody([]byte(`{"description":"new description","public":false,"collaborative":true}`))
+	return options
+}
+
+func playlistDetails() playlist.PlaylistDetails {
+	description := "new description"
+	isPublic := false
+	collaborative := true
+	return playlist.PlaylistDetails{Description: &description, IsPublic: &isPublic, Collaborative: &collaborative}
+}
+
+func deletePlaylistHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.spotify.com/v1/playlists/%s/followers", addSongsPlaylistId)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.DELETE, 200)
+	options.SetHeaders(authHeaders())
+	return options
+}
+
+func uploadCoverHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.spotify.com/v1/playlists/%s/images", addSongsPlaylistId)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.PUT, 202)
+	options.SetHeaders(map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token), "Content-Type": "image/jpeg"})
+	options.SetBody([]byte("/9j/AQID"))
+	return options
+}
+
+func coverImage() []byte {
+	return []byte{0xff, 0xd8, 0xff, 0x01, 0x02, 0x03}
+}
+
+func userPlaylistsHttpOptions(offset int) httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.spotify.com/v1/me/playlists?limit=2&offset=%d", offset)
 	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
 	options.SetHeaders(authHeaders())
 	return options
@@ -150,13 +366,16 @@ func spotifyPlaylistRepository(sender httpsender.HTTPRequestSender) SpotifyPlayl
 	repository := NewSpotifyPlaylistRepository(sender)
 	repository.SetTokenKey(tokenKey)
 	repository.SetUserIdKey(userIdKey)
+	repository.SetTracksPageLimit(2)
+	repository.SetUserPlaylistsPageLimit(2)
+	repository.SetSongsBatchSize(3)
 	return repository
 }
 
 func TestAddSongsReturnsErrorWhenNoSongsProvided(t *testing.T) {
 	repository := spotifyPlaylistRepository(emptyResponseSender())
 
-	err := repository.AddSongs(testContext(), addSongsPlaylistId, []song.Song{})
+	_, err := repository.AddSongs(testContext(), addSongsPlaylistId, []song.Song{})
 
 	assert.NotNil(t, err)
 }
@@ -174,17 +393,199 @@ func TestAddSongsSendsRequestUsingProperOptions(t *testing.T) {
 func TestAddSongsReturnsErrorOnSendError(t *testing.T) {
 	repository := spotifyPlaylistRepository(errorSender())
 
-	err := repository.AddSongs(testContext(), addSongsPlaylistId, songsToAdd())
+	_, err := repository.AddSongs(testContext(), addSongsPlaylistId, songsToAdd())
 
 	assert.NotNil(t, err)
 }
 
 func TestAddSongsReturnsNoError(t *testing.T) {
+	repository := spotifyPlaylistRepository(snapshotResponseSender())
+
+	_, err := repository.AddSongs(testContext(), addSongsPlaylistId, songsToAdd())
+
+	assert.Nil(t, err)
+}
+
+func TestAddSongsReturnsErrorOnInvalidSnapshot(t *testing.T) {
+	repository := spotifyPlaylistRepository(nonJsonResponseSender())
+
+	_, err := repository.AddSongs(testContext(), addSongsPlaylistId, songsToAdd())
+
+	assert.NotNil(t, err)
+}
+
+func TestAddSongsSendsSongsInBatches(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri1","uri2","uri3"]}`)).Return(snapshotResponse("first"), nil)
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri4"]}`)).Return(snapshotResponse("second"), nil)
+	repository := spotifyPlaylistRepository(&sender)
+
+	actual, err := repository.AddSongs(testContext(), addSongsPlaylistId, batchSongsToAdd())
+
+	expected := playlist.AddedSongs{
+		SnapshotIds: []string{"first", "second"},
+		Added:       batchSongsToAdd(),
+		NotAdded:    []song.Song{},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+	sender.AssertExpectations(t)
+}
+
+func TestAddSongsReportsSongsNotAddedOnBatchError(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri1","uri2","uri3"]}`)).Return(snapshotResponse("first"), nil)
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri4"]}`)).Return(nil, errors.New("test send error"))
+	repository := spotifyPlaylistRepository(&sender)
+
+	actual, err := repository.AddSongs(testContext(), addSongsPlaylistId, batchSongsToAdd())
+
+	expected := playlist.AddedSongs{
+		SnapshotIds: []string{"first"},
+		Added:       batchSongsToAdd()[:3],
+		NotAdded:    batchSongsToAdd()[3:],
+	}
+	assert.NotNil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestAddSongsReturnsUpstreamAuthErrorOnBatchError(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	authErr := senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, "")
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri1","uri2","uri3"]}`)).Return(snapshotResponse("first"), nil)
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri4"]}`)).Return(nil, authErr)
+	repository := spotifyPlaylistRepository(&sender)
+
+	actual, err := repository.AddSongs(testContext(), addSongsPlaylistId, batchSongsToAdd())
+
+	assert.Equal(t, authErr, err)
+	assert.Equal(t, batchSongsToAdd()[:3], actual.Added)
+}
+
+func TestAddSongsReportsAllSongsNotAddedOnFirstBatchError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	actual, err := repository.AddSongs(testContext(), addSongsPlaylistId, batchSongsToAdd())
+
+	expected := playlist.AddedSongs{SnapshotIds: []string{}, Added: []song.Song{}, NotAdded: batchSongsToAdd()}
+	assert.NotNil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestReplaceSongsSendsRequestUsingProperOptions(t *testing.T) {
+	sender := emptyResponseSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	err := repository.ReplaceSongs(testContext(), addSongsPlaylistId, songsToAdd())
+
+	assert.Nil(t, err)
+	assert.Equal(t, replaceSongsHttpOptions(), sender.GetSendArgs())
+}
+
+func TestReplaceSongsAppendsSongsBeyondFirstBatch(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", replaceSongsBatchHttpOptions(`{"uris":["uri1","uri2","uri3"]}`)).Return(snapshotResponse("first"), nil)
+	sender.On("Send", addSongsBatchHttpOptions(`{"uris":["uri4"]}`)).Return(snapshotResponse("second"), nil)
+	repository := spotifyPlaylistRepository(&sender)
+
+	err := repository.ReplaceSongs(testContext(), addSongsPlaylistId, batchSongsToAdd())
+
+	assert.Nil(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestReplaceSongsReturnsErrorOnSendError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	err := repository.ReplaceSongs(testContext(), addSongsPlaylistId, songsToAdd())
+
+	assert.NotNil(t, err)
+}
+
+func TestRemoveSongsReturnsErrorWhenNoSongsProvided(t *testing.T) {
 	repository := spotifyPlaylistRepository(emptyResponseSender())
 
-	err := repository.AddSongs(testContext(), addSongsPlaylistId, songsToAdd())
+	err := repository.RemoveSongs(testContext(), addSongsPlaylistId, "snapshot", []song.Song{})
+
+	assert.NotNil(t, err)
+}
+
+func TestRemoveSongsSendsRequestUsingProperOptions(t *testing.T) {
+	sender := emptyResponseSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	err := repository.RemoveSongs(testContext(), addSongsPlaylistId, "snapshot", songsToAdd())
 
 	assert.Nil(t, err)
+	assert.Equal(t, removeSongsHttpOptions(), sender.GetSendArgs())
+}
+
+func TestRemoveSongsSendsSongsInBatches(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	firstBatch := `{"tracks":[{"uri":"uri1"},{"uri":"uri2"},{"uri":"uri3"}],"snapshot_id":"snapshot"}`
+	sender.On("Send", removeSongsBatchHttpOptions(firstBatch)).Return(snapshotResponse("first"), nil)
+	secondBatch := `{"tracks":[{"uri":"uri4"}],"snapshot_id":"snapshot"}`
+	sender.On("Send", removeSongsBatchHttpOptions(secondBatch)).Return(snapshotResponse("second"), nil)
+	repository := spotifyPlaylistRepository(&sender)
+
+	err := repository.RemoveSongs(testContext(), addSongsPlaylistId, "snapshot", batchSongsToAdd())
+
+	assert.Nil(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestRemoveSongsReturnsErrorOnSendError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	err := repository.RemoveSongs(testContext(), addSongsPlaylistId, "snapshot", songsToAdd())
+
+	assert.NotNil(t, err)
+}
+
+func TestGetPlaylistTracksRequestsAllPages(t *testing.T) {
+	sender := playlistTracksSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	_, err := repository.GetPlaylistTracks(testContext(), addSongsPlaylistId)
+
+	assert.Nil(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestGetPlaylistTracksReturnsTracksFromAllPages(t *testing.T) {
+	repository := spotifyPlaylistRepository(playlistTracksSender())
+
+	actual, err := repository.GetPlaylistTracks(testContext(), addSongsPlaylistId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPlaylistTracks(), actual)
+}
+
+func TestGetPlaylistTracksReturnsErrorOnNextPageSendError(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getPlaylistWithTracksHttpOptions()).Return(playlistTracksPageResponse(0), nil)
+	sender.On("Send", getPlaylistTracksHttpOptions(2)).Return(nil, errors.New("test error"))
+	repository := spotifyPlaylistRepository(&sender)
+
+	_, err := repository.GetPlaylistTracks(testContext(), addSongsPlaylistId)
+
+	assert.NotNil(t, err)
+}
+
+func TestGetPlaylistTracksReturnsErrorOnSendError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	_, err := repository.GetPlaylistTracks(testContext(), addSongsPlaylistId)
+
+	assert.NotNil(t, err)
+}
+
+func TestGetPlaylistTracksReturnsErrorIfSenderResponseIsNotJson(t *testing.T) {
+	repository := spotifyPlaylistRepository(nonJsonResponseSender())
+
+	_, err := repository.GetPlaylistTracks(testContext(), addSongsPlaylistId)
+
+	assert.NotNil(t, err)
 }
 
 func TestCreatePlaylistReturnsErrorOnPlaylistSerializationError(t *testing.T) {
@@ -233,39 +634,206 @@ func TestCreatePlaylistReturnsCreatedPlaylistId(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestSearchPlaylistSendsRequestWithOptions(t *testing.T) {
-	sender := searchPlaylistSender()
+func TestUpdatePlaylistDetailsSendsOnlyProvidedFields(t *testing.T) {
+	sender := emptyResponseSender()
 	repository := spotifyPlaylistRepository(sender)
 
-	repository.SearchPlaylist(testContext(), searchPlaylistName, searchPlaylistLimit)
+	err := repository.UpdatePlaylistDetails(testContext(), addSongsPlaylistId, playlistDetails())
 
-	actual := sender.GetSendArgs()
-	assert.Equal(t, searchPlaylistHttpOptions(), actual)
+	assert.Nil(t, err)
+	assert.Equal(t, updatePlaylistDetailsHttpOptions(), sender.GetSendArgs())
+}
+
+func TestUpdatePlaylistDetailsReturnsErrorOnSenderError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	err := repository.UpdatePlaylistDetails(testContext(), addSongsPlaylistId, playlistDetails())
+
+	assert.NotNil(t, err)
+}
+
+func TestDeletePlaylistSendsRequestWithOptions(t *testing.T) {
+	sender := emptyResponseSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	err := repository.DeletePlaylist(testContext(), addSongsPlaylistId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, deletePlaylistHttpOptions(), sender.GetSendArgs())
+}
+
+func TestDeletePlaylistReturnsErrorOnSenderError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	err := repository.DeletePlaylist(testContext(), addSongsPlaylistId)
+
+	assert.NotNil(t, err)
+}
+
+func TestGetPlaylistReturnsMetadataAndTracksPage(t *testing.T) {
+	sender := getPlaylistSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	actual, err := repository.GetPlaylist(testContext(), addSongsPlaylistId, 1, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPlaylistWithTracks(), actual)
+	sender.AssertExpectations(t)
+}
+
+func TestGetPlaylistReturnsErrorOnTracksSendError(t *testing.T) {
+	metadata := []byte(`{"id": "testId"}`)
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getPlaylistMetadataHttpOptions()).Return(&metadata, nil)
+	sender.On("Send", getPlaylistPageHttpOptions()).Return(nil, errors.New("test error"))
+	repository := spotifyPlaylistRepository(&sender)
+
+	_, err := repository.GetPlaylist(testContext(), addSongsPlaylistId, 1, 2)
+
+	assert.NotNil(t, err)
 }
 
-func TestSearchPlaylistReturnsErrorOnSendError(t *testing.T) {
+func TestGetPlaylistReturnsErrorOnSendError(t *testing.T) {
 	repository := spotifyPlaylistRepository(errorSender())
 
-	_, err := repository.SearchPlaylist(testContext(), searchPlaylistName, searchPlaylistLimit)
+	_, err := repository.GetPlaylist(testContext(), addSongsPlaylistId, 1, 2)
 
 	assert.NotNil(t, err)
 }
 
-func TestSearchPlaylistReturnsErrorIfSenderResponseIsNotJson(t *testing.T) {
+func TestGetPlaylistReturnsErrorIfSenderResponseIsNotJson(t *testing.T) {
 	repository := spotifyPlaylistRepository(nonJsonResponseSender())
 
-	_, err := repository.SearchPlaylist(testContext(), searchPlaylistName, searchPlaylistLimit)
+	_, err := repository.GetPlaylist(testContext(), addSongsPlaylistId, 1, 2)
+
+	assert.NotNil(t, err)
+}
+
+func TestUploadCoverSendsBase64EncodedImage(t *testing.T) {
+	sender := emptyResponseSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	err := repository.UploadCover(testContext(), addSongsPlaylistId, coverImage())
+
+	assert.Nil(t, err)
+	assert.Equal(t, uploadCoverHttpOptions(), sender.GetSendArgs())
+}
+
+func TestUploadCoverReturnsErrorWhenNoImageProvided(t *testing.T) {
+	repository := spotifyPlaylistRepository(emptyResponseSender())
+
+	err := repository.UploadCover(testContext(), addSongsPlaylistId, []byte{})
+
+	assert.NotNil(t, err)
+}
+
+func TestUploadCoverReturnsErrorIfEncodedImageTooLarge(t *testing.T) {
+	sender := emptyResponseSender()
+	repository := spotifyPlaylistRepository(sender)
+	repository.SetMaxCoverBytes(7)
+
+	err := repository.UploadCover(testContext(), addSongsPlaylistId, coverImage())
 
 	assert.NotNil(t, err)
+	assert.Equal(t, httpsender.HTTPRequestOptions{}, sender.GetSendArgs())
+}
+
+func TestUploadCoverReturnsErrorOnSendError(t *testing.T) {
+	repository := spotifyPlaylistRepository(errorSender())
+
+	err := repository.UploadCover(testContext(), addSongsPlaylistId, coverImage())
+
+	assert.NotNil(t, err)
+}
+
+func TestSearchUserPlaylistsRequestsAllPages(t *testing.T) {
+	sender := userPlaylistsSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	_, err := repository.SearchUserPlaylists(testContext(), "festival", 0, searchPlaylistLimit)
+
+	assert.Nil(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestSearchUserPlaylistsReturnsEditablePlaylistsMatchingName(t *testing.T) {
+	repository := spotifyPlaylistRepository(userPlaylistsSender())
+
+	actual, err := repository.SearchUserPlaylists(testContext(), "FESTIVAL", 0, searchPlaylistLimit)
+
+	assert.Nil(t, err)
+	assert.Equal(t, pagination.NewPage(expectedUserPlaylists(), 0, 2), actual)
+}
+
+func TestSearchUserPlaylistsReturnsRequestedPage(t *testing.T) {
+	sender := userPlaylistsSender()
+	repository := spotifyPlaylistRepository(sender)
+
+	actual, err := repository.SearchUserPlaylists(testContext(), "festival", 1, 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, pagination.NewPage(expectedUserPlaylists()[1:], 1, 2), actual)
+	sender.AssertExpectations(t)
+}
+
+func TestSearchUserPlaylistsStopsReadingLibraryOncePageIsFilled(t *testing.T) {
+	response := []byte(`
+		{
+			"total": 4,
+			"items": [
+				{"id":"id1","name":"My Festival","public":true,"owner":{"id":"qrRwLBFxQL9fknW8NzBn4JprRNgS"}},
+				{"id":"id2","name":"Festival hits","public":true,"owner":{"id":"qrRwLBFxQL9fknW8NzBn4JprRNgS"}}
+			]
+		}
+	`)
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", userPlaylistsHttpOptions(0)).Return(&response, nil)
+	repository := spotifyPlaylistRepository(&sender)
+
+	actual, err := repository.SearchUserPlaylists(testContext(), "festival", 0, 1)
+
+	assert.Nil(t, err)
+	expected := []playlist.Playlist{{Id: "id1", Name: "My Festival", IsPublic: true}}
+	assert.Equal(t, pagination.NewPage(expected, 0, 2), actual)
+	assert.True(t, actual.HasNext())
+	sender.AssertNumberOfCalls(t, "Send", 1)
 }
 
-func TestSearchPlaylistReturnsSearchedPlaylists(t *testing.T) {
-	repository := spotifyPlaylistRepository(searchPlaylistSender())
+func TestSearchUserPlaylistsReturnsEmptyPageIfOffsetExceedsMatches(t *testing.T) {
+	repository := spotifyPlaylistRepository(userPlaylistsSender())
 
-	actual, err := repository.SearchPlaylist(testContext(), searchPlaylistName, searchPlaylistLimit)
+	actual, err := repository.SearchUserPlaylists(testContext(), "festival", 5, searchPlaylistLimit)
 
-	assert.Equal(t, searchedPlaylists(), actual)
 	assert.Nil(t, err)
+	assert.Equal(t, pagination.NewPage([]playlist.Playlist{}, 5, 2), actual)
+}
+
+func TestSearchUserPlaylistsReturnsEmptyListIfNoneMatches(t *testing.T) {
+	repository := spotifyPlaylistRepository(userPlaylistsSender())
+
+	actual, err := repository.SearchUserPlaylists(testContext(), "wacken", 0, searchPlaylistLimit)
+
+	assert.Nil(t, err)
+	assert.Equal(t, pagination.NewPage([]playlist.Playlist{}, 0, 0), actual)
+}
+
+func TestSearchUserPlaylistsReturnsErrorOnNextPageSendError(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", userPlaylistsHttpOptions(0)).Return(userPlaylistsPageResponse(0), nil)
+	sender.On("Send", userPlaylistsHttpOptions(2)).Return(nil, errors.New("test error"))
+	repository := spotifyPlaylistRepository(&sender)
+
+	_, err := repository.SearchUserPlaylists(testContext(), "festival", 0, searchPlaylistLimit)
+
+	assert.NotNil(t, err)
+}
+
+func TestSearchUserPlaylistsReturnsErrorIfSenderResponseIsNotJson(t *testing.T) {
+	repository := spotifyPlaylistRepository(nonJsonResponseSender())
+
+	_, err := repository.SearchUserPlaylists(testContext(), "festival", 0, searchPlaylistLimit)
+
+	assert.NotNil(t, err)
 }
 
 func TestRepositoryMethodsReturnErrorWhenInvalidToken(t *testing.T) {
@@ -293,13 +861,34 @@ func TestRepositoryMethodsReturnErrorWhenInvalidToken(t *testing.T) {
 			repository := spotifyPlaylistRepository(emptyResponseSender())
 			repository.SetTokenKey(test.repositoryTokenKey)
 
-			err := repository.AddSongs(ctx, addSongsPlaylistId, songsToAdd())
+			_, err := repository.AddSongs(ctx, addSongsPlaylistId, songsToAdd())
+			assert.NotNil(t, err)
+
+			err = repository.ReplaceSongs(ctx, addSongsPlaylistId, songsToAdd())
+			assert.NotNil(t, err)
+
+			err = repository.RemoveSongs(ctx, addSongsPlaylistId, "snapshot", songsToAdd())
+			assert.NotNil(t, err)
+
+			_, err = repository.GetPlaylistTracks(ctx, addSongsPlaylistId)
+			assert.NotNil(t, err)
+
+			_, err = repository.GetPlaylist(ctx, addSongsPlaylistId, 0, 2)
 			assert.NotNil(t, err)
 
 			_, err = repository.CreatePlaylist(ctx, playlistToCreate())
 			assert.NotNil(t, err)
 
-			_, err = repository.SearchPlaylist(ctx, searchPlaylistName, searchPlaylistLimit)
+			err = repository.DeletePlaylist(ctx, addSongsPlaylistId)
+			assert.NotNil(t, err)
+
+			err = repository.UpdatePlaylistDetails(ctx, addSongsPlaylistId, playlistDetails())
+			assert.NotNil(t, err)
+
+			err = repository.UploadCover(ctx, addSongsPlaylistId, coverImage())
+			assert.NotNil(t, err)
+
+			_, err = repository.SearchUserPlaylists(ctx, searchPlaylistName, 0, searchPlaylistLimit)
 			assert.NotNil(t, err)
 		})
 	}
@@ -330,7 +919,7 @@ func TestRepositoryMethodsReturnErrorWhenInvalidUserId(t *testing.T) {
 			repository := spotifyPlaylistRepository(emptyResponseSender())
 			repository.SetUserIdKey(test.repositoryUserIdKey)
 
-			_, err := repository.SearchPlaylist(ctx, searchPlaylistName, searchPlaylistLimit)
+			_, err := repository.SearchUserPlaylists(ctx, searchPlaylistName, 0, searchPlaylistLimit)
 			assert.NotNil(t, err)
 
 			_, err = repository.CreatePlaylist(ctx, playlistToCreate())
@@ -0,0 +1,51 @@
+package spotify
+
+import "festwrap/internal/playlist"
+
+type SpotifyTrackArtist struct {
+	Name string `json:"name"`
+}
+
+type SpotifyTrack struct {
+	Uri        string               `json:"uri"`
+	Name       string               `json:"name"`
+	Artists    []SpotifyTrackArtist `json:"artists"`
+	DurationMs int                  `json:"duration_ms"`
+}
+
+type SpotifyPlaylistTrackItem struct {
+	// Track can be null if it is no longer available
+	Track *SpotifyTrack `json:"track"`
+}
+
+type SpotifyPlaylistTracksResponse struct {
+	Items []SpotifyPlaylistTrackItem `json:"items"`
+	Total int                        `json:"total"`
+}
+
+func (r SpotifyPlaylistTracksResponse) GetTracks() []playlist.PlaylistTrack {
+	tracks := []playlist.PlaylistTrack{}
+	for _, item := range r.Items {
+		if item.Track == nil {
+			continue
+		}
+
+		artists := []string{}
+		for _, artist := range item.Track.Artists {
+			artists = append(artists, artist.Name)
+		}
+		track := playlist.PlaylistTrack{
+			Uri:        item.Track.Uri,
+			Name:       item.Track.Name,
+			Artists:    artists,
+			DurationMs: item.Track.DurationMs,
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks
+}
+
+type SpotifyPlaylistWithTracksResponse struct {
+	SnapshotId string                        `json:"snapshot_id"`
+	Tracks     SpotifyPlaylistTracksResponse `json:"tracks"`
+}
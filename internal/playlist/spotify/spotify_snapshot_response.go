@@ -0,0 +1,5 @@
+package spotify
+
+type SpotifySnapshotResponse struct {
+	SnapshotId string `json:"snapshot_id"`
+}
@@ -15,3 +15,20 @@ func NewSpotifySongs(songs []song.Song) SpotifySongs {
 	}
 	return SpotifySongs{Uris: songUris}
 }
+
+type SpotifySongUri struct {
+	Uri string `json:"uri"`
+}
+
+type SpotifyRemoveSongs struct {
+	Tracks     []SpotifySongUri `json:"tracks"`
+	SnapshotId string           `json:"snapshot_id,omitempty"`
+}
+
+func NewSpotifyRemoveSongs(songs []song.Song, snapshotId string) SpotifyRemoveSongs {
+	songUris := []SpotifySongUri{}
+	for _, currentSong := range songs {
+		songUris = append(songUris, SpotifySongUri{Uri: currentSong.GetUri()})
+	}
+	return SpotifyRemoveSongs{Tracks: songUris, SnapshotId: snapshotId}
+}
@@ -20,8 +20,7 @@ type ExistingPlaylistUpdateBuilder struct {
 }
 
 type NewPlaylistUpdateBuilder struct {
-	playlistService playlist.PlaylistService
-	deserializer    serialization.Deserializer[NewPlaylistUpdate]
+	deserializer serialization.Deserializer[NewPlaylistUpdate]
 }
 
 func NewExistingPlaylistUpdateBuilder(pathId string) ExistingPlaylistUpdateBuilder {
@@ -49,18 +48,27 @@ func (b *ExistingPlaylistUpdateBuilder) Build(request *http.Request) (playlist.P
 		return playlist.PlaylistUpdate{}, errors.New("failed to deserialize playlist artists: " + err.Error())
 	}
 
+	mode, err := playlist.NewUpdateMode(artists.Mode)
+	if err != nil {
+		return playlist.PlaylistUpdate{}, err
+	}
+
 	updateArtists := make([]playlist.PlaylistArtist, len(artists.Artists))
 	for i, artist := range artists.Artists {
-		updateArtists[i] = playlist.PlaylistArtist{Name: artist.Name}
+		updateArtists[i] = artist.toPlaylistArtist()
+	}
+	update := playlist.PlaylistUpdate{
+		PlaylistId:     playlistId,
+		Artists:        updateArtists,
+		Mode:           mode,
+		RelatedArtists: artists.RelatedArtists,
 	}
-	update := playlist.PlaylistUpdate{PlaylistId: playlistId, Artists: updateArtists}
 	return update, nil
 }
 
-func NewNewPlaylistUpdateBuilder(playlistService playlist.PlaylistService) NewPlaylistUpdateBuilder {
+func NewNewPlaylistUpdateBuilder() NewPlaylistUpdateBuilder {
 	return NewPlaylistUpdateBuilder{
-		playlistService: playlistService,
-		deserializer:    serialization.NewJsonDeserializer[NewPlaylistUpdate](),
+		deserializer: serialization.NewJsonDeserializer[NewPlaylistUpdate](),
 	}
 }
 
@@ -77,17 +85,19 @@ func (b *NewPlaylistUpdateBuilder) Build(request *http.Request) (playlist.Playli
 		return playlist.PlaylistUpdate{}, errors.New("failed to deserialize playlist information: " + err.Error())
 	}
 
-	playlistId, err := b.playlistService.CreatePlaylist(
-		request.Context(),
-		update.Playlist.toPlaylist(),
-	)
-	if err != nil {
-		return playlist.PlaylistUpdate{}, errors.New("could not create playlist")
-	}
-
 	playlistArtists := make([]playlist.PlaylistArtist, len(update.Artists))
 	for i, artist := range update.Artists {
-		playlistArtists[i] = playlist.PlaylistArtist{Name: artist.Name}
+		playlistArtists[i] = artist.toPlaylistArtist()
 	}
-	return playlist.PlaylistUpdate{PlaylistId: playlistId, Artists: playlistArtists}, nil
+	// The playlist is created once the update is validated, and being empty songs are always appended
+	newPlaylist := update.Playlist.toPlaylist()
+	return playlist.PlaylistUpdate{
+		Artists:             playlistArtists,
+		Mode:                playlist.AppendMode,
+		NewPlaylist:         &newPlaylist,
+		Name:                update.Playlist.Name,
+		GenerateCover:       update.Playlist.GenerateCover,
+		GenerateDescription: update.Playlist.GenerateDescription,
+		RelatedArtists:      update.RelatedArtists,
+	}, nil
 }
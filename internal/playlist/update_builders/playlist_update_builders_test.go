@@ -2,15 +2,13 @@ package update_builders
 
 import (
 	"bytes"
-	"context"
-	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 
 	"festwrap/internal/playlist"
-	mocks "festwrap/internal/playlist/mocks"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,23 +51,10 @@ func playlistUpdate() playlist.PlaylistUpdate {
 			{Name: "Silverstein"},
 			{Name: "Chinese Football"},
 		},
+		Mode: playlist.AppendMode,
 	}
 }
 
-func playlistService() *mocks.PlaylistServiceMock {
-	service := mocks.NewPlaylistServiceMock()
-	playlist := newPlaylistUpdate().Playlist.toPlaylist()
-	service.On("CreatePlaylist", context.Background(), playlist).Return(playlistId, nil)
-	return &service
-}
-
-func playlistErrorService() *mocks.PlaylistServiceMock {
-	service := mocks.NewPlaylistServiceMock()
-	playlist := newPlaylistUpdate().Playlist.toPlaylist()
-	service.On("CreatePlaylist", context.Background(), playlist).Return("", errors.New("test error"))
-	return &service
-}
-
 func buildRequest(t *testing.T, playlistId string, body []byte) *http.Request {
 	t.Helper()
 	requestUrl, err := url.Parse("https://some_url")
@@ -95,7 +80,7 @@ func TestExistingUpdateBuilderReturnsErrorIfPlaylistIdNotProvided(t *testing.T)
 
 func TestBuildersReturnErrorOnIncorrectBody(t *testing.T) {
 	existingPlaylistBuilder := NewExistingPlaylistUpdateBuilder(playlistIdPath)
-	newPlaylistBuilder := NewNewPlaylistUpdateBuilder(playlistService())
+	newPlaylistBuilder := NewNewPlaylistUpdateBuilder()
 	tests := map[string]struct {
 		builder PlaylistUpdateBuilder
 	}{
@@ -131,22 +116,130 @@ func TestExistingUpdateBuilderReturnsUpdate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestNewUpdateBuilderReturnsErrorOnPlaylistCration(t *testing.T) {
-	request := buildRequest(t, playlistId, newPlaylistUpdateBody())
-	builder := NewNewPlaylistUpdateBuilder(playlistErrorService())
+func TestExistingUpdateBuilderReturnsArtistIds(t *testing.T) {
+	body := []byte(`{"artists":[{"name":"Silverstein","spotifyId":"someId","mbid":"someMbid"},{"name":"Chinese Football"}]}`)
+	request := buildRequest(t, playlistId, body)
+	builder := NewExistingPlaylistUpdateBuilder(playlistIdPath)
 
-	_, err := builder.Build(request)
+	actual, err := builder.Build(request)
 
-	assert.NotNil(t, err)
+	expected := playlistUpdate()
+	expected.Artists[0] = playlist.PlaylistArtist{Name: "Silverstein", SpotifyId: "someId", MusicBrainzId: "someMbid"}
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, err)
+}
+
+func TestExistingUpdateBuilderReturnsRelatedArtists(t *testing.T) {
+	body := []byte(`{"artists":[{"name":"Silverstein"},{"name":"Chinese Football"}],"relatedArtists":3}`)
+	request := buildRequest(t, playlistId, body)
+	builder := NewExistingPlaylistUpdateBuilder(playlistIdPath)
+
+	actual, err := builder.Build(request)
+
+	expected := playlistUpdate()
+	expected.RelatedArtists = 3
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, err)
 }
 
 func TestNewUpdateBuilderReturnsUpdate(t *testing.T) {
 	request := buildRequest(t, playlistId, newPlaylistUpdateBody())
-	builder := NewNewPlaylistUpdateBuilder(playlistService())
+	builder := NewNewPlaylistUpdateBuilder()
 
 	actual, err := builder.Build(request)
 
+	newPlaylist := newPlaylistUpdate().Playlist.toPlaylist()
 	expected := playlistUpdate()
+	expected.PlaylistId = ""
+	expected.NewPlaylist = &newPlaylist
+	expected.Name = "Emo songs"
 	assert.Equal(t, expected, actual)
 	assert.Nil(t, err)
 }
+
+func TestNewUpdateBuilderReturnsCoverGeneration(t *testing.T) {
+	body := []byte(`{
+        "playlist": {"name": "Emo songs", "description": "Classic emo songs", "generateCover": true},
+        "artists": [{"name": "Silverstein"}]
+    }`)
+	request := buildRequest(t, playlistId, body)
+	builder := NewNewPlaylistUpdateBuilder()
+
+	actual, err := builder.Build(request)
+
+	assert.Nil(t, err)
+	assert.True(t, actual.GenerateCover)
+}
+
+func TestNewUpdateBuilderReturnsRelatedArtists(t *testing.T) {
+	body := []byte(`{
+        "playlist": {"name": "Emo songs", "description": "Classic emo songs"},
+        "artists": [{"name": "Silverstein"}],
+        "relatedArtists": 2
+    }`)
+	request := buildRequest(t, playlistId, body)
+	builder := NewNewPlaylistUpdateBuilder()
+
+	actual, err := builder.Build(request)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, actual.RelatedArtists)
+}
+
+func TestNewUpdateBuilderReturnsDescriptionGeneration(t *testing.T) {
+	body := []byte(`{
+        "playlist": {"name": "Emo songs", "description": "Classic emo songs", "generateDescription": true},
+        "artists": [{"name": "Silverstein"}]
+    }`)
+	request := buildRequest(t, playlistId, body)
+	builder := NewNewPlaylistUpdateBuilder()
+
+	actual, err := builder.Build(request)
+
+	assert.Nil(t, err)
+	assert.True(t, actual.GenerateDescription)
+}
+
+func TestExistingUpdateBuilderReturnsUpdateMode(t *testing.T) {
+	tests := map[string]struct {
+		mode     string
+		expected playlist.UpdateMode
+	}{
+		"append": {
+			mode:     "append",
+			expected: playlist.AppendMode,
+		},
+		"replace": {
+			mode:     "replace",
+			expected: playlist.ReplaceMode,
+		},
+		"sync": {
+			mode:     "sync",
+			expected: playlist.SyncMode,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			body := fmt.Appendf(nil, `{"artists":[{"name":"Silverstein"}],"mode":"%s"}`, test.mode)
+			request := buildRequest(t, playlistId, body)
+			builder := NewExistingPlaylistUpdateBuilder(playlistIdPath)
+
+			actual, err := builder.Build(request)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual.Mode)
+		})
+	}
+}
+
+func TestExistingUpdateBuilderReturnsErrorOnUnknownMode(t *testing.T) {
+	body := []byte(`{"artists":[{"name":"Silverstein"}],"mode":"something"}`)
+	request := buildRequest(t, playlistId, body)
+	builder := NewExistingPlaylistUpdateBuilder(playlistIdPath)
+
+	_, err := builder.Build(request)
+
+	assert.NotNil(t, err)
+}
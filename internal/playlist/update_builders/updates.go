@@ -3,17 +3,31 @@ package update_builders
 import "festwrap/internal/playlist"
 
 type PlaylistArtist struct {
-	Name string `json:"name"`
+	Name          string `json:"name"`
+	SpotifyId     string `json:"spotifyId,omitempty"`
+	MusicBrainzId string `json:"mbid,omitempty"`
+}
+
+func (a PlaylistArtist) toPlaylistArtist() playlist.PlaylistArtist {
+	return playlist.PlaylistArtist{
+		Name:          a.Name,
+		SpotifyId:     a.SpotifyId,
+		MusicBrainzId: a.MusicBrainzId,
+	}
 }
 
 type ExistingPlaylistUpdate struct {
-	Artists []PlaylistArtist `json:"artists"`
+	Artists        []PlaylistArtist `json:"artists"`
+	Mode           string           `json:"mode,omitempty"`
+	RelatedArtists int              `json:"relatedArtists,omitempty"`
 }
 
 type NewPlaylist struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	IsPublic    bool   `json:"isPublic"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	IsPublic            bool   `json:"isPublic"`
+	GenerateCover       bool   `json:"generateCover,omitempty"`
+	GenerateDescription bool   `json:"generateDescription,omitempty"`
 }
 
 func (p NewPlaylist) toPlaylist() playlist.Playlist {
@@ -0,0 +1,43 @@
+package serialization
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// Spreadsheets run cells starting with these characters as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+// Encodes objects as CSV, using the given function to turn each of them into rows. Cells which would
+// be run as formulas when opened in a spreadsheet are prefixed with a quote, so they are shown as text
+type CsvEncoder[T any] struct {
+	header  []string
+	records func(object T) [][]string
+}
+
+func NewCsvEncoder[T any](header []string, records func(object T) [][]string) CsvEncoder[T] {
+	return CsvEncoder[T]{header: header, records: records}
+}
+
+func (e CsvEncoder[T]) Encode(w io.Writer, object T) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(e.header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(escapeFormulas(e.records(object))); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func escapeFormulas(records [][]string) [][]string {
+	for _, record := range records {
+		for i, cell := range record {
+			if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+				record[i] = "'" + cell
+			}
+		}
+	}
+	return records
+}
@@ -0,0 +1,68 @@
+package serialization
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func objectRecords(objects []Object) [][]string {
+	records := make([][]string, len(objects))
+	for i, object := range objects {
+		records[i] = []string{object.Name, fmt.Sprint(object.Value)}
+	}
+	return records
+}
+
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("test error")
+}
+
+func TestCsvEncoderWritesHeaderAndRecords(t *testing.T) {
+	encoder := NewCsvEncoder([]string{"name", "value"}, objectRecords)
+	objects := []Object{{Name: "myname", Value: 10}, {Name: "other, name", Value: 2}}
+	buffer := bytes.Buffer{}
+
+	err := encoder.Encode(&buffer, objects)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "name,value\nmyname,10\n\"other, name\",2\n", buffer.String())
+}
+
+func TestCsvEncoderEscapesFormulas(t *testing.T) {
+	encoder := NewCsvEncoder([]string{"name", "value"}, objectRecords)
+	objects := []Object{
+		{Name: "=HYPERLINK(\"http://example.com\")", Value: 1},
+		{Name: "+1", Value: 2},
+		{Name: "-1", Value: 3},
+		{Name: "@SUM(A1)", Value: 4},
+		{Name: "\tname", Value: 5},
+		{Name: "a=b", Value: 6},
+	}
+	buffer := bytes.Buffer{}
+
+	err := encoder.Encode(&buffer, objects)
+
+	expected := "name,value\n" +
+		"\"'=HYPERLINK(\"\"http://example.com\"\")\",1\n" +
+		"'+1,2\n" +
+		"'-1,3\n" +
+		"'@SUM(A1),4\n" +
+		"'\tname,5\n" +
+		"a=b,6\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestCsvEncoderReturnsErrorOnWriteError(t *testing.T) {
+	encoder := NewCsvEncoder([]string{"name", "value"}, objectRecords)
+
+	err := encoder.Encode(failingWriter{}, []Object{serializableObject()})
+
+	assert.NotNil(t, err)
+}
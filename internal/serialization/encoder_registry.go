@@ -0,0 +1,107 @@
+package serialization
+
+import (
+	"cmp"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	JsonMediaType   = "application/json"
+	NdjsonMediaType = "application/x-ndjson"
+	CsvMediaType    = "text/csv"
+)
+
+// Encoders for the same object keyed by media type, so clients can choose the format through
+// the Accept header. The first encoder registered is used when clients accept any type
+type EncoderRegistry[T any] struct {
+	mediaTypes []string
+	encoders   map[string]Encoder[T]
+}
+
+type acceptedMediaType struct {
+	mediaType string
+	quality   float64
+}
+
+func NewEncoderRegistry[T any]() EncoderRegistry[T] {
+	return EncoderRegistry[T]{mediaTypes: []string{}, encoders: map[string]Encoder[T]{}}
+}
+
+// Registers the encoder for the media type, replacing the existing one if any
+func (r *EncoderRegistry[T]) Register(mediaType string, encoder Encoder[T]) {
+	mediaType = strings.ToLower(mediaType)
+	if _, ok := r.encoders[mediaType]; !ok {
+		r.mediaTypes = append(r.mediaTypes, mediaType)
+	}
+	r.encoders[mediaType] = encoder
+}
+
+// Returns the registered media types, in registration order
+func (r *EncoderRegistry[T]) MediaTypes() []string {
+	return slices.Clone(r.mediaTypes)
+}
+
+// Returns the media type and encoder preferred by the given Accept header value,
+// or false if none of the accepted media types is registered
+func (r *EncoderRegistry[T]) Negotiate(accept string) (string, Encoder[T], bool) {
+	if len(r.mediaTypes) == 0 {
+		return "", nil, false
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return r.mediaTypes[0], r.encoders[r.mediaTypes[0]], true
+	}
+
+	for _, accepted := range parseAccept(accept) {
+		if mediaType, ok := r.match(accepted.mediaType); ok {
+			return mediaType, r.encoders[mediaType], true
+		}
+	}
+	return "", nil, false
+}
+
+func (r *EncoderRegistry[T]) match(accepted string) (string, bool) {
+	if accepted == "*/*" {
+		return r.mediaTypes[0], true
+	}
+
+	if prefix, ok := strings.CutSuffix(accepted, "/*"); ok {
+		for _, mediaType := range r.mediaTypes {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return mediaType, true
+			}
+		}
+		return "", false
+	}
+
+	_, ok := r.encoders[accepted]
+	return accepted, ok
+}
+
+// Returns the accepted media types, most preferred first. The ones with zero quality are discarded
+func parseAccept(accept string) []acceptedMediaType {
+	result := []acceptedMediaType{}
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(value)
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if qualityStr, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(qualityStr, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			result = append(result, acceptedMediaType{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b acceptedMediaType) int { return cmp.Compare(b.quality, a.quality) })
+	return result
+}
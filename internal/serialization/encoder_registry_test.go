@@ -0,0 +1,121 @@
+package serialization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEncoderRegistry() EncoderRegistry[[]Object] {
+	registry := NewEncoderRegistry[[]Object]()
+	registry.Register(JsonMediaType, NewJsonEncoder[[]Object]())
+	registry.Register(NdjsonMediaType, NewNdjsonSliceEncoder[Object]())
+	registry.Register(CsvMediaType, NewCsvEncoder([]string{"name"}, func([]Object) [][]string { return nil }))
+	return registry
+}
+
+func TestEncoderRegistryNegotiatesMediaType(t *testing.T) {
+	tests := map[string]struct {
+		accept   string
+		expected string
+	}{
+		"empty header": {
+			accept:   "",
+			expected: JsonMediaType,
+		},
+		"any type": {
+			accept:   "*/*",
+			expected: JsonMediaType,
+		},
+		"exact type": {
+			accept:   "text/csv",
+			expected: CsvMediaType,
+		},
+		"type with parameters": {
+			accept:   "text/csv; charset=utf-8",
+			expected: CsvMediaType,
+		},
+		"case insensitive": {
+			accept:   "Application/X-NDJSON",
+			expected: NdjsonMediaType,
+		},
+		"subtype wildcard": {
+			accept:   "text/*",
+			expected: CsvMediaType,
+		},
+		"first supported type": {
+			accept:   "application/xml, application/x-ndjson, text/csv",
+			expected: NdjsonMediaType,
+		},
+		"highest quality": {
+			accept:   "application/json;q=0.5, text/csv;q=0.9, */*;q=0.1",
+			expected: CsvMediaType,
+		},
+		"unsupported type with wildcard fallback": {
+			accept:   "application/xml, */*;q=0.1",
+			expected: JsonMediaType,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			registry := testEncoderRegistry()
+
+			mediaType, encoder, ok := registry.Negotiate(test.accept)
+
+			assert.True(t, ok)
+			assert.Equal(t, test.expected, mediaType)
+			assert.NotNil(t, encoder)
+		})
+	}
+}
+
+func TestEncoderRegistryFailsOnUnsupportedMediaType(t *testing.T) {
+	tests := map[string]struct {
+		accept string
+	}{
+		"unsupported type": {
+			accept: "application/xml",
+		},
+		"unsupported wildcard": {
+			accept: "image/*",
+		},
+		"excluded type": {
+			accept: "application/json;q=0",
+		},
+		"invalid header": {
+			accept: "not a media type",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			registry := testEncoderRegistry()
+
+			_, _, ok := registry.Negotiate(test.accept)
+
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestEncoderRegistryFailsIfEmpty(t *testing.T) {
+	registry := NewEncoderRegistry[[]Object]()
+
+	_, _, ok := registry.Negotiate("")
+
+	assert.False(t, ok)
+}
+
+func TestEncoderRegistryReplacesEncoderKeepingOrder(t *testing.T) {
+	registry := testEncoderRegistry()
+	replacement := FakeEncoder[[]Object]{}
+
+	registry.Register(JsonMediaType, replacement)
+	mediaType, encoder, _ := registry.Negotiate("*/*")
+
+	assert.Equal(t, JsonMediaType, mediaType)
+	assert.Equal(t, replacement, encoder)
+}
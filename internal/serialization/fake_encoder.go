@@ -5,13 +5,22 @@ import (
 )
 
 type FakeEncoder[T any] struct {
-	err error
+	err           error
+	partialOutput string
 }
 
 func (e *FakeEncoder[T]) SetError(err error) {
 	e.err = err
 }
 
+// Output written before failing, like encoders which fail halfway through the object
+func (e *FakeEncoder[T]) SetPartialOutput(output string) {
+	e.partialOutput = output
+}
+
 func (e FakeEncoder[T]) Encode(w io.Writer, object T) error {
+	if e.err != nil && e.partialOutput != "" {
+		io.WriteString(w, e.partialOutput)
+	}
 	return e.err
 }
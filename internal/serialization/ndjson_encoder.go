@@ -0,0 +1,30 @@
+package serialization
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Encodes objects as newline-delimited JSON, writing a line for each of the items the given function returns
+type NdjsonEncoder[T any, I any] struct {
+	items func(object T) []I
+}
+
+func NewNdjsonEncoder[T any, I any](items func(object T) []I) NdjsonEncoder[T, I] {
+	return NdjsonEncoder[T, I]{items: items}
+}
+
+// Writes a line for each element in the slice
+func NewNdjsonSliceEncoder[I any]() NdjsonEncoder[[]I, I] {
+	return NewNdjsonEncoder(func(items []I) []I { return items })
+}
+
+func (e NdjsonEncoder[T, I]) Encode(w io.Writer, object T) error {
+	encoder := json.NewEncoder(w)
+	for _, item := range e.items(object) {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
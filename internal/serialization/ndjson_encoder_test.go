@@ -0,0 +1,37 @@
+package serialization
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNdjsonEncoderWritesLinePerItem(t *testing.T) {
+	encoder := NewNdjsonSliceEncoder[Object]()
+	objects := []Object{serializableObject(), {Name: "other", Value: 2}}
+	buffer := bytes.Buffer{}
+
+	err := encoder.Encode(&buffer, objects)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"name\":\"myname\",\"value\":10}\n{\"name\":\"other\",\"value\":2}\n", buffer.String())
+}
+
+func TestNdjsonEncoderWritesNothingWithoutItems(t *testing.T) {
+	encoder := NewNdjsonSliceEncoder[Object]()
+	buffer := bytes.Buffer{}
+
+	err := encoder.Encode(&buffer, []Object{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", buffer.String())
+}
+
+func TestNdjsonEncoderReturnsErrorOnNonSerializableItem(t *testing.T) {
+	encoder := NewNdjsonSliceEncoder[NonSerializableObject]()
+
+	err := encoder.Encode(&bytes.Buffer{}, []NonSerializableObject{nonSerializableObject()})
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,15 @@
+package errors
+
+// Returned when the artist has no setlist with enough songs, as opposed to the setlist not being
+// retrievable, so other sources can be tried
+type SetlistNotFoundError struct {
+	message string
+}
+
+func NewSetlistNotFoundError(message string) error {
+	return &SetlistNotFoundError{message: message}
+}
+
+func (e *SetlistNotFoundError) Error() string {
+	return e.message
+}
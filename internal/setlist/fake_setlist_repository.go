@@ -1,12 +1,15 @@
 package setlist
 
+import "context"
+
 type FakeSetlistRepository struct {
 	getArgs  GetSetlistArgs
 	getValue getSetlistValue
 }
 
 type GetSetlistArgs struct {
-	Artist   string
+	Context  context.Context
+	Artist   SetlistArtist
 	MinSongs int
 }
 
@@ -19,9 +22,12 @@ func NewFakeSetlistRepository() FakeSetlistRepository {
 	return FakeSetlistRepository{}
 }
 
-func (s *FakeSetlistRepository) GetSetlist(artist string, minSongs int) (*Setlist, error) {
-	s.getArgs = GetSetlistArgs{Artist: artist, MinSongs: minSongs}
-	return &s.getValue.response, s.getValue.err
+func (s *FakeSetlistRepository) GetSetlist(ctx context.Context, artist SetlistArtist, minSongs int) (*Setlist, error) {
+	s.getArgs = GetSetlistArgs{Context: ctx, Artist: artist, MinSongs: minSongs}
+	if s.getValue.err != nil {
+		return nil, s.getValue.err
+	}
+	return &s.getValue.response, nil
 }
 
 func (s *FakeSetlistRepository) GetGetSetlistArgs() GetSetlistArgs {
@@ -0,0 +1,42 @@
+package setlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	setlisterrors "festwrap/internal/setlist/errors"
+)
+
+// Searches the setlist in each repository in order, returning the first one found. The next repository
+// is only tried if the setlist is not found, so other errors like expired tokens are returned as they are
+type FallbackSetlistRepository struct {
+	repositories []SetlistRepository
+}
+
+func NewFallbackSetlistRepository(repositories ...SetlistRepository) FallbackSetlistRepository {
+	return FallbackSetlistRepository{repositories: repositories}
+}
+
+func (r *FallbackSetlistRepository) GetSetlist(
+	ctx context.Context,
+	artist SetlistArtist,
+	minSongs int,
+) (*Setlist, error) {
+	if len(r.repositories) == 0 {
+		return nil, setlisterrors.NewCannotRetrieveSetlistError("No setlist repositories configured")
+	}
+
+	for _, repository := range r.repositories {
+		setlist, err := repository.GetSetlist(ctx, artist, minSongs)
+		if err == nil {
+			return setlist, nil
+		}
+		var notFound *setlisterrors.SetlistNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+	}
+
+	return nil, setlisterrors.NewSetlistNotFoundError(fmt.Sprintf("No setlists for artist %s", artist.Name))
+}
@@ -0,0 +1,67 @@
+package setlist
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	senderrors "festwrap/internal/http/sender/errors"
+	setlisterrors "festwrap/internal/setlist/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fallbackSetup() (FallbackSetlistRepository, *FakeSetlistRepository, *FakeSetlistRepository) {
+	primary := NewFakeSetlistRepository()
+	primary.SetReturnValue(NewSetlist("Turnstile", []Song{NewSong("Mystery")}))
+	secondary := NewFakeSetlistRepository()
+	secondary.SetReturnValue(*topTracksSetlist())
+	return NewFallbackSetlistRepository(&primary, &secondary), &primary, &secondary
+}
+
+func TestFallbackGetSetlistReturnsFirstSetlistFound(t *testing.T) {
+	repository, _, secondary := fallbackSetup()
+
+	actual, err := repository.GetSetlist(context.Background(), SetlistArtist{Name: "Turnstile"}, 1)
+
+	expected := NewSetlist("Turnstile", []Song{NewSong("Mystery")})
+	assert.Nil(t, err)
+	assert.Equal(t, &expected, actual)
+	assert.Equal(t, GetSetlistArgs{}, secondary.GetGetSetlistArgs())
+}
+
+func TestFallbackGetSetlistTriesNextRepositoryIfNotFound(t *testing.T) {
+	repository, primary, secondary := fallbackSetup()
+	primary.SetError(setlisterrors.NewSetlistNotFoundError("no setlist"))
+	artist := SetlistArtist{Name: "Turnstile"}
+
+	actual, err := repository.GetSetlist(context.Background(), artist, 1)
+
+	expected := GetSetlistArgs{Context: context.Background(), Artist: artist, MinSongs: 1}
+	assert.Nil(t, err)
+	assert.Equal(t, topTracksSetlist(), actual)
+	assert.Equal(t, expected, secondary.GetGetSetlistArgs())
+}
+
+func TestFallbackGetSetlistReturnsErrorIfNoneFound(t *testing.T) {
+	repository, primary, secondary := fallbackSetup()
+	primary.SetError(setlisterrors.NewSetlistNotFoundError("no setlist"))
+	secondary.SetError(setlisterrors.NewSetlistNotFoundError("no top tracks"))
+
+	_, err := repository.GetSetlist(context.Background(), SetlistArtist{Name: "Turnstile"}, 1)
+
+	var notFound *setlisterrors.SetlistNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "No setlists for artist Turnstile", err.Error())
+}
+
+func TestFallbackGetSetlistReturnsOtherErrorsWithoutTryingNextRepository(t *testing.T) {
+	repository, primary, secondary := fallbackSetup()
+	authErr := senderrors.NewUpstreamError("expired token", http.StatusUnauthorized, http.Header{}, "")
+	primary.SetError(authErr)
+
+	_, err := repository.GetSetlist(context.Background(), SetlistArtist{Name: "Turnstile"}, 1)
+
+	assert.Equal(t, authErr, err)
+	assert.Equal(t, GetSetlistArgs{}, secondary.GetGetSetlistArgs())
+}
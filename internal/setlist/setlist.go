@@ -1,8 +1,24 @@
 package setlist
 
+import "time"
+
+// Where the songs of a setlist come from
+type Source string
+
+const (
+	// Songs played in a concert, as registered in setlist.fm
+	SetlistFMSource Source = "setlistfm"
+	// Most popular songs of the artist in Spotify, used when no concert is found
+	TopTracksSource Source = "topTracks"
+)
+
 type Setlist struct {
 	artist string
 	songs  []Song
+	date   time.Time
+	venue  string
+	tour   string
+	source Source
 }
 
 func NewSetlist(artist string, songs []Song) Setlist {
@@ -16,3 +32,36 @@ func (s Setlist) GetArtist() string {
 func (s Setlist) GetSongs() []Song {
 	return s.songs
 }
+
+// Date of the concert where the setlist was played. Zero if unknown
+func (s Setlist) GetDate() time.Time {
+	return s.date
+}
+
+func (s Setlist) GetVenue() string {
+	return s.venue
+}
+
+func (s Setlist) GetTour() string {
+	return s.tour
+}
+
+func (s Setlist) GetSource() Source {
+	return s.source
+}
+
+func (s *Setlist) SetDate(date time.Time) {
+	s.date = date
+}
+
+func (s *Setlist) SetVenue(venue string) {
+	s.venue = venue
+}
+
+func (s *Setlist) SetTour(tour string) {
+	s.tour = tour
+}
+
+func (s *Setlist) SetSource(source Source) {
+	s.source = source
+}
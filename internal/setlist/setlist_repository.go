@@ -1,5 +1,14 @@
 package setlist
 
+import "context"
+
+// Artist whose setlist is searched. Ids are optional and preferred over the name when present
+type SetlistArtist struct {
+	Name          string
+	MusicBrainzId string
+	SpotifyId     string
+}
+
 type SetlistRepository interface {
-	GetSetlist(artist string, minSongs int) (*Setlist, error)
+	GetSetlist(ctx context.Context, artist SetlistArtist, minSongs int) (*Setlist, error)
 }
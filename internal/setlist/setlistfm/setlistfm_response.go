@@ -1,9 +1,14 @@
 package setlistfm
 
 import (
+	"fmt"
+	"time"
+
 	"festwrap/internal/setlist"
 )
 
+const setlistfmDateLayout = "02-01-2006"
+
 type setlistfmSong struct {
 	Name string `json:"name"`
 }
@@ -20,9 +25,25 @@ type setlistFMSets struct {
 	Sets []setlistfmSet `json:"set"`
 }
 
+type setlistfmCity struct {
+	Name string `json:"name"`
+}
+
+type setlistfmVenue struct {
+	Name string        `json:"name"`
+	City setlistfmCity `json:"city"`
+}
+
+type setlistfmTour struct {
+	Name string `json:"name"`
+}
+
 type setlistFMSetlist struct {
-	Artist setlistfmArtist `json:"artist"`
-	Sets   setlistFMSets   `json:"sets"`
+	Artist    setlistfmArtist `json:"artist"`
+	Sets      setlistFMSets   `json:"sets"`
+	EventDate string          `json:"eventDate"`
+	Venue     setlistfmVenue  `json:"venue"`
+	Tour      setlistfmTour   `json:"tour"`
 }
 
 func (s *setlistFMSetlist) GetSongs() []setlist.Song {
@@ -35,6 +56,25 @@ func (s *setlistFMSetlist) GetSongs() []setlist.Song {
 	return songs
 }
 
+func (s *setlistFMSetlist) GetVenue() string {
+	if s.Venue.Name == "" || s.Venue.City.Name == "" {
+		return s.Venue.Name
+	}
+	return fmt.Sprintf("%s, %s", s.Venue.Name, s.Venue.City.Name)
+}
+
+func (s *setlistFMSetlist) ToSetlist() setlist.Setlist {
+	result := setlist.NewSetlist(s.Artist.Name, s.GetSongs())
+	// Date is only informative, so setlists are still returned if it cannot be parsed
+	if date, err := time.Parse(setlistfmDateLayout, s.EventDate); err == nil {
+		result.SetDate(date)
+	}
+	result.SetVenue(s.GetVenue())
+	result.SetTour(s.Tour.Name)
+	result.SetSource(setlist.SetlistFMSource)
+	return result
+}
+
 type setlistFMResponse struct {
 	Body []setlistFMSetlist `json:"setlist"`
 }
@@ -42,7 +82,7 @@ type setlistFMResponse struct {
 func (s setlistFMResponse) findSetlistWithMinSongs(minSongs int) *setlist.Setlist {
 	var result *setlist.Setlist
 	for _, set := range s.Body {
-		currentSetlist := setlist.NewSetlist(set.Artist.Name, set.GetSongs())
+		currentSetlist := set.ToSetlist()
 		if len(currentSetlist.GetSongs()) >= minSongs {
 			result = &currentSetlist
 			break
@@ -1,13 +1,17 @@
 package setlistfm
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
 	"festwrap/internal/serialization"
 	"festwrap/internal/setlist"
-	"festwrap/internal/setlist/errors"
+	setlisterrors "festwrap/internal/setlist/errors"
 )
 
 type SetlistFMRepository struct {
@@ -33,14 +37,26 @@ func (r *SetlistFMRepository) SetDeserializer(deserializer serialization.Deseria
 	r.deserializer = deserializer
 }
 
-func (r *SetlistFMRepository) GetSetlist(artist string, minSongs int) (*setlist.Setlist, error) {
+// Artists are searched by MusicBrainz id if provided, since names can be shared by several artists
+func (r *SetlistFMRepository) GetSetlist(
+	ctx context.Context,
+	artist setlist.SetlistArtist,
+	minSongs int,
+) (*setlist.Setlist, error) {
+	if artist.MusicBrainzId != "" {
+		return r.findSetlist("artistMbid", artist.MusicBrainzId, minSongs)
+	}
+	return r.findSetlist("artistName", artist.Name, minSongs)
+}
 
+// Searches setlists filtering by the given parameter, which identifies the artist
+func (r *SetlistFMRepository) findSetlist(param string, artist string, minSongs int) (*setlist.Setlist, error) {
 	page := 1
 	var setlist *setlist.Setlist
 	var err error
 
 	for page <= r.maxPages {
-		setlist, err = r.getFirstSetlistFromPage(artist, page, minSongs)
+		setlist, err = r.getFirstSetlistFromPage(param, artist, page, minSongs)
 		resultOrErrorFound := setlist != nil || err != nil
 		if resultOrErrorFound {
 			break
@@ -49,33 +65,49 @@ func (r *SetlistFMRepository) GetSetlist(artist string, minSongs int) (*setlist.
 		}
 	}
 
-	if setlist == nil {
+	if err != nil {
+		return nil, err
+	} else if setlist == nil {
 		errorMsg := fmt.Sprintf("Could not find setlist for artist %s", artist)
-		return nil, errors.NewCannotRetrieveSetlistError(errorMsg)
+		return nil, setlisterrors.NewSetlistNotFoundError(errorMsg)
 	} else {
 		return setlist, nil
 	}
 }
 
-func (r *SetlistFMRepository) getFirstSetlistFromPage(artist string, page int, minSongs int) (*setlist.Setlist, error) {
-	httpOptions := r.createSetlistHttpOptions(artist, page)
+func (r *SetlistFMRepository) getFirstSetlistFromPage(
+	param string,
+	artist string,
+	page int,
+	minSongs int,
+) (*setlist.Setlist, error) {
+	httpOptions := r.createSetlistHttpOptions(param, artist, page)
 	responseBody, err := r.httpSender.Send(httpOptions)
-	if err != nil {
-		return nil, errors.NewCannotRetrieveSetlistError(err.Error())
+	// Setlist.fm answers with not found when the artist has no setlists at all. Its error is not kept,
+	// since the message includes the request sent along with the API key
+	var upstreamErr *senderrors.UpstreamError
+	if errors.As(err, &upstreamErr) && upstreamErr.StatusCode() == http.StatusNotFound {
+		return nil, setlisterrors.NewSetlistNotFoundError(fmt.Sprintf("No setlists for artist %s", artist))
+	} else if err != nil {
+		return nil, setlisterrors.NewCannotRetrieveSetlistError(err.Error())
 	}
 
 	var response setlistFMResponse
 	err = r.deserializer.Deserialize(*responseBody, &response)
 	if err != nil {
-		return nil, errors.NewCannotRetrieveSetlistError(err.Error())
+		return nil, setlisterrors.NewCannotRetrieveSetlistError(err.Error())
 	}
 
 	setlist := response.findSetlistWithMinSongs(minSongs)
 	return setlist, nil
 }
 
-func (r *SetlistFMRepository) createSetlistHttpOptions(artist string, page int) httpsender.HTTPRequestOptions {
-	url := r.getSetlistFullUrl(artist, page)
+func (r *SetlistFMRepository) createSetlistHttpOptions(
+	param string,
+	artist string,
+	page int,
+) httpsender.HTTPRequestOptions {
+	url := r.getSetlistFullUrl(param, artist, page)
 	httpOptions := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
 	httpOptions.SetHeaders(
 		map[string]string{
@@ -86,9 +118,9 @@ func (r *SetlistFMRepository) createSetlistHttpOptions(artist string, page int)
 	return httpOptions
 }
 
-func (r *SetlistFMRepository) getSetlistFullUrl(artist string, page int) string {
+func (r *SetlistFMRepository) getSetlistFullUrl(param string, artist string, page int) string {
 	queryParams := url.Values{}
-	queryParams.Set("artistName", artist)
+	queryParams.Set(param, artist)
 	queryParams.Set("p", fmt.Sprint(page))
 	setlistPath := "rest/1.0/search/setlists"
 	return fmt.Sprintf("https://%s/%s?%s", r.host, setlistPath, queryParams.Encode())
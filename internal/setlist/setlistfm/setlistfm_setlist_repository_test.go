@@ -1,14 +1,19 @@
 package setlistfm
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"testing"
+	"time"
 
 	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
 	httpsendermocks "festwrap/internal/http/sender/mocks"
 	"festwrap/internal/setlist"
+	setlisterrors "festwrap/internal/setlist/errors"
 	"festwrap/internal/testtools"
 
 	"github.com/stretchr/testify/assert"
@@ -17,6 +22,7 @@ import (
 const (
 	setlistFMApiKey = "someApiKey"
 	artist          = "The Menzingers"
+	artistMbid      = "0b4a1d9f-8d26-4f8e-8d7b-4b4c3b1d1c5e"
 	minSongs        = 3
 )
 
@@ -50,6 +56,18 @@ func getSetlistHttpOptions(page int) httpsender.HTTPRequestOptions {
 	return options
 }
 
+func getSetlistByMbidHttpOptions() httpsender.HTTPRequestOptions {
+	url := fmt.Sprintf("https://api.setlist.fm/rest/1.0/search/setlists?artistMbid=%s&p=1", artistMbid)
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(
+		map[string]string{
+			"x-api-key": setlistFMApiKey,
+			"Accept":    "application/json",
+		},
+	)
+	return options
+}
+
 func expectedSetlist() *setlist.Setlist {
 	songs := []setlist.Song{
 		setlist.NewSong("Walk of Life"),
@@ -63,6 +81,10 @@ func expectedSetlist() *setlist.Setlist {
 		setlist.NewSong("Layla"),
 	}
 	setlist := setlist.NewSetlist("The Menzingers", songs)
+	setlist.SetDate(time.Date(2024, time.January, 25, 0, 0, 0, 0, time.UTC))
+	setlist.SetVenue("Gruenspan, Hamburg")
+	setlist.SetTour("Some Of It Was True Tour")
+	setlist.SetSource("setlistfm")
 	return &setlist
 }
 
@@ -70,7 +92,7 @@ func TestGetSetlistSenderCalledWithProperOptions(t *testing.T) {
 	sender := sender(t).(*httpsendermocks.HTTPSenderMock)
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, sender)
 
-	repository.GetSetlist(artist, minSongs)
+	repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
 
 	sender.AssertExpectations(t)
 }
@@ -80,7 +102,7 @@ func TestGetSetlistReturnsErrorOnSenderError(t *testing.T) {
 	sender.On("Send", getSetlistHttpOptions(1)).Return(nil, errors.New("test error"))
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
 
-	_, err := repository.GetSetlist(artist, minSongs)
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
 
 	assert.NotNil(t, err)
 }
@@ -91,7 +113,7 @@ func TestGetSetlistReturnsErrorOnDeserializationError(t *testing.T) {
 	sender.On("Send", getSetlistHttpOptions(1)).Return(&invalidResponse, nil)
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
 
-	_, err := repository.GetSetlist(artist, minSongs)
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
 
 	assert.NotNil(t, err)
 }
@@ -101,15 +123,43 @@ func TestGetSetlistReturnsErrorIfNoSetlistFound(t *testing.T) {
 	sender.On("Send", getSetlistHttpOptions(1)).Return(emptyResponseBody(t), nil)
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
 
-	_, err := repository.GetSetlist(artist, minSongs)
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
 
-	assert.NotNil(t, err)
+	var notFound *setlisterrors.SetlistNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestGetSetlistReturnsNotFoundIfArtistHasNoSetlists(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	notFoundErr := senderrors.NewUpstreamError(
+		fmt.Sprintf("request with api key %s failed", setlistFMApiKey), http.StatusNotFound, http.Header{}, "",
+	)
+	sender.On("Send", getSetlistHttpOptions(1)).Return(nil, notFoundErr)
+	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
+
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
+
+	var notFound *setlisterrors.SetlistNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, fmt.Sprintf("No setlists for artist %s", artist), err.Error())
+}
+
+func TestGetSetlistReturnsRetrievalErrorOnUpstreamFailure(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	upstreamErr := senderrors.NewUpstreamError("unavailable", http.StatusServiceUnavailable, http.Header{}, "")
+	sender.On("Send", getSetlistHttpOptions(1)).Return(nil, upstreamErr)
+	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
+
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
+
+	var retrievalErr *setlisterrors.CannotRetrieveSetlistError
+	assert.ErrorAs(t, err, &retrievalErr)
 }
 
 func TestGetSetlistReturnsSetlist(t *testing.T) {
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, sender(t))
 
-	actual, _ := repository.GetSetlist(artist, minSongs)
+	actual, _ := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
 
 	assert.Equal(t, expectedSetlist(), actual)
 }
@@ -117,7 +167,7 @@ func TestGetSetlistReturnsSetlist(t *testing.T) {
 func TestGetSetlistRetrievesErrorWhenMinSongsNotReached(t *testing.T) {
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, sender(t))
 
-	_, err := repository.GetSetlist(artist, 50)
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, 50)
 
 	assert.NotNil(t, err)
 }
@@ -129,8 +179,30 @@ func TestGetSetlistReturnsResultsFromNextPageIfFirstHasNoResults(t *testing.T) {
 	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &multiPageSender)
 	repository.SetMaxPages(3)
 
-	actual, err := repository.GetSetlist(artist, minSongs)
+	actual, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist}, minSongs)
+
+	assert.Equal(t, expectedSetlist(), actual)
+	assert.Nil(t, err)
+}
+
+func TestGetSetlistSearchesByMbidIfProvided(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getSetlistByMbidHttpOptions()).Return(responseBody(t), nil)
+	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
+
+	actual, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist, MusicBrainzId: artistMbid}, minSongs)
 
 	assert.Equal(t, expectedSetlist(), actual)
 	assert.Nil(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestGetSetlistReturnsErrorIfNoSetlistFoundByMbid(t *testing.T) {
+	sender := httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getSetlistByMbidHttpOptions()).Return(emptyResponseBody(t), nil)
+	repository := NewSetlistFMSetlistRepository(setlistFMApiKey, &sender)
+
+	_, err := repository.GetSetlist(context.Background(), setlist.SetlistArtist{Name: artist, MusicBrainzId: artistMbid}, minSongs)
+
+	assert.NotNil(t, err)
 }
@@ -0,0 +1,63 @@
+package setlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"festwrap/internal/artist"
+	artisterrors "festwrap/internal/artist/errors"
+	httpsender "festwrap/internal/http/sender"
+	setlisterrors "festwrap/internal/setlist/errors"
+)
+
+// Builds setlists from the most popular tracks of the artist in Spotify, so artists
+// without concerts registered can still be added to playlists
+type TopTracksSetlistRepository struct {
+	artistRepository artist.ArtistRepository
+}
+
+func NewTopTracksSetlistRepository(artistRepository artist.ArtistRepository) *TopTracksSetlistRepository {
+	return &TopTracksSetlistRepository{artistRepository: artistRepository}
+}
+
+func (r *TopTracksSetlistRepository) GetSetlist(
+	ctx context.Context,
+	setlistArtist SetlistArtist,
+	minSongs int,
+) (*Setlist, error) {
+	artistId, err := r.getArtistId(ctx, setlistArtist)
+	var notFound *artisterrors.ArtistNotFoundError
+	if errors.As(err, &notFound) {
+		return nil, setlisterrors.NewSetlistNotFoundError(err.Error())
+	} else if err != nil {
+		return nil, httpsender.WrapError(err, setlisterrors.NewCannotRetrieveSetlistError)
+	}
+
+	tracks, err := r.artistRepository.GetTopTracks(ctx, artistId)
+	if err != nil {
+		return nil, httpsender.WrapError(err, setlisterrors.NewCannotRetrieveSetlistError)
+	}
+
+	if len(tracks) == 0 || len(tracks) < minSongs {
+		message := fmt.Sprintf("Could not find enough top tracks for artist %s", setlistArtist.Name)
+		return nil, setlisterrors.NewSetlistNotFoundError(message)
+	}
+
+	songs := make([]Song, len(tracks))
+	for i, track := range tracks {
+		songs[i] = NewSong(track)
+	}
+	result := NewSetlist(setlistArtist.Name, songs)
+	result.SetSource(TopTracksSource)
+	return &result, nil
+}
+
+// Artists are looked up by name if their Spotify id is not provided
+func (r *TopTracksSetlistRepository) getArtistId(ctx context.Context, setlistArtist SetlistArtist) (string, error) {
+	if setlistArtist.SpotifyId != "" {
+		return setlistArtist.SpotifyId, nil
+	}
+
+	return artist.FindArtistId(ctx, r.artistRepository, setlistArtist.Name)
+}
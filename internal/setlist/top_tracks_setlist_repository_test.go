@@ -0,0 +1,130 @@
+package setlist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"festwrap/internal/artist"
+	senderrors "festwrap/internal/http/sender/errors"
+	setlisterrors "festwrap/internal/setlist/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func topTracksArtistRepository() *artist.FakeArtistRepository {
+	repository := &artist.FakeArtistRepository{}
+	found := artist.NewArtist("Militarie Gun")
+	found.Id = "militarieGun"
+	repository.SetSearchReturnValue([]artist.Artist{found})
+	repository.SetTopTracks("militarieGun", []string{"Do It Faster", "Very High", "Big Disappointment"})
+	return repository
+}
+
+func topTracksSetlist() *Setlist {
+	songs := []Song{NewSong("Do It Faster"), NewSong("Very High"), NewSong("Big Disappointment")}
+	result := NewSetlist("Militarie Gun", songs)
+	result.SetSource(TopTracksSource)
+	return &result
+}
+
+func TestTopTracksGetSetlistReturnsTopTracks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		artist SetlistArtist
+	}{
+		"artist with Spotify id": {
+			artist: SetlistArtist{Name: "Militarie Gun", SpotifyId: "militarieGun"},
+		},
+		"artist searched by name": {
+			artist: SetlistArtist{Name: "militarie gun "},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			repository := NewTopTracksSetlistRepository(topTracksArtistRepository())
+
+			actual, err := repository.GetSetlist(context.Background(), test.artist, 2)
+
+			expected := topTracksSetlist()
+			expected.artist = test.artist.Name
+			assert.Nil(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestTopTracksGetSetlistDoesNotSearchArtistIfIdProvided(t *testing.T) {
+	artistRepository := topTracksArtistRepository()
+	repository := NewTopTracksSetlistRepository(artistRepository)
+
+	_, err := repository.GetSetlist(context.Background(), SetlistArtist{Name: "Militarie Gun", SpotifyId: "otherId"}, 0)
+
+	expected := []artist.GetTopTracksArgs{{Context: context.Background(), ArtistId: "otherId"}}
+	assert.NotNil(t, err)
+	assert.Equal(t, expected, artistRepository.GetGetTopTracksArgs())
+	assert.Equal(t, artist.SearchArtistArgs{}, artistRepository.GetSearchArtistArgs())
+}
+
+func TestTopTracksGetSetlistReturnsError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		artist   SetlistArtist
+		minSongs int
+		setup    func(repository *artist.FakeArtistRepository)
+		notFound bool
+	}{
+		"artist not found": {
+			artist:   SetlistArtist{Name: "Some other artist"},
+			setup:    func(repository *artist.FakeArtistRepository) {},
+			notFound: true,
+		},
+		"search error": {
+			artist: SetlistArtist{Name: "Militarie Gun"},
+			setup: func(repository *artist.FakeArtistRepository) {
+				repository.SetSearchArtistError(errors.New("test error"))
+			},
+		},
+		"top tracks error": {
+			artist: SetlistArtist{Name: "Militarie Gun"},
+			setup: func(repository *artist.FakeArtistRepository) {
+				repository.SetTopTracksError(errors.New("test error"))
+			},
+		},
+		"not enough top tracks": {
+			artist:   SetlistArtist{Name: "Militarie Gun"},
+			minSongs: 4,
+			setup:    func(repository *artist.FakeArtistRepository) {},
+			notFound: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			artistRepository := topTracksArtistRepository()
+			test.setup(artistRepository)
+			repository := NewTopTracksSetlistRepository(artistRepository)
+
+			_, err := repository.GetSetlist(context.Background(), test.artist, test.minSongs)
+
+			var notFound *setlisterrors.SetlistNotFoundError
+			assert.NotNil(t, err)
+			assert.Equal(t, test.notFound, errors.As(err, &notFound))
+		})
+	}
+}
+
+func TestTopTracksGetSetlistReturnsUpstreamAuthErrors(t *testing.T) {
+	artistRepository := topTracksArtistRepository()
+	authErr := senderrors.NewUpstreamError("expired token", http.StatusUnauthorized, http.Header{}, "")
+	artistRepository.SetTopTracksError(authErr)
+	repository := NewTopTracksSetlistRepository(artistRepository)
+
+	_, err := repository.GetSetlist(context.Background(), SetlistArtist{Name: "Militarie Gun"}, 0)
+
+	assert.Equal(t, authErr, err)
+}
@@ -0,0 +1,14 @@
+package errors
+
+// Returned when Spotify has no song matching the one searched, as opposed to the search failing
+type SongNotFoundError struct {
+	message string
+}
+
+func NewSongNotFoundError(message string) error {
+	return &SongNotFoundError{message: message}
+}
+
+func (e *SongNotFoundError) Error() string {
+	return e.message
+}
@@ -7,9 +7,10 @@ import (
 )
 
 type GetSongArgs struct {
-	Context context.Context
-	Artist  string
-	Title   string
+	Context  context.Context
+	ArtistId string
+	Artist   string
+	Title    string
 }
 
 type FakeSongRepository struct {
@@ -28,6 +29,15 @@ func (r *FakeSongRepository) GetSong(ctx context.Context, artist string, title s
 	return r.repository.GetSong(ctx, artist, title)
 }
 
+func (r *FakeSongRepository) GetSongByArtistId(
+	ctx context.Context,
+	artistId string,
+	artist string,
+	title string,
+) (*Song, error) {
+	return r.repository.GetSongByArtistId(ctx, artistId, artist, title)
+}
+
 func (r *FakeSongRepository) GetGetSongArgs() []GetSongArgs {
 	return r.repository.getSongArgs
 }
@@ -47,6 +57,16 @@ func (w *WrappedFakeSongRepository) GetSong(ctx context.Context, artist string,
 	return w.popSongLeft()
 }
 
+func (w *WrappedFakeSongRepository) GetSongByArtistId(
+	ctx context.Context,
+	artistId string,
+	artist string,
+	title string,
+) (*Song, error) {
+	w.getSongArgs = append(w.getSongArgs, GetSongArgs{Context: ctx, ArtistId: artistId, Artist: artist, Title: title})
+	return w.popSongLeft()
+}
+
 func (w *WrappedFakeSongRepository) popSongLeft() (*Song, error) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
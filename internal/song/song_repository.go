@@ -4,4 +4,7 @@ import "context"
 
 type SongRepository interface {
 	GetSong(ctx context.Context, artist string, title string) (*Song, error)
+	// Same as GetSong, but songs are matched by the Spotify id of the artist instead of its name, which is
+	// only used to describe errors
+	GetSongByArtistId(ctx context.Context, artistId string, artist string, title string) (*Song, error)
 }
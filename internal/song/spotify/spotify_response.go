@@ -1,11 +1,44 @@
 package spotify
 
+import "festwrap/internal/song"
+
+type spotifyArtist struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type spotifyAlbum struct {
+	Name string `json:"name"`
+}
+
 type spotifySong struct {
-	Uri string `json:"uri"`
+	Id      string          `json:"id"`
+	Uri     string          `json:"uri"`
+	Name    string          `json:"name"`
+	Artists []spotifyArtist `json:"artists"`
+	Album   spotifyAlbum    `json:"album"`
+}
+
+func (s spotifySong) HasArtist(artistId string) bool {
+	for _, artist := range s.Artists {
+		if artist.Id == artistId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s spotifySong) toTrack() song.Track {
+	artists := make([]string, len(s.Artists))
+	for i, artist := range s.Artists {
+		artists[i] = artist.Name
+	}
+	return song.Track{Id: s.Id, Uri: s.Uri, Name: s.Name, Artists: artists, Album: s.Album.Name}
 }
 
 type spotifyTracks struct {
 	Songs []spotifySong `json:"items"`
+	Total int           `json:"total"`
 }
 
 type spotifyResponse struct {
@@ -7,37 +7,124 @@ import (
 
 	types "festwrap/internal"
 	httpsender "festwrap/internal/http/sender"
+	"festwrap/internal/pagination"
 	"festwrap/internal/serialization"
 	"festwrap/internal/song"
 	"festwrap/internal/song/errors"
 )
 
 type SpotifySongRepository struct {
-	tokenKey     types.ContextKey
-	host         string
-	httpSender   httpsender.HTTPRequestSender
-	deserializer serialization.Deserializer[spotifyResponse]
+	tokenKey         types.ContextKey
+	host             string
+	httpSender       httpsender.HTTPRequestSender
+	deserializer     serialization.Deserializer[spotifyResponse]
+	maxArtistMatches int
 }
 
 func NewSpotifySongRepository(httpSender httpsender.HTTPRequestSender) *SpotifySongRepository {
 	return &SpotifySongRepository{
-		tokenKey:     "token",
-		host:         "api.spotify.com",
-		httpSender:   httpSender,
-		deserializer: serialization.NewJsonDeserializer[spotifyResponse](),
+		tokenKey:         "token",
+		host:             "api.spotify.com",
+		httpSender:       httpSender,
+		deserializer:     serialization.NewJsonDeserializer[spotifyResponse](),
+		maxArtistMatches: 50,
 	}
 }
 
 func (r *SpotifySongRepository) GetSong(ctx context.Context, artist string, title string) (*song.Song, error) {
+	songs, err := r.searchSongs(ctx, fmt.Sprintf("artist:%s track:%s", artist, title), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(songs) == 0 {
+		errorMsg := fmt.Sprintf("No songs found for song %s (%s)", title, artist)
+		return nil, errors.NewSongNotFoundError(errorMsg)
+	}
+
+	// We assume the first result is the most trusted one
+	result := song.NewSong(songs[0].Uri)
+	return &result, nil
+}
+
+// Artist names can be common words, so songs searched by artist and title are only returned if the
+// artist takes part in them. Artists may also be written differently in setlists, so songs are then
+// searched by title only, returning the first candidate where the artist takes part
+func (r *SpotifySongRepository) GetSongByArtistId(
+	ctx context.Context,
+	artistId string,
+	artist string,
+	title string,
+) (*song.Song, error) {
+	queries := []string{fmt.Sprintf("artist:%s track:%s", artist, title), fmt.Sprintf("track:%s", title)}
+	for _, query := range queries {
+		songs, err := r.searchSongs(ctx, query, r.maxArtistMatches)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range songs {
+			if candidate.HasArtist(artistId) {
+				result := song.NewSong(candidate.Uri)
+				return &result, nil
+			}
+		}
+	}
+
+	errorMsg := fmt.Sprintf("No songs found for song %s (%s) with artist id %s", title, artist, artistId)
+	return nil, errors.NewSongNotFoundError(errorMsg)
+}
+
+// Searches tracks by free text, matching their name, artists or album
+func (r *SpotifySongRepository) SearchTracks(
+	ctx context.Context,
+	query string,
+	offset int,
+	limit int,
+) (pagination.Page[song.Track], error) {
+	queryParams := url.Values{}
+	queryParams.Set("q", query)
+	queryParams.Set("type", "track")
+	queryParams.Set("offset", fmt.Sprint(offset))
+	queryParams.Set("limit", fmt.Sprint(limit))
+	response, err := r.search(ctx, queryParams)
+	if err != nil {
+		return pagination.Page[song.Track]{}, err
+	}
+
+	tracks := make([]song.Track, len(response.Tracks.Songs))
+	for i, track := range response.Tracks.Songs {
+		tracks[i] = track.toTrack()
+	}
+	return pagination.NewPage(tracks, offset, response.Tracks.Total), nil
+}
+
+// A zero limit uses the default one from Spotify
+func (r *SpotifySongRepository) searchSongs(ctx context.Context, query string, limit int) ([]spotifySong, error) {
+	queryParams := url.Values{}
+	queryParams.Set("q", query)
+	queryParams.Set("type", "track")
+	if limit > 0 {
+		queryParams.Set("limit", fmt.Sprint(limit))
+	}
+	response, err := r.search(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Tracks.Songs, nil
+}
+
+func (r *SpotifySongRepository) search(ctx context.Context, queryParams url.Values) (*spotifyResponse, error) {
 	token, ok := ctx.Value(r.tokenKey).(string)
 	if !ok {
 		return nil, errors.NewCannotRetrieveSongError("Could not retrieve token from context")
 	}
 
-	httpOptions := r.createSongHttpOptions(artist, title, token)
+	httpOptions := r.createSearchHttpOptions(queryParams, token)
 	responseBody, err := r.httpSender.Send(httpOptions)
 	if err != nil {
-		return nil, errors.NewCannotRetrieveSongError(err.Error())
+		return nil, httpsender.WrapError(err, errors.NewCannotRetrieveSongError)
 	}
 
 	var response spotifyResponse
@@ -46,38 +133,31 @@ func (r *SpotifySongRepository) GetSong(ctx context.Context, artist string, titl
 		return nil, errors.NewCannotRetrieveSongError(err.Error())
 	}
 
-	if len(response.Tracks.Songs) == 0 {
-		errorMsg := fmt.Sprintf("No songs found for song %s (%s)", title, artist)
-		return nil, errors.NewCannotRetrieveSongError(errorMsg)
-	}
-
-	// We assume the first result is the most trusted one
-	result := song.NewSong(response.Tracks.Songs[0].Uri)
-	return &result, nil
+	return &response, nil
 }
 
 func (r *SpotifySongRepository) SetDeserializer(deserializer serialization.Deserializer[spotifyResponse]) {
 	r.deserializer = deserializer
 }
 
-func (r *SpotifySongRepository) createSongHttpOptions(
-	artist string,
-	title string,
+func (r *SpotifySongRepository) SetMaxArtistMatches(maxMatches int) {
+	r.maxArtistMatches = maxMatches
+}
+
+func (r *SpotifySongRepository) createSearchHttpOptions(
+	queryParams url.Values,
 	token string,
 ) httpsender.HTTPRequestOptions {
-	httpOptions := httpsender.NewHTTPRequestOptions(r.getSetlistFullUrl(artist, title), httpsender.GET, 200)
+	httpOptions := httpsender.NewHTTPRequestOptions(r.getSearchUrl(queryParams), httpsender.GET, 200)
 	httpOptions.SetHeaders(
 		map[string]string{"Authorization": fmt.Sprintf("Bearer %s", token)},
 	)
 	return httpOptions
 }
 
-func (r *SpotifySongRepository) getSetlistFullUrl(artist string, title string) string {
-	queryParams := url.Values{}
-	queryParams.Set("q", fmt.Sprintf("artist:%s track:%s", artist, title))
-	queryParams.Set("type", "track")
-	setlistPath := "v1/search"
-	return fmt.Sprintf("https://%s/%s?%s", r.host, setlistPath, queryParams.Encode())
+func (r *SpotifySongRepository) getSearchUrl(queryParams url.Values) string {
+	searchPath := "v1/search"
+	return fmt.Sprintf("https://%s/%s?%s", r.host, searchPath, queryParams.Encode())
 }
 
 func (r *SpotifySongRepository) SetTokenKey(key types.ContextKey) {
@@ -5,7 +5,10 @@ import (
 	"errors"
 	types "festwrap/internal"
 	httpsender "festwrap/internal/http/sender"
+	httpsendermocks "festwrap/internal/http/sender/mocks"
+	"festwrap/internal/pagination"
 	"festwrap/internal/song"
+	songerrors "festwrap/internal/song/errors"
 	"festwrap/internal/testtools"
 	"path/filepath"
 	"testing"
@@ -29,6 +32,24 @@ func getSongHttpOptions() httpsender.HTTPRequestOptions {
 	return options
 }
 
+func getSongByArtistIdHttpOptions() httpsender.HTTPRequestOptions {
+	url := "https://api.spotify.com/v1/search?limit=50&q=artist%3AMovements+track%3ADaylily&type=track"
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(
+		map[string]string{"Authorization": "Bearer some_token"},
+	)
+	return options
+}
+
+func getSongByTitleHttpOptions() httpsender.HTTPRequestOptions {
+	url := "https://api.spotify.com/v1/search?limit=50&q=track%3ADaylily&type=track"
+	options := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	options.SetHeaders(
+		map[string]string{"Authorization": "Bearer some_token"},
+	)
+	return options
+}
+
 func searchSongResponseBody(t *testing.T) []byte {
 	return testtools.LoadTestDataOrError(
 		t,
@@ -43,6 +64,13 @@ func noSongsSearchSongResponseBody(t *testing.T) []byte {
 	)
 }
 
+func searchTracksResponseBody(t *testing.T) []byte {
+	return testtools.LoadTestDataOrError(
+		t,
+		filepath.Join(testtools.GetParentDir(t), "testdata", "search_tracks_response.json"),
+	)
+}
+
 func songsSender(t *testing.T) *httpsender.FakeHTTPSender {
 	sender := &httpsender.FakeHTTPSender{}
 	response := searchSongResponseBody(t)
@@ -95,7 +123,8 @@ func TestGetSongReturnsErrorIfNoSongsFound(t *testing.T) {
 
 	_, err := repository.GetSong(testContext(), artist, songTitle)
 
-	assert.NotNil(t, err)
+	var notFoundErr *songerrors.SongNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
 }
 
 func TestGetSongReturnsFirstSongFound(t *testing.T) {
@@ -139,3 +168,140 @@ func TestGetSongReturnsErrorWhenInvalidToken(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSongByArtistIdSearchesArtistAndTitleWithCandidatesLimit(t *testing.T) {
+	sender := songsSender(t)
+	repository := NewSpotifySongRepository(sender)
+
+	_, err := repository.GetSongByArtistId(testContext(), "0rpKM0MniNkXM1SLSglYUZ", artist, songTitle)
+
+	assert.Nil(t, err)
+	assert.Equal(t, getSongByArtistIdHttpOptions(), sender.GetSendArgs())
+}
+
+func TestGetSongByArtistIdReturnsFirstSongOfArtist(t *testing.T) {
+	repository := NewSpotifySongRepository(songsSender(t))
+
+	actual, err := repository.GetSongByArtistId(testContext(), "510l1uqaJ2YZoHhBVNSISi", artist, songTitle)
+
+	expected := song.NewSong("spotify:track:5R0JuZYJxvTKAUnbtoGBXt")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, *actual)
+}
+
+func TestGetSongByArtistIdSearchesTitleIfArtistSongNotFound(t *testing.T) {
+	noSongsBody := noSongsSearchSongResponseBody(t)
+	songsBody := searchSongResponseBody(t)
+	sender := &httpsendermocks.HTTPSenderMock{}
+	sender.On("Send", getSongByArtistIdHttpOptions()).Return(&noSongsBody, nil)
+	sender.On("Send", getSongByTitleHttpOptions()).Return(&songsBody, nil)
+	repository := NewSpotifySongRepository(sender)
+
+	actual, err := repository.GetSongByArtistId(testContext(), "510l1uqaJ2YZoHhBVNSISi", artist, songTitle)
+
+	expected := song.NewSong("spotify:track:5R0JuZYJxvTKAUnbtoGBXt")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, *actual)
+	sender.AssertExpectations(t)
+}
+
+func TestGetSongByArtistIdReturnsErrorIfNoSongOfArtistFound(t *testing.T) {
+	repository := NewSpotifySongRepository(songsSender(t))
+
+	_, err := repository.GetSongByArtistId(testContext(), "unknownId", artist, songTitle)
+
+	var notFoundErr *songerrors.SongNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+}
+
+func TestGetSongByArtistIdReturnsErrorOnSendError(t *testing.T) {
+	errorSender := songsSender(t)
+	errorSender.SetError(errors.New("test error"))
+	repository := NewSpotifySongRepository(errorSender)
+
+	_, err := repository.GetSongByArtistId(testContext(), "0rpKM0MniNkXM1SLSglYUZ", artist, songTitle)
+
+	assert.NotNil(t, err)
+}
+
+func tracksSender(t *testing.T) *httpsender.FakeHTTPSender {
+	sender := &httpsender.FakeHTTPSender{}
+	response := searchTracksResponseBody(t)
+	sender.SetResponse(&response)
+	return sender
+}
+
+func TestSearchTracksSendsRequestWithProperOptions(t *testing.T) {
+	sender := tracksSender(t)
+	repository := NewSpotifySongRepository(sender)
+
+	_, err := repository.SearchTracks(testContext(), "goodbye", 2, 2)
+
+	url := "https://api.spotify.com/v1/search?limit=2&offset=2&q=goodbye&type=track"
+	expected := httpsender.NewHTTPRequestOptions(url, httpsender.GET, 200)
+	expected.SetHeaders(map[string]string{"Authorization": "Bearer some_token"})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, sender.GetSendArgs())
+}
+
+func TestSearchTracksReturnsTracks(t *testing.T) {
+	repository := NewSpotifySongRepository(tracksSender(t))
+
+	actual, err := repository.SearchTracks(testContext(), "goodbye", 2, 2)
+
+	expected := []song.Track{
+		{
+			Id:      "6gUx4nFkNUr0MZaEjjzWCL",
+			Uri:     "spotify:track:6gUx4nFkNUr0MZaEjjzWCL",
+			Name:    "Goodbye",
+			Artists: []string{"toe"},
+			Album:   "The Book About My Idle Plot on a Vague Anxiety",
+		},
+		{
+			Id:      "1Dy1m6S7PzS4zcnUs3LcIh",
+			Uri:     "spotify:track:1Dy1m6S7PzS4zcnUs3LcIh",
+			Name:    "Goodbye",
+			Artists: []string{"toe", "Various Artists"},
+			Album:   "I Am Shark: Confessions Under Water, Vol. 3",
+		},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, pagination.NewPage(expected, 2, 836), actual)
+}
+
+func TestSearchTracksReturnsError(t *testing.T) {
+	nonJsonBody := []byte("{some_non_json")
+	tests := map[string]struct {
+		ctx     context.Context
+		sendErr error
+		body    *[]byte
+	}{
+		"missing token": {
+			ctx: context.Background(),
+		},
+		"send error": {
+			ctx:     testContext(),
+			sendErr: errors.New("test error"),
+		},
+		"non json response": {
+			ctx:  testContext(),
+			body: &nonJsonBody,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			sender := tracksSender(t)
+			sender.SetError(test.sendErr)
+			if test.body != nil {
+				sender.SetResponse(test.body)
+			}
+			repository := NewSpotifySongRepository(sender)
+
+			_, err := repository.SearchTracks(test.ctx, "goodbye", 0, 2)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package song
+
+// Song details shown when searching, as opposed to Song which only identifies it
+type Track struct {
+	Id      string   `json:"id"`
+	Uri     string   `json:"uri"`
+	Name    string   `json:"name"`
+	Artists []string `json:"artists"`
+	Album   string   `json:"album"`
+}
@@ -0,0 +1,21 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Returns the text in lower case, without accents nor surrounding spaces, so names written
+// differently can be compared. Accents are removed by decomposing the characters into their
+// base letter and combining marks, which are then dropped
+func Normalize(text string) string {
+	var result strings.Builder
+	for _, char := range norm.NFD.String(strings.TrimSpace(text)) {
+		if !unicode.Is(unicode.Mn, char) {
+			result.WriteRune(unicode.ToLower(char))
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,38 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		expected string
+	}{
+		"lower case": {
+			text:     "Touché Amoré",
+			expected: "touche amore",
+		},
+		"upper case": {
+			text:     "MÖTLEY CRÜE",
+			expected: "motley crue",
+		},
+		"decomposed accents": {
+			text:     "Resurrección",
+			expected: "resurreccion",
+		},
+		"surrounding spaces": {
+			text:     "  Hellfest ",
+			expected: "hellfest",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, Normalize(test.text))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	types "festwrap/internal"
+	"festwrap/internal/auth"
+	"festwrap/internal/cache"
+)
+
+// Keeps the user of each token, so Spotify is not asked for it on every request. Tokens are
+// stored hashed, so they cannot be leaked from the cache. Users are not kept beyond the expiration
+// of their token when it is known, so expired tokens are not taken as valid
+type CachedUserRepository struct {
+	tokenKey   types.ContextKey
+	expiryKey  types.ContextKey
+	repository UserRepository
+	userIds    cache.Cache[string, string]
+}
+
+func NewCachedUserRepository(repository UserRepository, userIds cache.Cache[string, string]) CachedUserRepository {
+	return CachedUserRepository{
+		tokenKey:   types.ContextKey("token"),
+		expiryKey:  types.ContextKey("token_expiry"),
+		repository: repository,
+		userIds:    userIds,
+	}
+}
+
+func (r CachedUserRepository) GetCurrentUserId(ctx context.Context) (string, error) {
+	token, ok := ctx.Value(r.tokenKey).(string)
+	if !ok {
+		return r.repository.GetCurrentUserId(ctx)
+	}
+
+	key := auth.HashToken(token)
+	if userId, ok := r.userIds.Get(key); ok {
+		return userId, nil
+	}
+
+	// Errors are not cached, since the token may be valid once the failure is solved
+	userId, err := r.repository.GetCurrentUserId(ctx)
+	if err != nil {
+		return "", err
+	}
+	if expiresAt, ok := ctx.Value(r.expiryKey).(time.Time); ok {
+		r.userIds.SetUntil(key, userId, expiresAt)
+	} else {
+		r.userIds.Set(key, userId)
+	}
+	return userId, nil
+}
+
+func (r *CachedUserRepository) SetTokenKey(key types.ContextKey) {
+	r.tokenKey = key
+}
+
+func (r *CachedUserRepository) SetExpiryKey(key types.ContextKey) {
+	r.expiryKey = key
+}
@@ -0,0 +1,125 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	types "festwrap/internal"
+	"festwrap/internal/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tokenKey  = types.ContextKey("myKey")
+	expiryKey = types.ContextKey("myExpiryKey")
+	token     = "some_token"
+	userId    = "some_id"
+)
+
+func tokenContext(token string) context.Context {
+	return context.WithValue(context.Background(), tokenKey, token)
+}
+
+func cachedRepositorySetup() (CachedUserRepository, *FakeUserRepository, *cache.MemoryCache[string, string]) {
+	repository := &FakeUserRepository{}
+	repository.SetGetCurrentIdValue(GetCurrentIdValue{UserId: userId})
+	userIds := cache.NewMemoryCache[string, string](time.Minute)
+	cached := NewCachedUserRepository(repository, userIds)
+	cached.SetTokenKey(tokenKey)
+	cached.SetExpiryKey(expiryKey)
+	return cached, repository, userIds
+}
+
+func TestCachedUserRepositoryReturnsUserFromRepository(t *testing.T) {
+	cached, _, _ := cachedRepositorySetup()
+
+	actual, err := cached.GetCurrentUserId(tokenContext(token))
+
+	assert.Nil(t, err)
+	assert.Equal(t, userId, actual)
+}
+
+func TestCachedUserRepositoryReturnsCachedUser(t *testing.T) {
+	cached, repository, _ := cachedRepositorySetup()
+	cached.GetCurrentUserId(tokenContext(token))
+	repository.SetGetCurrentIdValue(GetCurrentIdValue{UserId: "other_id"})
+
+	actual, err := cached.GetCurrentUserId(tokenContext(token))
+
+	assert.Nil(t, err)
+	assert.Equal(t, userId, actual)
+}
+
+func TestCachedUserRepositoryKeepsUsersPerToken(t *testing.T) {
+	cached, repository, _ := cachedRepositorySetup()
+	cached.GetCurrentUserId(tokenContext(token))
+	repository.SetGetCurrentIdValue(GetCurrentIdValue{UserId: "other_id"})
+
+	actual, err := cached.GetCurrentUserId(tokenContext("other_token"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "other_id", actual)
+}
+
+func TestCachedUserRepositoryDoesNotStoreRawTokens(t *testing.T) {
+	cached, _, userIds := cachedRepositorySetup()
+
+	cached.GetCurrentUserId(tokenContext(token))
+
+	_, found := userIds.Get(token)
+	assert.False(t, found)
+	assert.Equal(t, 1, userIds.Len())
+}
+
+func TestCachedUserRepositoryDoesNotCacheErrors(t *testing.T) {
+	cached, repository, userIds := cachedRepositorySetup()
+	repository.SetGetCurrentIdValue(GetCurrentIdValue{Err: errors.New("test error")})
+
+	_, err := cached.GetCurrentUserId(tokenContext(token))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, userIds.Len())
+}
+
+func TestCachedUserRepositoryCallsRepositoryWithoutToken(t *testing.T) {
+	cached, repository, userIds := cachedRepositorySetup()
+	ctx := context.Background()
+
+	cached.GetCurrentUserId(ctx)
+
+	assert.Equal(t, ctx, repository.GetGetCurrentIdArgs().Context)
+	assert.Equal(t, 0, userIds.Len())
+}
+
+func TestCachedUserRepositoryDoesNotKeepUsersBeyondTokenExpiry(t *testing.T) {
+	cached, repository, userIds := cachedRepositorySetup()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	userIds.SetClock(func() time.Time { return now })
+	ctx := context.WithValue(tokenContext(token), expiryKey, now.Add(10*time.Second))
+	cached.GetCurrentUserId(ctx)
+	repository.SetGetCurrentIdValue(GetCurrentIdValue{UserId: "other_id"})
+	now = now.Add(10 * time.Second)
+
+	actual, err := cached.GetCurrentUserId(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "other_id", actual)
+}
+
+func TestCachedUserRepositoryKeepsUsersUntilTokenExpiry(t *testing.T) {
+	cached, repository, userIds := cachedRepositorySetup()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	userIds.SetClock(func() time.Time { return now })
+	ctx := context.WithValue(tokenContext(token), expiryKey, now.Add(10*time.Second))
+	cached.GetCurrentUserId(ctx)
+	repository.SetGetCurrentIdValue(GetCurrentIdValue{UserId: "other_id"})
+	now = now.Add(9 * time.Second)
+
+	actual, err := cached.GetCurrentUserId(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, userId, actual)
+}
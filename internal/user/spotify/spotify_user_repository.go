@@ -34,7 +34,7 @@ func (r SpotifyUserRepository) GetCurrentUserId(ctx context.Context) (string, er
 
 	responseBody, err := r.httpSender.Send(r.getCurrentUserIdHTTPOptions(token))
 	if err != nil {
-		return "", fmt.Errorf("could not get current user: %v", err.Error())
+		return "", fmt.Errorf("could not get current user: %w", err)
 	}
 
 	var response spotifyUserResponse
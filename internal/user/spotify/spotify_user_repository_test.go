@@ -5,7 +5,9 @@ import (
 	"errors"
 	types "festwrap/internal"
 	httpsender "festwrap/internal/http/sender"
+	senderrors "festwrap/internal/http/sender/errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -95,6 +97,17 @@ func TestGetCurrentUserReturnsErrorOnSendError(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestGetCurrentUserWrapsSendError(t *testing.T) {
+	sender := &httpsender.FakeHTTPSender{}
+	authErr := senderrors.NewUpstreamError("test error", http.StatusUnauthorized, http.Header{}, "")
+	sender.SetError(authErr)
+	repository := spotifyUserRepository(sender)
+
+	_, err := repository.GetCurrentUserId(testContext())
+
+	assert.ErrorIs(t, err, authErr)
+}
+
 func TestGetCurrentUserReturnsErrorOnNonJsonUserIdBody(t *testing.T) {
 	sender := userIdSender()
 	nonJsonResponse := []byte("{non_json")